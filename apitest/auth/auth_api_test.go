@@ -6,14 +6,16 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/config"
 	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
-	"github.com/Hajime3778/go-clean-arch/infrastructure/env"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/migration"
 	interfaceDB "github.com/Hajime3778/go-clean-arch/interface/database"
 	userRepository "github.com/Hajime3778/go-clean-arch/interface/database/user"
 	authHandler "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/auth"
@@ -28,9 +30,25 @@ const signInURL = "http://localhost:8080/auth/sign_in"
 
 var sqlDriver interfaceDB.SqlDriver
 
+// secretKey サーバーがアクセストークンの署名に使用する鍵
+var secretKey []byte
+
 func TestMain(m *testing.M) {
-	env.NewEnv().LoadEnvFile("../../.env")
-	sqlDriver = database.NewSqlConnenction()
+	// サーバーと同じ設定ファイルを読み込みます
+	appConfig, _, err := config.Load([]string{"--config", "../../.env"})
+	if err != nil {
+		log.Fatalf("config load failed: '%s'", err)
+	}
+	secretKey = appConfig.Auth.SecretKey
+	sqlDriver, err = database.NewSqlConnenction(context.Background(), appConfig.Database)
+	if err != nil {
+		log.Fatalf("database connect failed: '%s'", err)
+	}
+	driver := sqlDriver.(*database.SqlDriver)
+	err = migration.Up(context.Background(), driver.Conn, driver.Dialect())
+	if err != nil {
+		log.Fatalf("migrate failed: '%s'", err)
+	}
 	exitVal := m.Run()
 	os.Exit(exitVal)
 }
@@ -41,7 +59,7 @@ func TestSignUp(t *testing.T) {
 		signUpRequest := authHandler.SignUpRequest{
 			Name:     "test user",
 			Email:    generateRandomEmail(),
-			Password: "password",
+			Password: "password1",
 		}
 		byteRequest, _ := json.Marshal(signUpRequest)
 		req, _ := http.NewRequest("POST", signUpURL, bytes.NewBuffer(byteRequest))
@@ -81,7 +99,7 @@ func TestSignUp(t *testing.T) {
 		createUser := domain.User{
 			Name:     "test user",
 			Email:    email,
-			Password: "password",
+			Password: "password1",
 			Salt:     "salt",
 		}
 		repo.Create(ctx, createUser)
@@ -89,7 +107,7 @@ func TestSignUp(t *testing.T) {
 		signUpRequest := authHandler.SignUpRequest{
 			Name:     "test user",
 			Email:    email,
-			Password: "password",
+			Password: "password1",
 		}
 		byteRequest, _ := json.Marshal(signUpRequest)
 		req, _ := http.NewRequest("POST", signUpURL, bytes.NewBuffer(byteRequest))
@@ -101,7 +119,7 @@ func TestSignUp(t *testing.T) {
 		}
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err = decoder.Decode(&response)
 		if err != nil {
@@ -109,12 +127,12 @@ func TestSignUp(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.Equal(t, domain.ErrorResponse{Message: domain.ErrExistEmail.Error()}, response)
+		assert.Equal(t, domain.ErrExistEmail.Code, response.Code)
 	})
 	t.Run("準正常系 リクエストパラメータが足りていない場合、400エラーとなること", func(t *testing.T) {
 		signUpRequest := authHandler.SignUpRequest{
 			Email:    generateRandomEmail(),
-			Password: "password",
+			Password: "password1",
 		}
 		byteRequest, _ := json.Marshal(signUpRequest)
 		req, _ := http.NewRequest("POST", signUpURL, bytes.NewBuffer(byteRequest))
@@ -126,7 +144,7 @@ func TestSignUp(t *testing.T) {
 		}
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err = decoder.Decode(&response)
 		if err != nil {
@@ -134,11 +152,11 @@ func TestSignUp(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, response.Message)
+		assert.NotEmpty(t, response.Code)
 	})
 	t.Run("準正常系 リクエスト形式が間違っている場合、400エラーとなること", func(t *testing.T) {
-		signUpRequest := domain.ErrorResponse{
-			Message: "foo",
+		signUpRequest := map[string]string{
+			"message": "foo",
 		}
 		byteRequest, _ := json.Marshal(signUpRequest)
 		req, _ := http.NewRequest("POST", signUpURL, bytes.NewBuffer(byteRequest))
@@ -150,7 +168,7 @@ func TestSignUp(t *testing.T) {
 		}
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err = decoder.Decode(&response)
 		if err != nil {
@@ -158,7 +176,7 @@ func TestSignUp(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, response.Message)
+		assert.NotEmpty(t, response.Code)
 	})
 }
 
@@ -198,7 +216,7 @@ func TestSignIn(t *testing.T) {
 	t.Run("準正常系 存在しないEmailの場合、401エラーとなること", func(t *testing.T) {
 		request := authHandler.SignInRequest{
 			Email:    generateRandomEmail(),
-			Password: "password",
+			Password: "password1",
 		}
 		byteRequest, _ := json.Marshal(request)
 		req, _ := http.NewRequest("POST", signInURL, bytes.NewBuffer(byteRequest))
@@ -210,7 +228,7 @@ func TestSignIn(t *testing.T) {
 		}
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err = decoder.Decode(&response)
 		if err != nil {
@@ -218,7 +236,7 @@ func TestSignIn(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
-		assert.Equal(t, domain.ErrorResponse{Message: domain.ErrFailedSignIn.Error()}, response)
+		assert.Equal(t, domain.ErrFailedSignIn.Code, response.Code)
 	})
 	t.Run("準正常系 パスワードが間違っている場合、401エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
@@ -241,7 +259,7 @@ func TestSignIn(t *testing.T) {
 		}
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err = decoder.Decode(&response)
 		if err != nil {
@@ -249,7 +267,7 @@ func TestSignIn(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
-		assert.Equal(t, domain.ErrorResponse{Message: domain.ErrFailedSignIn.Error()}, response)
+		assert.Equal(t, domain.ErrFailedSignIn.Code, response.Code)
 	})
 	t.Run("準正常系 リクエストパラメータが足りていない場合、400エラーとなること", func(t *testing.T) {
 		request := authHandler.SignInRequest{
@@ -265,7 +283,7 @@ func TestSignIn(t *testing.T) {
 		}
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err = decoder.Decode(&response)
 		if err != nil {
@@ -273,11 +291,11 @@ func TestSignIn(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, response.Message)
+		assert.NotEmpty(t, response.Code)
 	})
 	t.Run("準正常系 リクエスト形式が間違っている場合、400エラーとなること", func(t *testing.T) {
-		request := domain.ErrorResponse{
-			Message: "foo",
+		request := map[string]string{
+			"message": "foo",
 		}
 		byteRequest, _ := json.Marshal(request)
 		req, _ := http.NewRequest("POST", signInURL, bytes.NewBuffer(byteRequest))
@@ -289,7 +307,7 @@ func TestSignIn(t *testing.T) {
 		}
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err = decoder.Decode(&response)
 		if err != nil {
@@ -297,13 +315,13 @@ func TestSignIn(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, response.Message)
+		assert.NotEmpty(t, response.Code)
 	})
 }
 
 func getUserIDFromToken(strToken string) int64 {
 	token, _ := jwt.ParseWithClaims(strToken, &domain.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(os.Getenv("SECRET_KEY")), nil
+		return secretKey, nil
 	})
 	claims := token.Claims.(*domain.Claims)
 	return claims.UserID
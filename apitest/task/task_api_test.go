@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"sort"
@@ -13,8 +14,9 @@ import (
 	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/config"
 	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
-	"github.com/Hajime3778/go-clean-arch/infrastructure/env"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/migration"
 	interfaceDB "github.com/Hajime3778/go-clean-arch/interface/database"
 	taskRepository "github.com/Hajime3778/go-clean-arch/interface/database/task"
 	userRepository "github.com/Hajime3778/go-clean-arch/interface/database/user"
@@ -27,9 +29,25 @@ const taskURL = "http://localhost:8080/tasks"
 
 var sqlDriver interfaceDB.SqlDriver
 
+// secretKey サーバーがアクセストークンの署名に使用する鍵
+var secretKey []byte
+
 func TestMain(m *testing.M) {
-	env.NewEnv().LoadEnvFile("../../.env")
-	sqlDriver = database.NewSqlConnenction()
+	// サーバーと同じ設定ファイルを読み込みます
+	appConfig, _, err := config.Load([]string{"--config", "../../.env"})
+	if err != nil {
+		log.Fatalf("config load failed: '%s'", err)
+	}
+	secretKey = appConfig.Auth.SecretKey
+	sqlDriver, err = database.NewSqlConnenction(context.Background(), appConfig.Database)
+	if err != nil {
+		log.Fatalf("database connect failed: '%s'", err)
+	}
+	driver := sqlDriver.(*database.SqlDriver)
+	err = migration.Up(context.Background(), driver.Conn, driver.Dialect())
+	if err != nil {
+		log.Fatalf("migrate failed: '%s'", err)
+	}
 	exitVal := m.Run()
 	os.Exit(exitVal)
 }
@@ -172,7 +190,7 @@ func TestFindByUserID(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -180,7 +198,7 @@ func TestFindByUserID(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 トークンが間違っている場合、401エラーとなること", func(t *testing.T) {
@@ -195,7 +213,7 @@ func TestFindByUserID(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -203,7 +221,7 @@ func TestFindByUserID(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 パラメータが指定されてない場合、400エラーとなること", func(t *testing.T) {
@@ -223,7 +241,7 @@ func TestFindByUserID(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -231,7 +249,7 @@ func TestFindByUserID(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 パラメータの型が間違っている場合、400エラーとなること", func(t *testing.T) {
@@ -251,7 +269,7 @@ func TestFindByUserID(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -259,7 +277,7 @@ func TestFindByUserID(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 }
 
@@ -299,6 +317,46 @@ func TestGetByID(t *testing.T) {
 		assert.Equal(t, createdTask.Content, resTask.Content)
 	})
 
+	t.Run("正常系 作成日時と更新日時が入れ替わらずに返却されること", func(t *testing.T) {
+		ctx := context.TODO()
+		user, token, err := createUser(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		createdTasks, err := createTasks(ctx, 1, user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		createdTask := createdTasks[0]
+
+		// テーブル定義の列順とは関係なく判定できるよう、異なる日時を直接設定します
+		createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+		updatedAt := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+		_, err = sqlDriver.ExecuteContext(ctx, "UPDATE tasks SET created_at = ?, updated_at = ? WHERE id = ?", createdAt, updatedAt, createdTask.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, _ := http.NewRequest("GET", taskURL+"/"+strconv.Itoa(int(createdTask.ID)), nil)
+		req.Header.Set("Authorization", token)
+		client := new(http.Client)
+		response, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+
+		var resTask domain.Task
+		err = json.NewDecoder(response.Body).Decode(&resTask)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.True(t, createdAt.Equal(resTask.CreatedAt), "created_at: %s", resTask.CreatedAt)
+		assert.True(t, updatedAt.Equal(resTask.UpdatedAt), "updated_at: %s", resTask.UpdatedAt)
+	})
+
 	t.Run("準正常系 存在しないIDで検索した際に404エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		_, token, err := createUser(ctx)
@@ -321,7 +379,7 @@ func TestGetByID(t *testing.T) {
 			t.Fatal("成功レスポンスのためテスト失敗")
 		}
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -329,7 +387,42 @@ func TestGetByID(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusNotFound, response.StatusCode)
-		assert.Equal(t, resError.Message, domain.ErrRecordNotFound.Error())
+		assert.Equal(t, domain.ErrRecordNotFound.Code, resError.Code)
+	})
+
+	t.Run("準正常系 他のユーザーのタスクのIDで検索した際に404エラーとなること", func(t *testing.T) {
+		ctx := context.TODO()
+		owner, _, err := createUser(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		createdTasks, err := createTasks(ctx, 1, owner.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, token, err := createUser(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, _ := http.NewRequest("GET", taskURL+"/"+strconv.Itoa(int(createdTasks[0].ID)), nil)
+		req.Header.Set("Authorization", token)
+		client := new(http.Client)
+		response, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+
+		var resError domain.ProblemDetails
+		err = json.NewDecoder(response.Body).Decode(&resError)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		assert.Equal(t, domain.ErrRecordNotFound.Code, resError.Code)
+		assert.NotContains(t, resError.Detail, createdTasks[0].Title)
 	})
 
 	t.Run("準正常系 トークンが指定されてない場合、401エラーとなること", func(t *testing.T) {
@@ -346,7 +439,7 @@ func TestGetByID(t *testing.T) {
 			t.Fatal("成功レスポンスのためテスト失敗")
 		}
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -354,7 +447,7 @@ func TestGetByID(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 トークンが間違っている場合、401エラーとなること", func(t *testing.T) {
@@ -372,7 +465,7 @@ func TestGetByID(t *testing.T) {
 			t.Fatal("成功レスポンスのためテスト失敗")
 		}
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -380,11 +473,11 @@ func TestGetByID(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 
 	})
 
-	t.Run("準正常系 指定されたIDが数字でない場合、400エラーとなること", func(t *testing.T) {
+	t.Run("準正常系 指定されたIDが数字でない場合、ルートに一致せず404エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		_, token, err := createUser(ctx)
 		if err != nil {
@@ -404,14 +497,14 @@ func TestGetByID(t *testing.T) {
 			t.Fatal("成功レスポンスのためテスト失敗")
 		}
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
 			t.Fatal(err)
 		}
-		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		assert.NotEmpty(t, resError.Code)
 	})
 }
 
@@ -471,7 +564,7 @@ func TestCreate(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -479,7 +572,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 トークンが間違っている場合、401エラーとなること", func(t *testing.T) {
@@ -499,7 +592,7 @@ func TestCreate(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -507,7 +600,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 リクエストパラメータが足りていない場合、400エラーとなること", func(t *testing.T) {
@@ -530,7 +623,7 @@ func TestCreate(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -538,7 +631,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 リクエスト形式が間違っている場合、400エラーとなること", func(t *testing.T) {
@@ -547,8 +640,8 @@ func TestCreate(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		createRequest := domain.ErrorResponse{
-			Message: "test",
+		createRequest := map[string]string{
+			"message": "test",
 		}
 		byteRequest, _ := json.Marshal(createRequest)
 		req, _ := http.NewRequest("POST", taskURL, bytes.NewBuffer(byteRequest))
@@ -561,7 +654,7 @@ func TestCreate(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -569,7 +662,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 }
 
@@ -643,17 +736,17 @@ func TestUpdate(t *testing.T) {
 			t.Fatal("成功レスポンスのためテスト失敗")
 		}
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
 			t.Fatal(err)
 		}
 		assert.Equal(t, http.StatusNotFound, response.StatusCode)
-		assert.Equal(t, resError.Message, domain.ErrRecordNotFound.Error())
+		assert.Equal(t, domain.ErrRecordNotFound.Code, resError.Code)
 	})
 
-	t.Run("準正常系 指定されたIDが数字でない場合、400エラーとなること", func(t *testing.T) {
+	t.Run("準正常系 指定されたIDが数字でない場合、ルートに一致せず404エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		_, token, err := createUser(ctx)
 		if err != nil {
@@ -680,14 +773,14 @@ func TestUpdate(t *testing.T) {
 			t.Fatal("成功レスポンスのためテスト失敗")
 		}
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
 			t.Fatal(err)
 		}
-		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 トークンが指定されてない場合、401エラーとなること", func(t *testing.T) {
@@ -707,7 +800,7 @@ func TestUpdate(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -715,7 +808,7 @@ func TestUpdate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 トークンが間違っている場合、401エラーとなること", func(t *testing.T) {
@@ -734,7 +827,7 @@ func TestUpdate(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -742,7 +835,7 @@ func TestUpdate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 リクエストパラメータが足りていない場合、400エラーとなること", func(t *testing.T) {
@@ -776,8 +869,8 @@ func TestUpdate(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		updateRequest := domain.ErrorResponse{
-			Message: "test",
+		updateRequest := map[string]string{
+			"message": "test",
 		}
 		byteRequest, _ := json.Marshal(updateRequest)
 		req, _ := http.NewRequest("PUT", taskURL+"/123", bytes.NewBuffer(byteRequest))
@@ -826,7 +919,7 @@ func TestDelete(t *testing.T) {
 		assert.Equal(t, domain.ErrRecordNotFound, err)
 	})
 
-	t.Run("正常系 存在しないIDを指定した際にエラーとならないこと(204が返却されること)", func(t *testing.T) {
+	t.Run("準正常系 存在しないIDを指定した場合、404エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		_, token, err := createUser(ctx)
 		if err != nil {
@@ -844,7 +937,7 @@ func TestDelete(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		assert.Equal(t, http.StatusNoContent, response.StatusCode)
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
 	})
 
 	t.Run("準正常系 トークンが指定されてない場合、401エラーとなること", func(t *testing.T) {
@@ -857,7 +950,7 @@ func TestDelete(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -865,7 +958,7 @@ func TestDelete(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 トークンが間違っている場合、401エラーとなること", func(t *testing.T) {
@@ -879,7 +972,7 @@ func TestDelete(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
@@ -887,10 +980,10 @@ func TestDelete(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
-	t.Run("準正常系 指定されたIDが数字でない場合、400エラーとなること", func(t *testing.T) {
+	t.Run("準正常系 指定されたIDが数字でない場合、ルートに一致せず404エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		_, token, err := createUser(ctx)
 		if err != nil {
@@ -907,15 +1000,15 @@ func TestDelete(t *testing.T) {
 		}
 		defer response.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(response.Body)
 		err = decoder.Decode(&resError)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		assert.NotEmpty(t, resError.Code)
 	})
 }
 
@@ -933,7 +1026,7 @@ func createUser(ctx context.Context) (domain.User, string, error) {
 	userID, err := userRepo.Create(ctx, user)
 	user.ID = userID
 
-	token := token.GenerateAccessToken(user)
+	token := token.GenerateAccessToken(user, secretKey)
 
 	return user, token, err
 }
@@ -1,52 +1,180 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
-	"github.com/Hajime3778/go-clean-arch/infrastructure/env"
-	taskRepository "github.com/Hajime3778/go-clean-arch/interface/database/task"
-	userRepository "github.com/Hajime3778/go-clean-arch/interface/database/user"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/config"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/logger"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/server"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/storage"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/tracing"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	attachmentHandler "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/attachment"
 	authHandler "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/auth"
+	calendarHandler "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/calendar"
+	healthHandler "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/health"
 	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/middleware"
 	taskHandler "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/task"
+	rateLimiter "github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+	attachmentUsecase "github.com/Hajime3778/go-clean-arch/usecase/attachment"
 	authUsecase "github.com/Hajime3778/go-clean-arch/usecase/auth"
+	calendarUsecase "github.com/Hajime3778/go-clean-arch/usecase/calendar"
 	taskUsecase "github.com/Hajime3778/go-clean-arch/usecase/task"
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/Hajime3778/go-clean-arch/util/metrics"
 )
 
+// metricsPath Prometheusがメトリクスを収集するパス
+const metricsPath string = "/metrics"
+
+// main プロセスを終了するのはここだけとし、終了前にrunの後処理がすべて実行されるようにします
 func main() {
-	env.NewEnv().Init()
-	sqlDriver := database.NewSqlConnenction()
-	middleware := middleware.Middleware
+	err := run(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, errMigrateUsage) {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+		} else {
+			slog.Error("exited with error", "error", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run 設定を読み込み、サーバーまたはサブコマンドを実行します
+// 起動に失敗した場合も、それまでに初期化したトレースの送信や接続の切断を行ってからエラーを返却します
+func run(osArgs []string) error {
+	appConfig, args, err := config.Load(osArgs)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("config load failed: '%w'", err)
+	}
+	logger.Init(appConfig.Log)
+	if len(args) > 0 && args[0] == "migrate" {
+		return runMigrate(appConfig.Database, args[1:])
+	}
+	err = appConfig.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid config: '%w'", err)
+	}
+
+	// SIGINT, SIGTERMを受け取った場合は、処理中のリクエストの完了を待ってから停止します
+	// データベースへの接続を待っている間に受け取った場合は、起動を中止します
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.Init(ctx, appConfig.Tracing)
+	if err != nil {
+		return fmt.Errorf("tracing init failed: '%w'", err)
+	}
+	serverConfig := appConfig.Server
+	defer func() {
+		tracingCtx, cancel := context.WithTimeout(context.Background(), serverConfig.ShutdownTimeout)
+		defer cancel()
+		err := shutdownTracing(tracingCtx)
+		if err != nil {
+			slog.Error("tracing shutdown failed", "error", err)
+		}
+	}()
+	blobStore, err := storage.NewBlobStore(appConfig.BlobStore)
+	if err != nil {
+		return fmt.Errorf("blob store init failed: '%w'", err)
+	}
+	decoder := httpUtil.NewJSONDecoder(serverConfig.MaxJSONBodyBytes)
+	auth := middleware.Auth(appConfig.Auth.SecretKey)
+	cors := middleware.CORS(appConfig.CORS)
+	language := middleware.Language(serverConfig.DefaultLanguage)
+	router := httpUtil.NewRouter(middleware.RequestID, middleware.Tracing, middleware.Logging, middleware.Metrics, cors, language, middleware.Middleware)
 
 	// 認証API
-	userRepository := userRepository.NewUserRepository(sqlDriver)
-	authUsecase := authUsecase.NewAuthUsecase(userRepository)
+	// パスワードの総当たりを防ぐため、IPアドレスとメールアドレスごとにリクエスト数を制限します
+	rateLimitConfig := appConfig.RateLimit
+	repos, err := newRepositories(ctx, appConfig)
+	if err != nil {
+		return fmt.Errorf("repositories init failed: '%w'", err)
+	}
+	defer func() {
+		err := repos.close()
+		if err != nil {
+			slog.Error("repositories close failed", "error", err)
+		}
+	}()
+	limiter := repos.limiter
+	lockout := rateLimiter.NewLockout(repos.lockoutStore, rateLimitConfig.Lockout)
+	signUpPerIP := middleware.RateLimit(limiter, "sign_up_ip", rateLimitConfig.SignUpPerIP, middleware.KeyByIP)
+	signInPerIP := middleware.RateLimit(limiter, "sign_in_ip", rateLimitConfig.SignInPerIP, middleware.KeyByIP)
+	signInPerEmail := middleware.RateLimit(limiter, "sign_in_email", rateLimitConfig.SignInPerEmail, middleware.KeyByJSONField("email", decoder))
 
-	// /auth/sign_up
-	signUpHandler := authHandler.NewAuthHandler(authUsecase).SignUpHandler
-	signUpHandlerFunc := http.HandlerFunc(signUpHandler)
-	http.Handle(authHandler.SignUpPath, middleware(signUpHandlerFunc))
+	authUsecase := authUsecase.WithTracing(authUsecase.NewAuthUsecase(repos.users, lockout, appConfig.Auth.SecretKey))
+	authPathHandler := authHandler.NewAuthHandler(authUsecase, decoder)
 
-	// /auth/sign_in
-	signInHandler := authHandler.NewAuthHandler(authUsecase).SignInHandler
-	signInHandlerFunc := http.HandlerFunc(signInHandler)
-	http.Handle(authHandler.SignInPath, middleware(signInHandlerFunc))
+	router.Handle(http.MethodPost, authHandler.SignUpPath, authPathHandler.SignUpHandler, signUpPerIP)
+	router.Handle(http.MethodPost, authHandler.SignInPath, authPathHandler.SignInHandler, signInPerIP, signInPerEmail)
 
 	// タスクAPI
-	taskRepository := taskRepository.NewTaskRepository(sqlDriver)
-	taskUsecase := taskUsecase.NewTaskUsecase(taskRepository)
-
-	// /tasks
-	taskIndexHandler := taskHandler.NewTaskIndexHandler(taskUsecase).Handler
-	taskIndexHandlerFunc := http.HandlerFunc(taskIndexHandler)
-	http.Handle(taskHandler.TaskIndexPath, middleware(taskIndexHandlerFunc))
-
-	// /tasks/:id
-	taskPathHandler := taskHandler.NewTaskHandler(taskUsecase).Handler
-	taskPathHandlerFunc := http.HandlerFunc(taskPathHandler)
-	http.Handle(taskHandler.TaskPath, middleware(taskPathHandlerFunc))
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	// 集計の日付の境界は、データベースの接続と同じタイムゾーンで計算します
+	location, err := appConfig.Database.Location()
+	if err != nil {
+		return fmt.Errorf("invalid config: '%w'", err)
+	}
+	taskUsecase := taskUsecase.WithTracing(taskUsecase.NewTaskUsecase(repos.tasks, repos.activities, repos.attachments, blobStore, repos.transactor, location))
+	taskIndexHandler := taskHandler.NewTaskIndexHandler(taskUsecase, decoder)
+	taskPathHandler := taskHandler.NewTaskHandler(taskUsecase, decoder)
+	taskHistoryHandler := taskHandler.NewTaskHistoryHandler(taskUsecase)
+	taskStatsHandler := taskHandler.NewTaskStatsHandler(taskUsecase)
+	taskTransferHandler := taskHandler.NewTaskTransferHandler(taskUsecase)
+
+	router.Handle(http.MethodGet, taskHandler.TaskIndexPath, taskIndexHandler.FindByUserID, auth)
+	router.Handle(http.MethodPost, taskHandler.TaskIndexPath, taskIndexHandler.Create, auth)
+	router.Handle(http.MethodGet, taskHandler.TaskPath, taskPathHandler.GetByID, auth)
+	router.Handle(http.MethodPut, taskHandler.TaskPath, taskPathHandler.Update, auth)
+	router.Handle(http.MethodDelete, taskHandler.TaskPath, taskPathHandler.Delete, auth)
+	router.Handle(http.MethodGet, taskHandler.TaskHistoryPath, taskHistoryHandler.History, auth)
+	router.Handle(http.MethodGet, taskHandler.TaskStatsPath, taskStatsHandler.Handler, auth)
+	router.Handle(http.MethodGet, taskHandler.TaskExportPath, taskTransferHandler.ExportHandler, auth)
+	router.Handle(http.MethodPost, taskHandler.TaskImportPath, taskTransferHandler.ImportHandler, auth)
+
+	// 添付ファイルAPI
+	attachmentUsecase := attachmentUsecase.WithTracing(attachmentUsecase.NewAttachmentUsecase(repos.tasks, repos.attachments, blobStore))
+	attachmentPathHandler := attachmentHandler.NewAttachmentHandler(attachmentUsecase)
+
+	router.Handle(http.MethodGet, attachmentHandler.AttachmentIndexPath, attachmentPathHandler.FindByTaskID, auth)
+	router.Handle(http.MethodPost, attachmentHandler.AttachmentIndexPath, attachmentPathHandler.Upload, auth)
+	router.Handle(http.MethodGet, attachmentHandler.AttachmentPath, attachmentPathHandler.Download, auth)
+	router.Handle(http.MethodDelete, attachmentHandler.AttachmentPath, attachmentPathHandler.Delete, auth)
+
+	// カレンダーフィードAPI
+	calendarUsecase := calendarUsecase.WithTracing(calendarUsecase.NewCalendarUsecase(repos.calendarFeeds, repos.tasks))
+	calendarPathHandler := calendarHandler.NewCalendarHandler(calendarUsecase)
+
+	// /calendar/token は /calendar/{feed} より優先されます
+	router.Handle(http.MethodGet, calendarHandler.CalendarFeedPath, calendarPathHandler.FeedHandler)
+	router.Handle(http.MethodGet, calendarHandler.CalendarTokenPath, calendarPathHandler.GetToken, auth)
+	router.Handle(http.MethodPost, calendarHandler.CalendarTokenPath, calendarPathHandler.RotateToken, auth)
+
+	// 死活監視API
+	// オーケストレーターから認証なしで参照されるため、認証は行いません
+	healthPathHandler := healthHandler.NewHealthHandler(repos.dependencies...)
+
+	router.Handle(http.MethodGet, healthHandler.LivenessPath, healthPathHandler.Liveness)
+	router.Handle(http.MethodGet, healthHandler.ReadinessPath, healthPathHandler.Readiness)
+
+	// メトリクスAPI
+	router.Handle(http.MethodGet, metricsPath, metrics.Handler().ServeHTTP)
+
+	srv := server.NewServer(serverConfig, router)
+	slog.Info("server started", "addr", serverConfig.Addr)
+	err = server.Run(ctx, srv, serverConfig, healthPathHandler.Shutdown)
+	if err != nil {
+		return fmt.Errorf("server stopped with error: '%w'", err)
+	}
+	return nil
 }
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/migration"
+)
+
+// migrateUsage migrateサブコマンドの使い方
+const migrateUsage = `usage: engine [flags] migrate <command>
+
+commands:
+  up            未適用のマイグレーションをすべて適用します
+  down [N]      適用済みのマイグレーションを新しいものからN件(省略時は1件)取り消します
+  status        マイグレーションの適用状況を表示します
+  to <version>  指定したバージョンまで適用、またはそれより新しいマイグレーションを取り消します`
+
+// errMigrateUsage migrateサブコマンドの引数が不正な場合のエラー
+var errMigrateUsage = errors.New(migrateUsage)
+
+// runMigrate migrateサブコマンドを実行します
+func runMigrate(dbConfig database.Config, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+	err := dbConfig.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid config: '%w'", err)
+	}
+
+	ctx := context.Background()
+	db, err := database.Open(ctx, dbConfig)
+	if err != nil {
+		return fmt.Errorf("database open failed: '%w'", err)
+	}
+	defer db.Close()
+	migrations, err := migration.Migrations(db.Dialect())
+	if err != nil {
+		return fmt.Errorf("migrations load failed: '%w'", err)
+	}
+	migrator := migration.NewMigrator(db.Conn, db.Dialect(), migrations)
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid steps: '%s'", args[1])
+			}
+		}
+		err = migrator.Down(ctx, steps)
+	case "to":
+		if len(args) < 2 {
+			return errMigrateUsage
+		}
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil || version < 0 {
+			return fmt.Errorf("invalid version: '%s'", args[1])
+		}
+		err = migrator.To(ctx, version)
+	case "status":
+		err = printStatus(ctx, migrator)
+	default:
+		return errMigrateUsage
+	}
+	if err != nil {
+		return fmt.Errorf("migrate %s failed: '%w'", args[0], err)
+	}
+	return nil
+}
+
+// printStatus マイグレーションの適用状況を表形式で出力します
+func printStatus(ctx context.Context, migrator *migration.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		if status.Applied {
+			state, appliedAt = "applied", status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if status.Dirty {
+			state = "dirty"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
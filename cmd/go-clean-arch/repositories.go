@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/config"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	infraRateLimit "github.com/Hajime3778/go-clean-arch/infrastructure/ratelimit"
+	interfaceDB "github.com/Hajime3778/go-clean-arch/interface/database"
+	activityRepository "github.com/Hajime3778/go-clean-arch/interface/database/activity"
+	attachmentRepository "github.com/Hajime3778/go-clean-arch/interface/database/attachment"
+	calendarRepository "github.com/Hajime3778/go-clean-arch/interface/database/calendar"
+	taskRepository "github.com/Hajime3778/go-clean-arch/interface/database/task"
+	userRepository "github.com/Hajime3778/go-clean-arch/interface/database/user"
+	healthHandler "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/health"
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+)
+
+// repositories APIが使用するリポジトリと、その保存先
+type repositories struct {
+	users         userRepository.UserRepository
+	tasks         taskRepository.TaskRepository
+	activities    activityRepository.ActivityRepository
+	attachments   attachmentRepository.AttachmentRepository
+	calendarFeeds calendarRepository.CalendarFeedRepository
+	transactor    interfaceDB.Transactor
+	limiter       ratelimit.Limiter
+	lockoutStore  ratelimit.LockoutStore
+	// dependencies 死活監視で確認する依存先
+	dependencies []healthHandler.Dependency
+	// close 停止時に保存先の接続を閉じます
+	close func() error
+}
+
+// newRepositories: appConfig.Storage で指定された保存先のリポジトリを作成します
+func newRepositories(ctx context.Context, appConfig config.Config) (repositories, error) {
+	rateLimitConfig := appConfig.RateLimit
+	switch appConfig.Storage {
+	case config.STORAGE_DATABASE:
+		sqlDriver, err := database.NewSqlConnenction(ctx, appConfig.Database)
+		if err != nil {
+			return repositories{}, err
+		}
+		limiter, err := infraRateLimit.NewLimiter(sqlDriver, rateLimitConfig)
+		if err != nil {
+			sqlDriver.Close()
+			return repositories{}, err
+		}
+		lockoutStore, err := infraRateLimit.NewLockoutStore(sqlDriver, rateLimitConfig)
+		if err != nil {
+			sqlDriver.Close()
+			return repositories{}, err
+		}
+		return repositories{
+			users:         userRepository.NewUserRepository(sqlDriver),
+			tasks:         taskRepository.NewTaskRepository(sqlDriver),
+			activities:    activityRepository.NewActivityRepository(sqlDriver),
+			attachments:   attachmentRepository.NewAttachmentRepository(sqlDriver),
+			calendarFeeds: calendarRepository.NewCalendarFeedRepository(sqlDriver),
+			transactor:    sqlDriver,
+			limiter:       limiter,
+			lockoutStore:  lockoutStore,
+			dependencies:  []healthHandler.Dependency{{Name: string(sqlDriver.Dialect()), Check: sqlDriver.PingContext}},
+			close:         sqlDriver.Close,
+		}, nil
+	case config.STORAGE_MEMORY:
+		users := userRepository.NewMemoryUserRepository()
+		tasks := taskRepository.NewMemoryTaskRepository()
+		activities := activityRepository.NewMemoryActivityRepository()
+		attachments := attachmentRepository.NewMemoryAttachmentRepository()
+		calendarFeeds := calendarRepository.NewMemoryCalendarFeedRepository()
+		// トランザクションがエラーとなった場合に、すべてのRepositoryの変更を取り消します
+		transactor := interfaceDB.NewMemoryTransactor(
+			users.(interfaceDB.Snapshotter),
+			tasks.(interfaceDB.Snapshotter),
+			activities.(interfaceDB.Snapshotter),
+			attachments.(interfaceDB.Snapshotter),
+			calendarFeeds.(interfaceDB.Snapshotter),
+		)
+		// データベースに接続しないため、レート制限も RATE_LIMIT_STORE に関わらずメモリに保持します
+		return repositories{
+			users:         users,
+			tasks:         tasks,
+			activities:    activities,
+			attachments:   attachments,
+			calendarFeeds: calendarFeeds,
+			transactor:    transactor,
+			limiter:       infraRateLimit.NewMemoryLimiter(),
+			lockoutStore:  infraRateLimit.NewMemoryLockoutStore(rateLimitConfig.Lockout.ResetAfter),
+			close:         func() error { return nil },
+		}, nil
+	default:
+		return repositories{}, fmt.Errorf("unknown storage: '%s'", appConfig.Storage)
+	}
+}
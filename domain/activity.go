@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+const (
+	ActivityCreate = "create"
+	ActivityUpdate = "update"
+	ActivityDelete = "delete"
+)
+
+// TaskActivity タスクの変更履歴
+type TaskActivity struct {
+	ID        int64         `json:"id"`
+	TaskID    int64         `json:"task_id"`
+	ActorID   int64         `json:"actor_id"`
+	Action    string        `json:"action"`
+	Changes   []FieldChange `json:"changes"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// FieldChange 変更された項目の変更前後の値(作成時のBefore, 削除時のAfterはnull)
+type FieldChange struct {
+	Field  string  `json:"field"`
+	Before *string `json:"before"`
+	After  *string `json:"after"`
+}
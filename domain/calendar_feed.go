@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// CalendarFeed ユーザーごとのiCalendarフィードの公開設定
+type CalendarFeed struct {
+	UserID    int64     `json:"user_id"`
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
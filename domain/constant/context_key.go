@@ -1,8 +0,0 @@
-package constant
-
-type key int
-
-const (
-	UserIDContextKey    key = 0
-	AuthTokenContextKey key = 1
-)
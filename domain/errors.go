@@ -1,15 +1,178 @@
 package domain
 
-import "errors"
+import (
+	"net/http"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
+)
 
 var (
-	ErrInternalServerError = errors.New("internal server error")
-	ErrRecordNotFound      = errors.New("record not found")
-	ErrBadRequest          = errors.New("bad request")
-	ErrExistEmail          = errors.New("exist email")
-	ErrFailedSignIn        = errors.New("mismatched email or password")
+	ErrInternalServerError  = NewAppError("internal_server_error", http.StatusInternalServerError, "internal server error")
+	ErrRecordNotFound       = NewAppError("not_found", http.StatusNotFound, "record not found")
+	ErrBadRequest           = NewAppError("bad_request", http.StatusBadRequest, "bad request")
+	ErrValidation           = NewAppError("validation_failed", http.StatusBadRequest, "request validation failed")
+	ErrInvalidJSON          = NewAppError("invalid_json", http.StatusBadRequest, "request body contains invalid JSON")
+	ErrExistEmail           = NewAppError("email_already_exists", http.StatusBadRequest, "exist email")
+	ErrFailedSignIn         = NewAppError("invalid_credentials", http.StatusUnauthorized, "mismatched email or password")
+	ErrUnauthorized         = NewAppError("unauthorized", http.StatusUnauthorized, "unauthorized")
+	ErrMethodNotAllowed     = NewAppError("method_not_allowed", http.StatusMethodNotAllowed, "method not allowed")
+	ErrFileTooLarge         = NewAppError("payload_too_large", http.StatusRequestEntityTooLarge, "file too large")
+	ErrUnsupportedMediaType = NewAppError("unsupported_media_type", http.StatusUnsupportedMediaType, "unsupported media type")
+	ErrTooManyRequests      = NewAppError("too_many_requests", http.StatusTooManyRequests, "too many requests")
 )
 
-type ErrorResponse struct {
+// AppError クライアントに返却するアプリケーションのエラー
+// Codeが同じAppErrorはerrors.Isで同じエラーとして判定されるため、
+// WithMessageやWrapで作成したエラーも元のエラー変数と比較できます
+// Messageはログ用の英語のメッセージで、クライアントにはMessageKeyで翻訳したメッセージを返却します
+type AppError struct {
+	// Code エラーの種類を表す機械可読なコード
+	Code string
+	// Status クライアントに返却するHttpStatusCode
+	Status int
+	// Message クライアントに返却できる英語のメッセージ
+	Message string
+	// MessageKey メッセージカタログのキー。空の場合はMessageをそのまま返却します
+	MessageKey string
+	// MessageArgs メッセージに埋め込む値
+	MessageArgs []interface{}
+	// Details フィールドごとのエラーなどの詳細
+	Details []ErrorDetail
+	// Err エラーの原因。ログにのみ出力され、クライアントには返却しません
+	Err error
+}
+
+// ErrorDetail エラーの詳細
+type ErrorDetail struct {
+	Field   string `json:"field,omitempty"`
+	Rule    string `json:"rule,omitempty"`
 	Message string `json:"message"`
+	// MessageKey, MessageArgs Messageを翻訳するためのメッセージカタログのキーと埋め込む値
+	MessageKey  string        `json:"-"`
+	MessageArgs []interface{} `json:"-"`
+}
+
+// NewAppError AppErrorを作成します
+// メッセージカタログのキーは "error." + code となります
+func NewAppError(code string, status int, message string) *AppError {
+	return &AppError{Code: code, Status: status, Message: message, MessageKey: "error." + code}
+}
+
+// NewErrorDetail メッセージカタログのキーから、英語のメッセージを持つErrorDetailを作成します
+func NewErrorDetail(field string, rule string, key string, args ...interface{}) ErrorDetail {
+	message, _ := i18n.Translate(i18n.English, key, args...)
+	return ErrorDetail{Field: field, Rule: rule, Message: message, MessageKey: key, MessageArgs: args}
+}
+
+// Localize メッセージをlangに翻訳したErrorDetailを返却します
+func (d ErrorDetail) Localize(lang i18n.Language) ErrorDetail {
+	if d.MessageKey == "" {
+		return d
+	}
+	if message, ok := i18n.Translate(lang, d.MessageKey, d.MessageArgs...); ok {
+		d.Message = message
+	}
+	return d
+}
+
+func (e *AppError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+// WithMessage メッセージを差し替えたエラーを作成します
+// メッセージは翻訳されないため、翻訳が必要な場合はWithMessageKeyを使用してください
+func (e *AppError) WithMessage(message string) *AppError {
+	c := *e
+	c.Message = message
+	c.MessageKey = ""
+	c.MessageArgs = nil
+	return &c
+}
+
+// WithMessageKey メッセージをメッセージカタログのキーで差し替えたエラーを作成します
+func (e *AppError) WithMessageKey(key string, args ...interface{}) *AppError {
+	c := *e
+	c.Message, _ = i18n.Translate(i18n.English, key, args...)
+	c.MessageKey = key
+	c.MessageArgs = args
+	return &c
+}
+
+// LocalizedMessage メッセージをlangに翻訳して返却します
+// メッセージカタログにキーがない場合はMessageを返却します
+func (e *AppError) LocalizedMessage(lang i18n.Language) string {
+	if e.MessageKey == "" {
+		return e.Message
+	}
+	message, ok := i18n.Translate(lang, e.MessageKey, e.MessageArgs...)
+	if !ok {
+		return e.Message
+	}
+	return message
+}
+
+// LocalizedDetails 詳細のメッセージをlangに翻訳して返却します
+func (e *AppError) LocalizedDetails(lang i18n.Language) []ErrorDetail {
+	if e.Details == nil {
+		return nil
+	}
+	details := make([]ErrorDetail, 0, len(e.Details))
+	for _, detail := range e.Details {
+		details = append(details, detail.Localize(lang))
+	}
+	return details
+}
+
+// WithDetails 詳細を追加したエラーを作成します
+func (e *AppError) WithDetails(details ...ErrorDetail) *AppError {
+	c := *e
+	c.Details = append(append([]ErrorDetail{}, e.Details...), details...)
+	return &c
+}
+
+// Wrap 原因となったエラーを保持したエラーを作成します
+func (e *AppError) Wrap(err error) *AppError {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// RateLimitError リクエスト数の制限やアカウントのロックにより、リクエストを受け付けられない場合のエラー
+// errors.Is(err, ErrTooManyRequests) で判定できます
+type RateLimitError struct {
+	// RetryAfter 再試行できるようになるまでの時間
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return ErrTooManyRequests.Error()
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrTooManyRequests
+}
+
+// ProblemDetails RFC 7807 形式のエラーレスポンス
+type ProblemDetails struct {
+	Type      string        `json:"type"`
+	Title     string        `json:"title"`
+	Status    int           `json:"status"`
+	Detail    string        `json:"detail,omitempty"`
+	Instance  string        `json:"instance,omitempty"`
+	Code      string        `json:"code"`
+	Errors    []ErrorDetail `json:"errors,omitempty"`
+	RequestID string        `json:"request_id,omitempty"`
 }
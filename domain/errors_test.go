@@ -0,0 +1,71 @@
+package domain_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppError(t *testing.T) {
+	t.Run("正常系 メッセージや原因を変えたエラーも元のエラーとしてerrors.Isで判定できること", func(t *testing.T) {
+		cause := errors.New("no rows")
+		err := fmt.Errorf("find task: %w", domain.ErrRecordNotFound.WithMessage("task not found").Wrap(cause))
+
+		assert.True(t, errors.Is(err, domain.ErrRecordNotFound))
+		assert.True(t, errors.Is(err, cause))
+		assert.False(t, errors.Is(err, domain.ErrBadRequest))
+		assert.Equal(t, "record not found", domain.ErrRecordNotFound.Message)
+	})
+
+	t.Run("正常系 原因を含むメッセージがError()で返却されること", func(t *testing.T) {
+		err := domain.ErrBadRequest.Wrap(errors.New("invalid syntax"))
+		assert.Equal(t, "bad request: invalid syntax", err.Error())
+	})
+
+	t.Run("正常系 WithDetailsで元のエラーの詳細が変更されないこと", func(t *testing.T) {
+		base := domain.ErrValidation.WithDetails(domain.ErrorDetail{Field: "a", Message: "is required"})
+		base.WithDetails(domain.ErrorDetail{Field: "b", Message: "is required"})
+
+		assert.Len(t, base.Details, 1)
+		assert.Empty(t, domain.ErrValidation.Details)
+	})
+
+	t.Run("正常系 RateLimitErrorがErrTooManyRequestsとして判定されること", func(t *testing.T) {
+		var appErr *domain.AppError
+		err := &domain.RateLimitError{}
+
+		assert.True(t, errors.Is(err, domain.ErrTooManyRequests))
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, domain.ErrTooManyRequests.Code, appErr.Code)
+	})
+
+	t.Run("正常系 WithMessageKeyのメッセージがログ用には英語で、指定した言語に翻訳されること", func(t *testing.T) {
+		err := domain.ErrFileTooLarge.WithMessageKey("request.body_too_large", 1024)
+
+		assert.Equal(t, "request body must not be larger than 1024 bytes", err.Error())
+		assert.Equal(t, "リクエストボディは1024バイト以下にしてください", err.LocalizedMessage(i18n.Japanese))
+		assert.True(t, errors.Is(err, domain.ErrFileTooLarge))
+	})
+
+	t.Run("正常系 WithMessageで差し替えたメッセージは翻訳されないこと", func(t *testing.T) {
+		err := domain.ErrBadRequest.WithMessage("unexpected EOF")
+		assert.Equal(t, "unexpected EOF", err.LocalizedMessage(i18n.Japanese))
+	})
+
+	t.Run("正常系 すべてのエラーの英語のメッセージがメッセージカタログと一致すること", func(t *testing.T) {
+		errs := []*domain.AppError{
+			domain.ErrInternalServerError, domain.ErrRecordNotFound, domain.ErrBadRequest, domain.ErrValidation,
+			domain.ErrInvalidJSON, domain.ErrExistEmail, domain.ErrFailedSignIn, domain.ErrUnauthorized,
+			domain.ErrMethodNotAllowed, domain.ErrFileTooLarge, domain.ErrUnsupportedMediaType, domain.ErrTooManyRequests,
+		}
+		for _, err := range errs {
+			assert.Equal(t, err.Message, err.LocalizedMessage(i18n.English), err.Code)
+			_, ok := i18n.Translate(i18n.Japanese, err.MessageKey)
+			assert.True(t, ok, err.Code)
+		}
+	})
+}
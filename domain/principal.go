@@ -0,0 +1,34 @@
+package domain
+
+import "context"
+
+// Principal 認証済みのリクエストの利用者
+type Principal struct {
+	UserID int64
+	Token  string
+}
+
+// principalKey Principalをcontextに保持する際のキー
+type principalKey struct{}
+
+// WithPrincipal 認証済みの利用者をcontextに設定します
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext contextから認証済みの利用者を取得します
+// 設定されていない場合、okはfalseとなります
+func PrincipalFromContext(ctx context.Context) (principal Principal, ok bool) {
+	principal, ok = ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// UserIDFromContext contextから認証済みの利用者のユーザーIDを取得します
+// 設定されていない場合はErrUnauthorizedを返却します
+func UserIDFromContext(ctx context.Context) (int64, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return 0, ErrUnauthorized
+	}
+	return principal.UserID, nil
+}
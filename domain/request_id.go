@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+// requestIDKey リクエストIDをcontextに保持する際のキー
+type requestIDKey struct{}
+
+// WithRequestID リクエストIDをcontextに設定します
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext contextからリクエストIDを取得します
+// 設定されていない場合は空文字を返却します
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
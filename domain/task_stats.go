@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// DateLayout 日付のみを表す形式
+const DateLayout = "2006-01-02"
+
+// TaskStats ユーザーのタスクの集計結果
+type TaskStats struct {
+	ByStatus        TaskStatusCounts `json:"by_status"`
+	Overdue         int64            `json:"overdue"`
+	DueToday        int64            `json:"due_today"`
+	DueThisWeek     int64            `json:"due_this_week"`
+	CompletionTrend []DailyCount     `json:"completion_trend"`
+	// AverageCompletionSeconds 作成から完了までの平均秒数(完了したタスクがない場合はnull)
+	AverageCompletionSeconds *float64 `json:"average_completion_seconds"`
+}
+
+// TaskStatusCounts 状態ごとのタスクの件数
+type TaskStatusCounts struct {
+	Open      int64 `json:"open"`
+	Completed int64 `json:"completed"`
+}
+
+// DailyCount 日ごとの件数(Dateは YYYY-MM-DD 形式)
+type DailyCount struct {
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
+
+// StatsPeriod 集計に使用する日時の境界
+// 未完了のタスクのうち、DueDateが Now より前を期限切れ、
+// [TodayStart, TomorrowStart) を今日期限、[WeekStart, WeekEnd) を今週期限として集計し、
+// CompletedAtが [TrendStart, TomorrowStart) のタスクを日ごとの完了数として集計します
+type StatsPeriod struct {
+	Now           time.Time
+	TodayStart    time.Time
+	TomorrowStart time.Time
+	WeekStart     time.Time
+	WeekEnd       time.Time
+	TrendStart    time.Time
+}
@@ -0,0 +1,183 @@
+// Package config アプリケーションの設定を読み込み、検証します
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/logger"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/ratelimit"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/server"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/storage"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/tracing"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/middleware"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+	"github.com/joho/godotenv"
+)
+
+const (
+	// STORAGE_DATABASE DB_DRIVER で接続したデータベースにデータを保存します
+	STORAGE_DATABASE = "database"
+	// STORAGE_MEMORY プロセス内のメモリにデータを保存します。停止するとデータは破棄されるため、デモ用です
+	STORAGE_MEMORY = "memory"
+)
+
+// minSecretKeyLength SECRET_KEY の最小のバイト数
+// HS256の署名に使用するため、ハッシュの出力と同じ256bit以上とします
+const minSecretKeyLength = 32
+
+// Config アプリケーションの設定
+type Config struct {
+	// Storage データの保存先
+	Storage string
+	// Database データベースの接続設定
+	Database database.Config
+	// Auth 認証の設定
+	Auth AuthConfig
+	// Server HTTPサーバーの設定
+	Server server.Config
+	// RateLimit サインイン・サインアップのレート制限の設定
+	RateLimit ratelimit.Config
+	// CORS CORSの設定
+	CORS middleware.CORSConfig
+	// BlobStore 添付ファイルの保存先の設定
+	BlobStore storage.Config
+	// Tracing トレースの設定
+	Tracing tracing.Config
+	// Log ログの設定
+	Log logger.Config
+}
+
+// AuthConfig 認証の設定
+type AuthConfig struct {
+	// SecretKey アクセストークンの署名に使用する鍵
+	SecretKey []byte
+}
+
+// flagKeys コマンドライン引数と、同じ項目を指定する環境変数
+var flagKeys = []struct {
+	name  string
+	key   string
+	usage string
+}{
+	{"storage", "STORAGE", "データの保存先 (database: DB_DRIVER のデータベース, memory: プロセス内のメモリ)"},
+	{"db-driver", "DB_DRIVER", "接続するデータベース (mysql, postgres, sqlite)"},
+	{"db-host", "DB_HOST", "MySQL, Postgresのホスト"},
+	{"db-port", "DB_PORT", "MySQL, Postgresのポート"},
+	{"db-user", "DB_USER", "MySQL, Postgresのユーザー"},
+	{"db-name", "DB_NAME", "MySQL, Postgresのデータベース名"},
+	{"db-path", "DB_PATH", "SQLiteのファイル"},
+	{"db-timezone", "DB_TIMEZONE", "MySQL, Postgresの接続で日時を扱うタイムゾーン"},
+}
+
+// Load: 設定ファイル、環境変数、コマンドライン引数から設定を読み込みます
+// 同じ項目はコマンドライン引数、環境変数、設定ファイルの順に優先されます
+//
+// 設定ファイルはdotenv形式で、--config または環境変数 CONFIG_FILE で指定した場合のみ読み込みます
+// 設定ファイルの値はプロセスの環境変数には設定しません
+// パスワードなどの秘密情報はプロセスの一覧から参照できないよう、コマンドライン引数では指定できません
+// 戻り値の[]stringは、フラグ以外のコマンドライン引数です
+func Load(arguments []string) (Config, []string, error) {
+	fs := flag.NewFlagSet("engine", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "dotenv形式の設定ファイル")
+	flags := make(map[string]*string, len(flagKeys))
+	for _, f := range flagKeys {
+		flags[f.key] = fs.String(f.name, "", f.usage)
+	}
+	err := fs.Parse(arguments)
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	fileValues := map[string]string{}
+	if *configFile != "" {
+		fileValues, err = godotenv.Read(*configFile)
+		if err != nil {
+			return Config{}, nil, fmt.Errorf("config file load failed: %w", err)
+		}
+	}
+
+	config, err := Parse(func(key string) string {
+		if value, ok := flags[key]; ok && *value != "" {
+			return *value
+		}
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+		return fileValues[key]
+	})
+	if err != nil {
+		return Config{}, nil, err
+	}
+	return config, fs.Args(), nil
+}
+
+// Parse: 設定値からアプリケーションの設定を読み込みます
+// 値の形式が不正な場合はエラーを返却します。値の範囲や組み合わせは Validate で検証します
+func Parse(lookup envconfig.Lookup) (Config, error) {
+	var config Config
+	var err error
+	p := envconfig.NewParser(lookup)
+	config.Storage = p.String("STORAGE", STORAGE_DATABASE)
+	config.Auth.SecretKey = []byte(p.String("SECRET_KEY", ""))
+
+	if config.Database, err = database.LoadConfig(lookup); err != nil {
+		return Config{}, err
+	}
+	if config.Server, err = server.LoadConfig(lookup); err != nil {
+		return Config{}, err
+	}
+	if config.RateLimit, err = ratelimit.LoadConfig(lookup); err != nil {
+		return Config{}, err
+	}
+	if config.CORS, err = middleware.LoadCORSConfig(lookup); err != nil {
+		return Config{}, err
+	}
+	if config.BlobStore, err = storage.LoadConfig(lookup); err != nil {
+		return Config{}, err
+	}
+	if config.Tracing, err = tracing.LoadConfig(lookup); err != nil {
+		return Config{}, err
+	}
+	if config.Log, err = logger.LoadConfig(lookup); err != nil {
+		return Config{}, err
+	}
+	return config, p.Err()
+}
+
+// Validate: 起動に必要な設定がされているかを検証します
+// データをメモリに保存する場合は、データベースの接続設定は検証しません
+func (c Config) Validate() error {
+	switch c.Storage {
+	case STORAGE_DATABASE:
+		err := c.Database.Validate()
+		if err != nil {
+			return err
+		}
+	case STORAGE_MEMORY:
+	default:
+		return fmt.Errorf("invalid STORAGE: '%s'", c.Storage)
+	}
+	validators := []interface{ Validate() error }{c.Auth, c.Server, c.RateLimit, c.CORS, c.BlobStore, c.Tracing}
+	for _, v := range validators {
+		err := v.Validate()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate: アクセストークンを安全に署名できる鍵が設定されているかを検証します
+func (c AuthConfig) Validate() error {
+	if len(c.SecretKey) == 0 {
+		return errors.New("SECRET_KEY is required")
+	}
+	if len(c.SecretKey) < minSecretKeyLength {
+		return fmt.Errorf("SECRET_KEY must be at least %d bytes", minSecretKeyLength)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/config"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	interfaceDB "github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+// configKeys テストで使用する環境変数
+var configKeys = []string{"CONFIG_FILE", "STORAGE", "DB_DRIVER", "DB_HOST", "DB_PORT", "DB_USER", "DB_PASS", "DB_NAME", "DB_PATH", "DB_SSLMODE", "DB_TIMEZONE", "DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME", "DB_CONNECT_TIMEOUT", "SECRET_KEY", "SERVER_READ_TIMEOUT", "BLOB_STORE"}
+
+// unsetEnv 環境変数を未設定にし、テストの終了時に元に戻します
+func unsetEnv(t *testing.T) {
+	for _, key := range configKeys {
+		key := key
+		old, ok := os.LookupEnv(key)
+		os.Unsetenv(key)
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(key, old)
+			} else {
+				os.Unsetenv(key)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("正常系 未設定の場合、デフォルト値となること", func(t *testing.T) {
+		unsetEnv(t)
+		appConfig, args, err := config.Load(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, args)
+		assert.Equal(t, config.STORAGE_DATABASE, appConfig.Storage)
+		assert.Equal(t, interfaceDB.MySQL, appConfig.Database.Driver)
+		assert.Equal(t, "go_clean_arch.db", appConfig.Database.Path)
+		assert.Equal(t, "disable", appConfig.Database.SSLMode)
+		assert.Equal(t, "Asia/Tokyo", appConfig.Database.TimeZone)
+		assert.Equal(t, 25, appConfig.Database.MaxOpenConns)
+		assert.Equal(t, 25, appConfig.Database.MaxIdleConns)
+		assert.Equal(t, 5*time.Minute, appConfig.Database.ConnMaxLifetime)
+		assert.Equal(t, 30*time.Second, appConfig.Database.ConnectTimeout)
+		assert.Empty(t, appConfig.Auth.SecretKey)
+	})
+
+	t.Run("正常系 コネクションプールの設定が読み込まれること", func(t *testing.T) {
+		unsetEnv(t)
+		t.Setenv("DB_MAX_OPEN_CONNS", "10")
+		t.Setenv("DB_MAX_IDLE_CONNS", "5")
+		t.Setenv("DB_CONN_MAX_LIFETIME", "1m")
+		t.Setenv("DB_CONNECT_TIMEOUT", "1m30s")
+
+		appConfig, _, err := config.Load(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, appConfig.Database.MaxOpenConns)
+		assert.Equal(t, 5, appConfig.Database.MaxIdleConns)
+		assert.Equal(t, time.Minute, appConfig.Database.ConnMaxLifetime)
+		assert.Equal(t, 90*time.Second, appConfig.Database.ConnectTimeout)
+	})
+
+	t.Run("異常系 コネクションプールの設定が不正な場合、エラーとなること", func(t *testing.T) {
+		unsetEnv(t)
+		t.Setenv("DB_CONNECT_TIMEOUT", "30")
+
+		_, _, err := config.Load(nil)
+		assert.EqualError(t, err, "invalid DB_CONNECT_TIMEOUT: '30'")
+	})
+
+	t.Run("正常系 コマンドライン引数、環境変数、設定ファイルの順に優先されること", func(t *testing.T) {
+		unsetEnv(t)
+		path := filepath.Join(t.TempDir(), "app.env")
+		err := os.WriteFile(path, []byte("DB_HOST=file-host\nDB_NAME=file_db\nDB_USER=file_user\nSECRET_KEY=file_secret_key_0123456789abcdef\n"), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("DB_HOST", "env-host")
+		t.Setenv("DB_NAME", "env_db")
+
+		appConfig, args, err := config.Load([]string{"--config", path, "--db-name", "flag_db", "migrate", "up"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"migrate", "up"}, args)
+		assert.Equal(t, "flag_db", appConfig.Database.Name)
+		assert.Equal(t, "env-host", appConfig.Database.Host)
+		assert.Equal(t, "file_user", appConfig.Database.User)
+		assert.Equal(t, []byte("file_secret_key_0123456789abcdef"), appConfig.Auth.SecretKey)
+		_, ok := os.LookupEnv("DB_USER")
+		assert.False(t, ok, "設定ファイルの値は環境変数に設定されないこと")
+	})
+
+	t.Run("異常系 設定ファイルの値の形式が不正な場合、エラーとなること", func(t *testing.T) {
+		unsetEnv(t)
+		path := filepath.Join(t.TempDir(), "app.env")
+		err := os.WriteFile(path, []byte("SERVER_READ_TIMEOUT=30\n"), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err = config.Load([]string{"--config", path})
+		assert.EqualError(t, err, "invalid SERVER_READ_TIMEOUT: '30'")
+	})
+
+	t.Run("正常系 環境変数 CONFIG_FILE の設定ファイルが読み込まれること", func(t *testing.T) {
+		unsetEnv(t)
+		path := filepath.Join(t.TempDir(), "app.env")
+		err := os.WriteFile(path, []byte("STORAGE=memory\n"), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("CONFIG_FILE", path)
+
+		appConfig, _, err := config.Load(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, config.STORAGE_MEMORY, appConfig.Storage)
+	})
+
+	t.Run("異常系 設定ファイルが存在しない場合、エラーとなること", func(t *testing.T) {
+		unsetEnv(t)
+		_, _, err := config.Load([]string{"--config", filepath.Join(t.TempDir(), "notfound.env")})
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系 不明なフラグの場合、エラーとなること", func(t *testing.T) {
+		unsetEnv(t)
+		_, _, err := config.Load([]string{"--secret-key", "secret"})
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	valid, err := config.Parse(envconfig.Map(map[string]string{
+		"DB_HOST":    "localhost",
+		"DB_PORT":    "3306",
+		"DB_USER":    "user",
+		"DB_NAME":    "go_clean_arch",
+		"SECRET_KEY": "test_secret_key_0123456789abcdef",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("正常系 必要な設定がされている場合、エラーとならないこと", func(t *testing.T) {
+		assert.NoError(t, valid.Validate())
+	})
+
+	t.Run("正常系 メモリに保存する場合、データベースの接続設定は検証されないこと", func(t *testing.T) {
+		appConfig := valid
+		appConfig.Storage = config.STORAGE_MEMORY
+		appConfig.Database = database.Config{}
+		assert.NoError(t, appConfig.Validate())
+	})
+
+	t.Run("正常系 SQLiteの場合、DB_PATHのみで接続できること", func(t *testing.T) {
+		appConfig := valid
+		appConfig.Database = database.Config{Driver: interfaceDB.SQLite, Path: ":memory:"}
+		assert.NoError(t, appConfig.Validate())
+	})
+
+	t.Run("異常系 SECRET_KEYが未設定の場合、エラーとなること", func(t *testing.T) {
+		appConfig := valid
+		appConfig.Auth.SecretKey = nil
+		assert.EqualError(t, appConfig.Validate(), "SECRET_KEY is required")
+	})
+
+	t.Run("異常系 SECRET_KEYが32バイト未満の場合、エラーとなること", func(t *testing.T) {
+		appConfig := valid
+		appConfig.Auth.SecretKey = []byte("sample_secret_key")
+		assert.EqualError(t, appConfig.Validate(), "SECRET_KEY must be at least 32 bytes")
+	})
+
+	t.Run("異常系 不明な保存先の場合、エラーとなること", func(t *testing.T) {
+		appConfig := valid
+		appConfig.Storage = "redis"
+		assert.EqualError(t, appConfig.Validate(), "invalid STORAGE: 'redis'")
+	})
+
+	t.Run("異常系 不明なDB_DRIVERの場合、エラーとなること", func(t *testing.T) {
+		appConfig := valid
+		appConfig.Database.Driver = "oracle"
+		assert.EqualError(t, appConfig.Validate(), "invalid DB_DRIVER: 'oracle'")
+	})
+
+	t.Run("異常系 DB_HOSTが未設定の場合、エラーとなること", func(t *testing.T) {
+		appConfig := valid
+		appConfig.Database.Host = ""
+		assert.Error(t, appConfig.Validate())
+	})
+
+	t.Run("異常系 接続数が負の場合、エラーとなること", func(t *testing.T) {
+		appConfig := valid
+		appConfig.Database.MaxOpenConns = -1
+		assert.Error(t, appConfig.Validate())
+	})
+
+	t.Run("異常系 DB_CONNECT_TIMEOUTが0の場合、エラーとなること", func(t *testing.T) {
+		appConfig := valid
+		appConfig.Database.ConnectTimeout = 0
+		assert.EqualError(t, appConfig.Validate(), "invalid DB_CONNECT_TIMEOUT: '0s'")
+	})
+
+	t.Run("異常系 DB_TIMEZONEが不正な場合、エラーとなること", func(t *testing.T) {
+		appConfig := valid
+		appConfig.Database.TimeZone = "Asia/Nowhere"
+		assert.EqualError(t, appConfig.Validate(), "invalid DB_TIMEZONE: 'Asia/Nowhere'")
+	})
+
+	t.Run("異常系 不明なBLOB_STOREの場合、エラーとなること", func(t *testing.T) {
+		appConfig := valid
+		appConfig.BlobStore.Store = "gcs"
+		assert.EqualError(t, appConfig.Validate(), "unknown BLOB_STORE: 'gcs'")
+	})
+}
@@ -0,0 +1,122 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+)
+
+// Config データベースの接続設定
+type Config struct {
+	// Driver 接続するデータベース。空の場合はMySQLとして扱います
+	Driver database.Dialect
+	// Host MySQL, Postgresのホスト
+	Host string
+	// Port MySQL, Postgresのポート
+	Port string
+	// User MySQL, Postgresのユーザー
+	User string
+	// Password MySQL, Postgresのパスワード
+	Password string
+	// Name MySQL, Postgresのデータベース名
+	Name string
+	// Path SQLiteのファイル。:memory: の場合はメモリ上に作成します
+	Path string
+	// SSLMode PostgresのSSLモード
+	SSLMode string
+	// TimeZone MySQL, Postgresの接続で日時を扱うタイムゾーン
+	// タスクの集計の日付の境界にも使用します
+	TimeZone string
+	// MaxOpenConns データベースへの最大の接続数。0の場合は制限しません
+	// SQLiteのメモリ上のデータベースの場合は、常に1つに限定します
+	MaxOpenConns int
+	// MaxIdleConns コネクションプールに保持する待機中の最大の接続数
+	MaxIdleConns int
+	// ConnMaxLifetime 接続を再利用する最大の時間。0の場合は制限しません
+	// データベースやロードバランサーに切断される前に、接続を作り直します
+	ConnMaxLifetime time.Duration
+	// ConnectTimeout 起動時にデータベースへ接続できるまで再試行する時間
+	ConnectTimeout time.Duration
+}
+
+// LoadConfig: 設定値からデータベースの接続設定を読み込みます
+// 時間はGoのDuration形式(例: 30s)で指定します
+func LoadConfig(lookup envconfig.Lookup) (Config, error) {
+	p := envconfig.NewParser(lookup)
+	config := Config{
+		Driver:   database.Dialect(p.String("DB_DRIVER", string(database.MySQL))),
+		Host:     p.String("DB_HOST", ""),
+		Port:     p.String("DB_PORT", ""),
+		User:     p.String("DB_USER", ""),
+		Password: p.String("DB_PASS", ""),
+		Name:     p.String("DB_NAME", ""),
+		Path:     p.String("DB_PATH", "go_clean_arch.db"),
+		SSLMode:  p.String("DB_SSLMODE", "disable"),
+		TimeZone: p.String("DB_TIMEZONE", "Asia/Tokyo"),
+
+		MaxOpenConns:    p.Int("DB_MAX_OPEN_CONNS", 25),
+		MaxIdleConns:    p.Int("DB_MAX_IDLE_CONNS", 25),
+		ConnMaxLifetime: p.Duration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		ConnectTimeout:  p.Duration("DB_CONNECT_TIMEOUT", 30*time.Second),
+	}
+	return config, p.Err()
+}
+
+// Validate: 接続に必要な設定がされているかを検証します
+func (c Config) Validate() error {
+	switch c.Driver {
+	case "", database.MySQL, database.Postgres:
+		if c.Host == "" || c.Port == "" || c.User == "" || c.Name == "" {
+			return fmt.Errorf("DB_HOST, DB_PORT, DB_USER and DB_NAME are required for DB_DRIVER '%s'", c.dialect())
+		}
+		if c.TimeZone == "" {
+			return fmt.Errorf("invalid DB_TIMEZONE: '%s'", c.TimeZone)
+		}
+		if c.ConnectTimeout <= 0 {
+			return fmt.Errorf("invalid DB_CONNECT_TIMEOUT: '%s'", c.ConnectTimeout)
+		}
+	case database.SQLite:
+		if c.Path == "" {
+			return fmt.Errorf("DB_PATH is required for DB_DRIVER '%s'", c.Driver)
+		}
+	default:
+		return fmt.Errorf("invalid DB_DRIVER: '%s'", c.Driver)
+	}
+	if _, err := c.Location(); err != nil {
+		return err
+	}
+	if c.MaxOpenConns < 0 || c.MaxIdleConns < 0 || c.ConnMaxLifetime < 0 {
+		return errors.New("DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS and DB_CONN_MAX_LIFETIME must not be negative")
+	}
+	return nil
+}
+
+// Location: 日時を扱うタイムゾーンを返却します
+// タスクの集計の日付の境界も、データベースの日付の変換と合わせるためこのタイムゾーンで計算します
+// 空の場合はUTCとします
+func (c Config) Location() (*time.Location, error) {
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_TIMEZONE: '%s'", c.TimeZone)
+	}
+	return loc, nil
+}
+
+// dialect: 接続するデータベースのSQLの方言を返却します
+func (c Config) dialect() database.Dialect {
+	if c.Driver == "" {
+		return database.MySQL
+	}
+	return c.Driver
+}
+
+// dbName: メトリクスのラベルに使用するデータベース名を返却します
+func (c Config) dbName() string {
+	if c.dialect() == database.SQLite {
+		return c.Path
+	}
+	return c.Name
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// initialRetryInterval 接続を再試行するまでの最初の待ち時間
+	initialRetryInterval = 100 * time.Millisecond
+	// maxRetryInterval 接続を再試行するまでの最大の待ち時間
+	maxRetryInterval = 5 * time.Second
+)
+
+// configurePool: コネクションプールの設定を反映します
+func configurePool(conn *sql.DB, config Config) {
+	conn.SetMaxOpenConns(config.MaxOpenConns)
+	conn.SetMaxIdleConns(config.MaxIdleConns)
+	conn.SetConnMaxLifetime(config.ConnMaxLifetime)
+}
+
+// PingWithRetry: データベースに接続できるまで、待ち時間を倍にしながら再試行します
+// コンテナの同時起動などでデータベースの起動が遅れても、timeoutまでは起動を待ちます
+// timeoutを過ぎた場合、またはctxがキャンセルされた場合は最後のエラーを返却します
+func PingWithRetry(ctx context.Context, conn *sql.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	interval := initialRetryInterval
+	for attempt := 1; ; attempt++ {
+		err := conn.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+		slog.WarnContext(ctx, "database ping failed, retrying", "attempt", attempt, "retry_in", interval.String(), "error", err)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("database ping failed after %d attempts: %w", attempt, err)
+		case <-timer.C:
+		}
+		interval *= 2
+		if interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+	}
+}
+
+// connect: sql.Openで作成した接続にプールの設定を反映し、接続できるまで待機します
+// 接続できなかった場合は接続を閉じます
+func connect(ctx context.Context, conn *sql.DB, config Config) (*sql.DB, error) {
+	configurePool(conn, config)
+	err := PingWithRetry(ctx, conn, config.ConnectTimeout)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
@@ -0,0 +1,59 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPingWithRetry(t *testing.T) {
+	t.Run("正常系 接続に失敗した場合、接続できるまで再試行されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		mock.ExpectPing()
+
+		err = database.PingWithRetry(context.TODO(), db, 5*time.Second)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系 制限時間内に接続できない場合、最後のエラーが返却されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		pingErr := errors.New("connection refused")
+		for i := 0; i < 10; i++ {
+			mock.ExpectPing().WillReturnError(pingErr)
+		}
+
+		start := time.Now()
+		err = database.PingWithRetry(context.TODO(), db, 250*time.Millisecond)
+		assert.ErrorIs(t, err, pingErr)
+		assert.Less(t, time.Since(start), 2*time.Second)
+	})
+
+	t.Run("異常系 contextがキャンセルされた場合、待機せずにエラーが返却されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		mock.ExpectPing()
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+
+		start := time.Now()
+		err = database.PingWithRetry(ctx, db, 5*time.Second)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
@@ -0,0 +1,29 @@
+// Package databasetest リポジトリのテストで使用するデータベースを提供します
+package databasetest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/migration"
+	interfaceDB "github.com/Hajime3778/go-clean-arch/interface/database"
+)
+
+// NewSQLite: テストごとの一時ディレクトリにSQLiteのデータベースを作成し、マイグレーションを適用します
+// データベースはテストの終了時に閉じられ、一時ディレクトリとともに削除されます
+func NewSQLite(t testing.TB) *database.SqlDriver {
+	t.Helper()
+	driver, err := database.OpenSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sqlite open error: '%s'", err)
+	}
+	t.Cleanup(func() { driver.Close() })
+
+	err = migration.Up(context.Background(), driver.Conn, interfaceDB.SQLite)
+	if err != nil {
+		t.Fatalf("migrate error: '%s'", err)
+	}
+	return driver
+}
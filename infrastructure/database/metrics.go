@@ -0,0 +1,32 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/Hajime3778/go-clean-arch/util/metrics"
+)
+
+// unknownOperation 操作名が設定されていない場合の操作名
+const unknownOperation = "unknown"
+
+// queryOperationName: ctxに設定されたクエリの操作名を返却します
+// 呼び出し元の関数名から推測すると、リファクタリングでメトリクスのラベルが変わるため、
+// リポジトリで database.WithOperation により明示的に設定された名前のみを使用します
+func queryOperationName(ctx context.Context) string {
+	operation := database.OperationFromContext(ctx)
+	if operation == "" {
+		return unknownOperation
+	}
+	return operation
+}
+
+// observeQuery: クエリの実行時間を記録します
+func observeQuery(operation string, start time.Time, err error) {
+	result := metrics.ResultSuccess
+	if err != nil {
+		result = metrics.ResultFailure
+	}
+	metrics.DBQueryDuration.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+}
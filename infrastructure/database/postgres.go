@@ -0,0 +1,30 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"net/url"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// openPostgres: 設定の接続情報でPostgresへ接続します
+func openPostgres(ctx context.Context, config Config) (*sql.DB, error) {
+	val := url.Values{}
+	val.Add("sslmode", config.SSLMode)
+	val.Add("timezone", config.TimeZone)
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(config.User, config.Password),
+		Host:     net.JoinHostPort(config.Host, config.Port),
+		Path:     config.Name,
+		RawQuery: val.Encode(),
+	}
+
+	conn, err := sql.Open("pgx", dsn.String())
+	if err != nil {
+		return nil, err
+	}
+	return connect(ctx, conn, config)
+}
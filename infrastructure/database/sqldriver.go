@@ -4,64 +4,156 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/url"
-	"os"
+	"strings"
+	"time"
 
 	"github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/Hajime3778/go-clean-arch/util/metrics"
+	"github.com/Hajime3778/go-clean-arch/util/tracing"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
 type SqlDriver struct {
 	Conn *sql.DB
+	// dialect 接続しているデータベースのSQLの方言。空の場合はMySQLとして扱います
+	dialect database.Dialect
 }
 
 type Rows struct {
 	Rows *sql.Rows
 }
 
-// NewSqlConnenction: データベースへ接続します
-func NewSqlConnenction() database.SqlDriver {
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASS")
-	dbName := os.Getenv("DB_NAME")
+// txKey トランザクションをcontextに保持する際のキー
+type txKey struct{}
 
-	connStr := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPass, dbHost, dbPort, dbName)
+// executor *sql.DB と *sql.Tx に共通するメソッド
+type executor interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// NewSqlDriver: 接続済みのデータベースとSQLの方言からSqlDriverを作成します
+func NewSqlDriver(conn *sql.DB, dialect database.Dialect) *SqlDriver {
+	return &SqlDriver{Conn: conn, dialect: dialect}
+}
+
+// NewSqlConnenction: 設定のデータベースへ接続し、コネクションプールのメトリクスを登録します
+func NewSqlConnenction(ctx context.Context, config Config) (database.SqlDriver, error) {
+	driver, err := Open(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	err = metrics.RegisterDBStats(driver.Conn, config.dbName())
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("db stats metrics register failed: %w", err)
+	}
+	return driver, nil
+}
+
+// Open: config.Driver で指定されたデータベースへ接続します
+// mysql(デフォルト)とpostgresの場合は DB_HOST などの接続情報、sqliteの場合は DB_PATH のファイルを使用します
+func Open(ctx context.Context, config Config) (*SqlDriver, error) {
+	switch config.dialect() {
+	case database.MySQL:
+		conn, err := openMySQL(ctx, config)
+		if err != nil {
+			return nil, fmt.Errorf("mysql connect failed: %w", err)
+		}
+		return NewSqlDriver(conn, database.MySQL), nil
+	case database.Postgres:
+		conn, err := openPostgres(ctx, config)
+		if err != nil {
+			return nil, fmt.Errorf("postgres connect failed: %w", err)
+		}
+		return NewSqlDriver(conn, database.Postgres), nil
+	case database.SQLite:
+		driver, err := openSQLite(config)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite connect failed: %w", err)
+		}
+		return driver, nil
+	default:
+		return nil, fmt.Errorf("invalid DB_DRIVER: '%s'", config.Driver)
+	}
+}
+
+// openMySQL: 設定の接続情報でMySQLへ接続します
+func openMySQL(ctx context.Context, config Config) (*sql.DB, error) {
+	connStr := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", config.User, config.Password, config.Host, config.Port, config.Name)
 	val := url.Values{}
 	val.Add("parseTime", "1")
-	val.Add("loc", "Asia/Tokyo")
+	val.Add("loc", config.TimeZone)
 	dsn := fmt.Sprintf("%s?%s", connStr, val.Encode())
 
 	conn, err := sql.Open(`mysql`, dsn)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	err = conn.Ping()
-	if err != nil {
-		log.Fatalf("mysql connect failed: '%s'", err)
+	return connect(ctx, conn, config)
+}
+
+// Dialect: 接続しているデータベースのSQLの方言を返却します
+func (driver *SqlDriver) Dialect() database.Dialect {
+	if driver.dialect == "" {
+		return database.MySQL
 	}
-	return &SqlDriver{conn}
+	return driver.dialect
 }
 
 // Query: 取得のクエリを実行します
 func (driver *SqlDriver) QueryContext(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
-	rows, err := driver.Conn.QueryContext(ctx, query, args...)
+	operation := queryOperationName(ctx)
+	ctx, span := startQuerySpan(ctx, driver.Dialect(), operation, query)
+	start := time.Now()
+	rows, err := driver.query(ctx, query, driver.bindArgs(args)...)
+	observeQuery(operation, start, err)
+	tracing.End(span, err)
+	return rows, err
+}
+
+// Execute: クエリを実行します
+func (driver *SqlDriver) ExecuteContext(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+	operation := queryOperationName(ctx)
+	ctx, span := startQuerySpan(ctx, driver.Dialect(), operation, query)
+	start := time.Now()
+	res, err := driver.execute(ctx, query, driver.bindArgs(args)...)
+	observeQuery(operation, start, err)
+	tracing.End(span, err)
+	return res, err
+}
+
+// InsertContext: INSERTのクエリを実行し、追加された行のidを返却します
+// PostgresはLastInsertIdに対応していないため、RETURNING句でidを取得します
+func (driver *SqlDriver) InsertContext(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	operation := queryOperationName(ctx)
+	ctx, span := startQuerySpan(ctx, driver.Dialect(), operation, query)
+	start := time.Now()
+	id, err := driver.insert(ctx, query, driver.bindArgs(args)...)
+	observeQuery(operation, start, err)
+	tracing.End(span, err)
+	return id, err
+}
+
+func (driver *SqlDriver) query(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
+	rows, err := driver.executor(ctx).QueryContext(ctx, driver.Dialect().Rebind(query), args...)
 	if err != nil {
 		return nil, err
 	}
 	return Rows{rows}, nil
 }
 
-// Execute: クエリを実行します
-func (driver *SqlDriver) ExecuteContext(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
+func (driver *SqlDriver) execute(ctx context.Context, query string, args ...interface{}) (database.Result, error) {
 	res := SqlResult{}
-	stmt, err := driver.Conn.PrepareContext(ctx, query)
+	stmt, err := driver.executor(ctx).PrepareContext(ctx, driver.Dialect().Rebind(query))
 	if err != nil {
 		return res, err
 	}
+	defer stmt.Close()
 	result, err := stmt.ExecContext(ctx, args...)
 	if err != nil {
 		return res, err
@@ -70,16 +162,89 @@ func (driver *SqlDriver) ExecuteContext(ctx context.Context, query string, args
 	return res, nil
 }
 
+func (driver *SqlDriver) insert(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	dialect := driver.Dialect()
+	if returning := dialect.Returning("id"); returning != "" {
+		var id int64
+		err := driver.executor(ctx).QueryRowContext(ctx, dialect.Rebind(strings.TrimSpace(query)+" "+returning), args...).Scan(&id)
+		if err != nil {
+			return 0, err
+		}
+		return id, nil
+	}
+
+	res, err := driver.execute(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// Transaction: fnを1つのトランザクションで実行します
+// fnがエラーを返却した場合はロールバックし、それ以外はコミットします
+// すでにトランザクション内の場合は、そのトランザクションをそのまま使用します
+func (driver *SqlDriver) Transaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	ctx, span := tracing.Start(ctx, "db.Transaction")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := driver.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, txKey{}, tx))
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			slog.ErrorContext(ctx, "rollback failed", "error", rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// executor: contextにトランザクションがあればそれを、なければコネクションを返却します
+func (driver *SqlDriver) executor(ctx context.Context) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return driver.Conn
+}
+
 // ErrNoRows: データが見つからなかったときのエラー
 func (driver *SqlDriver) ErrNoRows() error {
 	return sql.ErrNoRows
 }
 
+// PingContext: データベースに接続できるかどうかを確認します
+func (driver *SqlDriver) PingContext(ctx context.Context) error {
+	return driver.Conn.PingContext(ctx)
+}
+
+// Close: データベースへの接続を閉じます
+func (driver *SqlDriver) Close() error {
+	return driver.Conn.Close()
+}
+
 // Scan: マッピングを行います
 func (r Rows) Scan(dest ...interface{}) error {
 	return r.Rows.Scan(dest...)
 }
 
+// Columns: 列名を返却します
+func (r Rows) Columns() ([]string, error) {
+	return r.Rows.Columns()
+}
+
 func (r Rows) Next() bool {
 	return r.Rows.Next()
 }
@@ -0,0 +1,271 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	interfaceDB "github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/Hajime3778/go-clean-arch/util/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTransaction(t *testing.T) {
+	query := "DELETE FROM tasks where id = ?"
+
+	t.Run("正常系 fnが成功した場合、同じトランザクションで実行されコミットされること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := &database.SqlDriver{Conn: db}
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err = driver.Transaction(context.TODO(), func(ctx context.Context) error {
+			if _, err := driver.ExecuteContext(ctx, query, 1); err != nil {
+				return err
+			}
+			return driver.Transaction(ctx, func(ctx context.Context) error {
+				_, err := driver.ExecuteContext(ctx, query, 2)
+				return err
+			})
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系 fnがエラーを返却した場合、ロールバックされること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := &database.SqlDriver{Conn: db}
+		mockErr := errors.New("test error")
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		err = driver.Transaction(context.TODO(), func(ctx context.Context) error {
+			return mockErr
+		})
+		assert.Equal(t, mockErr, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系 トランザクションの開始に失敗した場合、エラーとなること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := &database.SqlDriver{Conn: db}
+		mockErr := errors.New("begin error")
+
+		mock.ExpectBegin().WillReturnError(mockErr)
+
+		called := false
+		err = driver.Transaction(context.TODO(), func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		assert.Equal(t, mockErr, err)
+		assert.False(t, called)
+	})
+}
+
+func TestInsertContext(t *testing.T) {
+	t.Run("正常系 MySQLの場合、LastInsertIdで追加された行のidが返却されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := database.NewSqlDriver(db, interfaceDB.MySQL)
+		query := "INSERT INTO users(name) VALUES(?)"
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).ExpectExec().WithArgs("name").WillReturnResult(sqlmock.NewResult(12, 1))
+
+		id, err := driver.InsertContext(context.TODO(), query, "name")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系 Postgresの場合、RETURNING句で追加された行のidが返却されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := database.NewSqlDriver(db, interfaceDB.Postgres)
+
+		mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO users(name) VALUES($1) RETURNING id")).WithArgs("name").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+
+		id, err := driver.InsertContext(context.TODO(), "\n\t\tINSERT INTO users(name) VALUES(?)\n\t", "name")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系 追加後のidの取得に失敗した場合、エラーが返却されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := database.NewSqlDriver(db, interfaceDB.MySQL)
+		mockErr := errors.New("test error")
+
+		mock.ExpectPrepare("INSERT").ExpectExec().WillReturnResult(sqlmock.NewErrorResult(mockErr))
+
+		id, err := driver.InsertContext(context.TODO(), "INSERT INTO users(name) VALUES(?)", "name")
+		assert.Equal(t, mockErr, err)
+		assert.Equal(t, int64(0), id)
+	})
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("正常系 SQLiteのファイルのデータベースの場合、コネクションプールの設定が反映されること", func(t *testing.T) {
+		driver, err := database.Open(context.TODO(), database.Config{
+			Driver:       interfaceDB.SQLite,
+			Path:         filepath.Join(t.TempDir(), "test.db"),
+			MaxOpenConns: 3,
+		})
+		if err != nil {
+			t.Fatalf("open error: '%s'", err)
+		}
+		defer driver.Close()
+
+		assert.Equal(t, 3, driver.Conn.Stats().MaxOpenConnections)
+	})
+
+	t.Run("正常系 SQLiteのメモリ上のデータベースの場合、設定に関わらず接続が1つに限定されること", func(t *testing.T) {
+		driver, err := database.Open(context.TODO(), database.Config{
+			Driver:       interfaceDB.SQLite,
+			Path:         ":memory:",
+			MaxOpenConns: 3,
+		})
+		if err != nil {
+			t.Fatalf("open error: '%s'", err)
+		}
+		defer driver.Close()
+
+		assert.Equal(t, 1, driver.Conn.Stats().MaxOpenConnections)
+	})
+}
+
+func TestClose(t *testing.T) {
+	t.Run("正常系 データベースへの接続が閉じられること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := &database.SqlDriver{Conn: db}
+
+		mock.ExpectClose()
+
+		err = driver.Close()
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPingContext(t *testing.T) {
+	t.Run("正常系 データベースに接続できる場合、エラーとならないこと", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := &database.SqlDriver{Conn: db}
+
+		mock.ExpectPing()
+
+		err = driver.PingContext(context.TODO())
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestQueryMetrics(t *testing.T) {
+	sampleCount := func(t *testing.T, operation string) uint64 {
+		histogram := metrics.DBQueryDuration.WithLabelValues(operation, metrics.ResultSuccess)
+		var m dto.Metric
+		err := histogram.(prometheus.Metric).Write(&m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m.GetHistogram().GetSampleCount()
+	}
+
+	t.Run("正常系 contextに設定した操作名ごとにクエリの実行時間が記録されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := &database.SqlDriver{Conn: db}
+		query := "SELECT id FROM tasks"
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		before := sampleCount(t, "task.FindByUserID")
+
+		_, err = driver.QueryContext(interfaceDB.WithOperation(context.TODO(), "task.FindByUserID"), query)
+		assert.NoError(t, err)
+
+		assert.Equal(t, before+1, sampleCount(t, "task.FindByUserID"))
+	})
+
+	t.Run("正常系 操作名が設定されていない場合、unknownとして記録されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := &database.SqlDriver{Conn: db}
+		query := "SELECT id FROM users"
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		before := sampleCount(t, "unknown")
+
+		_, err = driver.QueryContext(context.TODO(), query)
+		assert.NoError(t, err)
+
+		assert.Equal(t, before+1, sampleCount(t, "unknown"))
+	})
+}
+
+func TestQueryTracing(t *testing.T) {
+	t.Run("正常系 リテラルを除いたクエリがスパンに記録されること", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		prev := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		driver := &database.SqlDriver{Conn: db}
+		query := `
+			SELECT id FROM users
+			WHERE email = 'test@example.com' AND id > 10 AND name = ?
+		`
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err = driver.QueryContext(interfaceDB.WithOperation(context.TODO(), "user.GetByEmail"), query, "name")
+		assert.NoError(t, err)
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "user.GetByEmail", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), attribute.String("db.statement", "SELECT id FROM users WHERE email = ? AND id > ? AND name = ?"))
+		assert.Contains(t, spans[0].Attributes(), attribute.String("db.operation", "SELECT"))
+	})
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"database/sql"
+	"net/url"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteMemory メモリ上のデータベースを作成する場合のパス
+const sqliteMemory = ":memory:"
+
+// openSQLite: 設定のSQLiteのデータベースを開き、コネクションプールの設定を反映します
+// WALモードでは読み込みは同時に行え、書き込みはトランザクションの開始時のロックで順番に実行されるため、
+// ファイルのデータベースにはMySQL, Postgresと同じ設定を反映します
+// メモリ上のデータベースは接続ごとに作成されるため、設定に関わらず接続を1つに限定します
+func openSQLite(config Config) (*SqlDriver, error) {
+	driver, err := OpenSQLite(config.Path)
+	if err != nil {
+		return nil, err
+	}
+	if config.Path != sqliteMemory {
+		configurePool(driver.Conn, config)
+	}
+	return driver, nil
+}
+
+// OpenSQLite: pathのSQLiteのデータベースを開きます
+// pathに :memory: を指定した場合はメモリ上のデータベースとなり、接続を閉じると破棄されます
+//
+// MySQLと同じ制約で動作させるため、外部キー制約を有効にし、
+// 他の接続が書き込み中の場合はロックが解放されるまで待機します
+func OpenSQLite(path string) (*SqlDriver, error) {
+	val := url.Values{}
+	val.Add("_pragma", "foreign_keys(1)")
+	val.Add("_pragma", "busy_timeout(5000)")
+	val.Add("_pragma", "journal_mode(WAL)")
+	// トランザクションの開始時に書き込みロックを取得し、読み込み後の書き込みでデッドロックしないようにします
+	val.Add("_txlock", "immediate")
+	val.Add("_time_format", "sqlite")
+
+	conn, err := sql.Open("sqlite", "file:"+path+"?"+val.Encode())
+	if err != nil {
+		return nil, err
+	}
+	if path == sqliteMemory {
+		// メモリ上のデータベースは接続ごとに作成されるため、接続を1つに限定します
+		conn.SetMaxOpenConns(1)
+		conn.SetConnMaxLifetime(0)
+		conn.SetConnMaxIdleTime(0)
+	}
+	err = conn.Ping()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return NewSqlDriver(conn, database.SQLite), nil
+}
+
+// bindArgs: SQLiteの場合は日時のパラメータをUTCに揃えます
+// SQLiteは日時を文字列で保存して比較するため、タイムゾーンが混在すると大小関係が正しくなりません
+func (driver *SqlDriver) bindArgs(args []interface{}) []interface{} {
+	if driver.Dialect() != database.SQLite {
+		return args
+	}
+	bound := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case time.Time:
+			bound[i] = v.UTC()
+		case *time.Time:
+			if v != nil {
+				utc := v.UTC()
+				bound[i] = &utc
+			} else {
+				bound[i] = v
+			}
+		case sql.NullTime:
+			bound[i] = sql.NullTime{Time: v.Time.UTC(), Valid: v.Valid}
+		default:
+			bound[i] = arg
+		}
+	}
+	return bound
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/Hajime3778/go-clean-arch/util/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// stringLiteral クエリ中の文字列リテラル
+	stringLiteral = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'`)
+	// numberLiteral クエリ中の数値リテラル(識別子の一部は除きます)
+	numberLiteral = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	// whitespaces 連続する空白文字
+	whitespaces = regexp.MustCompile(`\s+`)
+)
+
+// startQuerySpan: クエリのスパンを開始します
+func startQuerySpan(ctx context.Context, dialect database.Dialect, operation string, query string) (context.Context, trace.Span) {
+	statement := sanitizeQuery(query)
+	return tracing.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", string(dialect)),
+			attribute.String("db.operation", queryOperation(statement)),
+			attribute.String("db.statement", statement),
+		),
+	)
+}
+
+// sanitizeQuery: クエリのリテラルを ? に置き換え、空白を詰めます
+// パラメータはプレースホルダーで渡しているため値は含まれませんが、リテラルで書かれた値も出力しないようにします
+func sanitizeQuery(query string) string {
+	query = stringLiteral.ReplaceAllString(query, "?")
+	query = numberLiteral.ReplaceAllString(query, "?")
+	return strings.TrimSpace(whitespaces.ReplaceAllString(query, " "))
+}
+
+// queryOperation: SELECT, INSERTなどのクエリの種類を返却します
+func queryOperation(statement string) string {
+	operation, _, _ := strings.Cut(statement, " ")
+	return strings.ToUpper(operation)
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// redacted 機密情報を置き換える値
+const redacted = "[REDACTED]"
+
+// sensitiveKeys ログに出力しない属性のキー(小文字)
+var sensitiveKeys = map[string]struct{}{
+	"password":      {},
+	"salt":          {},
+	"token":         {},
+	"access_token":  {},
+	"authorization": {},
+	"cookie":        {},
+	"secret":        {},
+	"secret_key":    {},
+}
+
+// Config ログの設定
+type Config struct {
+	// Level 出力する最小のレベル
+	Level slog.Level
+}
+
+// LoadConfig: 設定値からログの設定を読み込みます
+// LOG_LEVELはdebug, info, warn, errorのいずれかで指定します
+func LoadConfig(lookup envconfig.Lookup) (Config, error) {
+	p := envconfig.NewParser(lookup)
+	config := Config{Level: slog.LevelInfo}
+	if value := p.String("LOG_LEVEL", ""); value != "" {
+		err := config.Level.UnmarshalText([]byte(value))
+		if err != nil {
+			p.Invalid("LOG_LEVEL", value)
+		}
+	}
+	return config, p.Err()
+}
+
+// Init: 設定のレベルでJSON形式のロガーを作成し、標準のロガーに設定します
+// logパッケージの出力も同じ形式で出力されます
+func Init(config Config) {
+	slog.SetDefault(New(os.Stdout, config.Level))
+}
+
+// New: JSON形式で出力するロガーを作成します
+// contextのリクエストID、ユーザーID、トレースIDを出力に含め、機密情報の属性は値を伏せて出力します
+func New(w io.Writer, level slog.Leveler) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	})
+	return slog.New(contextHandler{handler})
+}
+
+// redact: 機密情報の属性の値を伏せます
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	if _, ok := sensitiveKeys[strings.ToLower(attr.Key)]; ok {
+		return slog.String(attr.Key, redacted)
+	}
+	return attr
+}
+
+// contextHandler contextのリクエストID、ユーザーID、トレースIDを出力に追加します
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := domain.RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		record.AddAttrs(slog.Int64("user_id", principal.UserID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()), slog.String("span_id", spanContext.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
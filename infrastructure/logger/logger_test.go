@@ -0,0 +1,60 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("正常系 contextのリクエストIDとユーザーIDが出力されること", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.New(&buf, slog.LevelInfo)
+		ctx := domain.WithRequestID(context.TODO(), "req-1")
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: 3, Token: "secret token"})
+
+		log.InfoContext(ctx, "test message")
+
+		entry := decodeEntry(t, &buf)
+		assert.Equal(t, "test message", entry["msg"])
+		assert.Equal(t, "req-1", entry["request_id"])
+		assert.Equal(t, float64(3), entry["user_id"])
+		assert.NotContains(t, buf.String(), "secret token")
+	})
+
+	t.Run("正常系 機密情報の属性は値が伏せられること", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.New(&buf, slog.LevelInfo)
+
+		log.Info("sign in", "email", "test@example.com", "Password", "p@ssw0rd", slog.Group("request", "token", "abc"))
+
+		entry := decodeEntry(t, &buf)
+		assert.Equal(t, "test@example.com", entry["email"])
+		assert.Equal(t, "[REDACTED]", entry["Password"])
+		assert.Equal(t, map[string]interface{}{"token": "[REDACTED]"}, entry["request"])
+	})
+
+	t.Run("正常系 設定したレベル未満のログは出力されないこと", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := logger.New(&buf, slog.LevelWarn)
+
+		log.Info("ignored")
+
+		assert.Empty(t, buf.String())
+	})
+}
+
+func decodeEntry(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	var entry map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entry
+}
@@ -0,0 +1,131 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+)
+
+// files バイナリに埋め込むマイグレーションのSQL
+// SQLの方言ごとに migrations/<方言> に配置します
+//
+//go:embed migrations/*/*.sql
+var files embed.FS
+
+// fileNamePattern マイグレーションのファイル名の形式
+// 例: 0001_create_users.up.sql, 0001_create_users.down.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration バージョンごとのスキーマの変更
+type Migration struct {
+	// Version バージョン。1から始まり、適用する順序となります
+	Version int64
+	// Name 変更内容を表す名前
+	Name string
+	// Up 適用するSQL
+	Up string
+	// Down 取り消すSQL
+	Down string
+}
+
+// Checksum 適用するSQLのチェックサムを返却します
+// 適用済みのマイグレーションが書き換えられていないかを検出するために使用します
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// Migrations バイナリに埋め込まれた、dialect向けのマイグレーションを返却します
+func Migrations(dialect database.Dialect) ([]Migration, error) {
+	migrations, err := fs.Sub(files, path.Join("migrations", string(dialect)))
+	if err != nil {
+		return nil, err
+	}
+	return Load(migrations)
+}
+
+// Load fsysの直下にあるマイグレーションのファイルを読み込み、バージョン順に並べて返却します
+// バージョンごとにupとdownの両方のファイルが必要です
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid migration version: %s", entry.Name())
+		}
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := migrations[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			migrations[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s, %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	result := make([]Migration, 0, len(migrations))
+	for _, m := range migrations {
+		if strings.TrimSpace(m.Up) == "" || strings.TrimSpace(m.Down) == "" {
+			return nil, fmt.Errorf("migration %d_%s requires both up and down files", m.Version, m.Name)
+		}
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+	return result, nil
+}
+
+// splitStatements SQLを文ごとに分割します
+// 行末の;を文の区切りとし、--で始まる行はコメントとして除きます
+func splitStatements(sql string) []string {
+	statements := make([]string, 0)
+	var current strings.Builder
+	for _, line := range strings.Split(sql, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
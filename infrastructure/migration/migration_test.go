@@ -0,0 +1,95 @@
+package migration_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/migration"
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrations(t *testing.T) {
+	t.Run("正常系 埋め込まれたマイグレーションが連番で読み込めること", func(t *testing.T) {
+		for _, dialect := range []database.Dialect{database.MySQL, database.SQLite, database.Postgres} {
+			migrations, err := migration.Migrations(dialect)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, migrations)
+			for i, m := range migrations {
+				assert.Equal(t, int64(i+1), m.Version)
+				assert.NotEmpty(t, m.Up)
+				assert.NotEmpty(t, m.Down)
+			}
+		}
+	})
+
+	t.Run("正常系 方言ごとに同じバージョンと名前のマイグレーションがあること", func(t *testing.T) {
+		mysql, err := migration.Migrations(database.MySQL)
+		assert.NoError(t, err)
+		for _, dialect := range []database.Dialect{database.SQLite, database.Postgres} {
+			migrations, err := migration.Migrations(dialect)
+			assert.NoError(t, err)
+
+			assert.Equal(t, len(mysql), len(migrations))
+			for i := range mysql {
+				assert.Equal(t, mysql[i].Version, migrations[i].Version)
+				assert.Equal(t, mysql[i].Name, migrations[i].Name)
+			}
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("正常系 upとdownがバージョン順にまとめられること", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0002_add_index.up.sql":   {Data: []byte("CREATE INDEX idx ON a (b);")},
+			"0002_add_index.down.sql": {Data: []byte("DROP INDEX idx ON a;")},
+			"0001_create_a.up.sql":    {Data: []byte("CREATE TABLE a (b int);")},
+			"0001_create_a.down.sql":  {Data: []byte("DROP TABLE a;")},
+			"README.md":               {Data: []byte("ignored")},
+		}
+
+		migrations, err := migration.Load(fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, []migration.Migration{
+			{Version: 1, Name: "create_a", Up: "CREATE TABLE a (b int);", Down: "DROP TABLE a;"},
+			{Version: 2, Name: "add_index", Up: "CREATE INDEX idx ON a (b);", Down: "DROP INDEX idx ON a;"},
+		}, migrations)
+	})
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"downのファイルがない場合", fstest.MapFS{
+			"0001_create_a.up.sql": {Data: []byte("CREATE TABLE a (b int);")},
+		}},
+		{"ファイル名の形式が不正な場合", fstest.MapFS{
+			"create_a.sql": {Data: []byte("CREATE TABLE a (b int);")},
+		}},
+		{"同じバージョンで名前が異なる場合", fstest.MapFS{
+			"0001_create_a.up.sql":   {Data: []byte("CREATE TABLE a (b int);")},
+			"0001_create_b.down.sql": {Data: []byte("DROP TABLE b;")},
+		}},
+		{"バージョンが0の場合", fstest.MapFS{
+			"0000_create_a.up.sql":   {Data: []byte("CREATE TABLE a (b int);")},
+			"0000_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run("異常系 "+tt.name+"、エラーとなること", func(t *testing.T) {
+			_, err := migration.Load(tt.fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	t.Run("正常系 upのSQLが変わるとチェックサムが変わること", func(t *testing.T) {
+		a := migration.Migration{Version: 1, Up: "CREATE TABLE a (b int);"}
+		b := migration.Migration{Version: 1, Up: "CREATE TABLE a (b bigint);"}
+
+		assert.Len(t, a.Checksum(), 64)
+		assert.NotEqual(t, a.Checksum(), b.Checksum())
+	})
+}
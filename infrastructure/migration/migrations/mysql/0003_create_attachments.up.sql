@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `attachments` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `task_id` bigint unsigned NOT NULL,
+  `file_name` varchar(255) NOT NULL DEFAULT '',
+  `content_type` varchar(100) NOT NULL DEFAULT '',
+  `size` bigint NOT NULL DEFAULT 0,
+  `storage_key` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`) ON DELETE CASCADE,
+  INDEX `idx_task_id` (`task_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
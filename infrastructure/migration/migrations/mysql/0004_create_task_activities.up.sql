@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `task_activities` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `task_id` bigint unsigned NOT NULL,
+  `actor_id` bigint unsigned NOT NULL,
+  `action` varchar(20) NOT NULL DEFAULT '',
+  `changes` text NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  INDEX `idx_task_id` (`task_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `rate_limit_buckets` (
+  `bucket_key` varchar(255) NOT NULL,
+  `tokens` double NOT NULL DEFAULT 0,
+  `updated_at` datetime(6) NOT NULL,
+  PRIMARY KEY (`bucket_key`),
+  INDEX `idx_updated_at` (`updated_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1 @@
+DROP TABLE `sign_in_lockouts`;
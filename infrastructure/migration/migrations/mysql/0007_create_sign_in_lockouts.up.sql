@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS `sign_in_lockouts` (
+  `lockout_key` varchar(255) NOT NULL,
+  `failures` int NOT NULL DEFAULT 0,
+  `lock_count` int NOT NULL DEFAULT 0,
+  `locked_until` datetime(6) NULL DEFAULT NULL,
+  `updated_at` datetime(6) NOT NULL,
+  PRIMARY KEY (`lockout_key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1 @@
+ALTER TABLE `tasks` ADD COLUMN `completed_at` datetime AFTER `updated_at`;
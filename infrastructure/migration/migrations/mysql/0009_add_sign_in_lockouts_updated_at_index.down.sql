@@ -0,0 +1 @@
+ALTER TABLE `sign_in_lockouts` DROP INDEX `idx_updated_at`;
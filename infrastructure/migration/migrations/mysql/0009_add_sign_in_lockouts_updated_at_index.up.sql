@@ -0,0 +1 @@
+ALTER TABLE `sign_in_lockouts` ADD INDEX `idx_updated_at` (`updated_at`);
@@ -0,0 +1,14 @@
+-- MySQLの ON UPDATE CURRENT_TIMESTAMP に相当する、updated_atを更新するトリガー関数です
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS trigger AS $$ BEGIN IF NEW.updated_at = OLD.updated_at THEN NEW.updated_at = CURRENT_TIMESTAMP; END IF; RETURN NEW; END; $$ LANGUAGE plpgsql;
+CREATE TABLE IF NOT EXISTS users (
+  id bigserial PRIMARY KEY,
+  name varchar(50) NOT NULL DEFAULT '',
+  email varchar(50) NOT NULL DEFAULT '',
+  password varchar(200) NOT NULL DEFAULT '',
+  salt varchar(10) NOT NULL DEFAULT '',
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+DROP TRIGGER IF EXISTS trg_users_updated_at ON users;
+CREATE TRIGGER trg_users_updated_at BEFORE UPDATE ON users FOR EACH ROW EXECUTE FUNCTION set_updated_at();
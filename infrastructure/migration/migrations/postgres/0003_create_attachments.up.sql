@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS attachments (
+  id bigserial PRIMARY KEY,
+  task_id bigint NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+  file_name varchar(255) NOT NULL DEFAULT '',
+  content_type varchar(100) NOT NULL DEFAULT '',
+  size bigint NOT NULL DEFAULT 0,
+  storage_key varchar(255) NOT NULL DEFAULT '',
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_attachments_task_id ON attachments (task_id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS task_activities (
+  id bigserial PRIMARY KEY,
+  task_id bigint NOT NULL,
+  actor_id bigint NOT NULL,
+  action varchar(20) NOT NULL DEFAULT '',
+  changes text NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_task_activities_task_id ON task_activities (task_id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+  user_id bigint PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  token varchar(64) NOT NULL DEFAULT '',
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_token ON calendar_feeds (token);
+DROP TRIGGER IF EXISTS trg_calendar_feeds_updated_at ON calendar_feeds;
+CREATE TRIGGER trg_calendar_feeds_updated_at BEFORE UPDATE ON calendar_feeds FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+  bucket_key varchar(255) PRIMARY KEY,
+  tokens double precision NOT NULL DEFAULT 0,
+  updated_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
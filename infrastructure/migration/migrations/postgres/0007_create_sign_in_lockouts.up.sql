@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS sign_in_lockouts (
+  lockout_key varchar(255) PRIMARY KEY,
+  failures integer NOT NULL DEFAULT 0,
+  lock_count integer NOT NULL DEFAULT 0,
+  locked_until timestamptz NULL DEFAULT NULL,
+  updated_at timestamptz NOT NULL
+);
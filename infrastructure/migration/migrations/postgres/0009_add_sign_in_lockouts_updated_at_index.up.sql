@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_sign_in_lockouts_updated_at ON sign_in_lockouts (updated_at);
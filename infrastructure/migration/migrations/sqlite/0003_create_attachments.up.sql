@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS attachments (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+  file_name VARCHAR(255) NOT NULL DEFAULT '',
+  content_type VARCHAR(100) NOT NULL DEFAULT '',
+  size INTEGER NOT NULL DEFAULT 0,
+  storage_key VARCHAR(255) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_attachments_task_id ON attachments (task_id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS task_activities (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  task_id INTEGER NOT NULL,
+  actor_id INTEGER NOT NULL,
+  action VARCHAR(20) NOT NULL DEFAULT '',
+  changes TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_task_activities_task_id ON task_activities (task_id);
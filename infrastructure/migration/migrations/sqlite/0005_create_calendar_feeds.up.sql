@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+  user_id INTEGER NOT NULL PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  token VARCHAR(64) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_token ON calendar_feeds (token);
+-- MySQLの ON UPDATE CURRENT_TIMESTAMP に相当します
+CREATE TRIGGER IF NOT EXISTS trg_calendar_feeds_updated_at AFTER UPDATE ON calendar_feeds FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at BEGIN UPDATE calendar_feeds SET updated_at = CURRENT_TIMESTAMP WHERE user_id = NEW.user_id; END;
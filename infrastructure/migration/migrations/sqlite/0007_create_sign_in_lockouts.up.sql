@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS sign_in_lockouts (
+  lockout_key VARCHAR(255) NOT NULL PRIMARY KEY,
+  failures INTEGER NOT NULL DEFAULT 0,
+  lock_count INTEGER NOT NULL DEFAULT 0,
+  locked_until DATETIME NULL DEFAULT NULL,
+  updated_at DATETIME NOT NULL
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_sign_in_lockouts_updated_at;
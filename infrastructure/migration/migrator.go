@@ -0,0 +1,351 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+)
+
+// lockName 複数のプロセスが同時にマイグレーションを実行しないよう取得するロックの名前
+const lockName = "go_clean_arch.schema_migrations"
+
+// createTableQueries 適用済みのマイグレーションを記録するテーブルを作成するSQL
+// dirtyはSQLの実行中であることを表し、途中で失敗した場合は1のまま残ります
+var createTableQueries = map[database.Dialect]string{
+	database.MySQL: `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version bigint NOT NULL,
+  name varchar(255) NOT NULL,
+  checksum char(64) NOT NULL,
+  dirty tinyint(1) NOT NULL DEFAULT 0,
+  applied_at datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  PRIMARY KEY (version)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`,
+	database.Postgres: `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version bigint NOT NULL PRIMARY KEY,
+  name varchar(255) NOT NULL,
+  checksum char(64) NOT NULL,
+  dirty smallint NOT NULL DEFAULT 0,
+  applied_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+	database.SQLite: `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version INTEGER NOT NULL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  checksum CHAR(64) NOT NULL,
+  dirty INTEGER NOT NULL DEFAULT 0,
+  applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+}
+
+// Status マイグレーションの適用状況
+type Status struct {
+	Migration
+	// Applied 適用済みかどうか
+	Applied bool
+	// Dirty 適用または取り消しの途中で失敗したかどうか
+	Dirty bool
+	// AppliedAt 適用した日時
+	AppliedAt time.Time
+}
+
+// Migrator マイグレーションを適用・取り消します
+// MySQLのDDLはトランザクションで取り消せないため、失敗したマイグレーションはdirtyとして記録し、
+// 手動で修正されるまで以降のマイグレーションを実行しません
+type Migrator struct {
+	DB *sql.DB
+	// Dialect DBのSQLの方言
+	Dialect    database.Dialect
+	Migrations []Migration
+	// LockTimeout ロックを取得できるまで待つ時間
+	LockTimeout time.Duration
+}
+
+// record schema_migrationsの1行
+type record struct {
+	checksum  string
+	dirty     bool
+	appliedAt time.Time
+}
+
+// NewMigrator Migratorオブジェクトを作成します
+func NewMigrator(db *sql.DB, dialect database.Dialect, migrations []Migration) *Migrator {
+	return &Migrator{DB: db, Dialect: dialect, Migrations: migrations, LockTimeout: 30 * time.Second}
+}
+
+// Up バイナリに埋め込まれたdialect向けの未適用のマイグレーションをすべて適用します
+func Up(ctx context.Context, db *sql.DB, dialect database.Dialect) error {
+	migrations, err := Migrations(dialect)
+	if err != nil {
+		return err
+	}
+	return NewMigrator(db, dialect, migrations).Up(ctx)
+}
+
+// Up 未適用のマイグレーションをすべて適用します
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.latestVersion())
+}
+
+// To 指定したバージョンまでマイグレーションを適用、またはそれより新しいマイグレーションを取り消します
+// 0を指定した場合はすべてのマイグレーションを取り消します
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("migration version %d is not found", version)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.Migrations) - 1; i >= 0; i-- {
+			migration := m.Migrations[i]
+			if _, ok := applied[migration.Version]; ok && migration.Version > version {
+				if err := m.down(ctx, conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+		for _, migration := range m.Migrations {
+			if _, ok := applied[migration.Version]; !ok && migration.Version <= version {
+				if err := m.up(ctx, conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Down 適用済みのマイグレーションを新しいものからsteps件取り消します
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.Migrations) - 1; i >= 0 && steps > 0; i-- {
+			migration := m.Migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.down(ctx, conn, migration); err != nil {
+				return err
+			}
+			steps--
+		}
+		return nil
+	})
+}
+
+// Status すべてのマイグレーションの適用状況をバージョン順に返却します
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, m.createTableQuery())
+	if err != nil {
+		return nil, err
+	}
+	records, err := m.records(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.Migrations))
+	for _, migration := range m.Migrations {
+		status := Status{Migration: migration}
+		if r, ok := records[migration.Version]; ok {
+			status.Applied = true
+			status.Dirty = r.dirty
+			status.AppliedAt = r.appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// withLock ロックを取得した接続でfnを実行します
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	release, err := m.lock(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return m.createTable(ctx, conn, fn)
+}
+
+// lock 複数のプロセスが同時にマイグレーションを実行しないようロックを取得し、解放する関数を返却します
+// SQLiteはローカル環境とテストで1つのプロセスから実行する前提のため、ロックを取得しません
+func (m *Migrator) lock(ctx context.Context, conn *sql.Conn) (func(), error) {
+	switch m.Dialect {
+	case database.SQLite:
+		return func() {}, nil
+	case database.Postgres:
+		lockCtx, cancel := context.WithTimeout(ctx, m.LockTimeout)
+		defer cancel()
+		_, err := conn.ExecContext(lockCtx, "SELECT pg_advisory_lock(hashtext($1))", lockName)
+		if errors.Is(lockCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("migration lock timed out after %s", m.LockTimeout)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return func() {
+			_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock(hashtext($1))", lockName)
+			if err != nil {
+				slog.Error("migration lock release failed", "error", err)
+			}
+		}, nil
+	default:
+		var locked sql.NullInt64
+		err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(m.LockTimeout.Seconds())).Scan(&locked)
+		if err != nil {
+			return nil, err
+		}
+		if !locked.Valid || locked.Int64 != 1 {
+			return nil, fmt.Errorf("migration lock timed out after %s", m.LockTimeout)
+		}
+		return func() {
+			var released sql.NullInt64
+			err := conn.QueryRowContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName).Scan(&released)
+			if err != nil {
+				slog.Error("migration lock release failed", "error", err)
+			}
+		}, nil
+	}
+}
+
+// createTable schema_migrationsを作成してからfnを実行します
+func (m *Migrator) createTable(ctx context.Context, conn *sql.Conn, fn func(conn *sql.Conn) error) error {
+	_, err := conn.ExecContext(ctx, m.createTableQuery())
+	if err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) createTableQuery() string {
+	if query, ok := createTableQueries[m.Dialect]; ok {
+		return query
+	}
+	return createTableQueries[database.MySQL]
+}
+
+// applied 適用済みのマイグレーションを取得し、実行できる状態かを検証します
+// 途中で失敗したマイグレーションや、適用後に書き換えられた・削除されたマイグレーションがある場合はエラーとなります
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]record, error) {
+	records, err := m.records(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	for version, r := range records {
+		migration := m.find(version)
+		if migration == nil {
+			return nil, fmt.Errorf("applied migration %d is not found in this binary", version)
+		}
+		if r.dirty {
+			return nil, fmt.Errorf("migration %d_%s is dirty: fix the schema manually and delete or update the row in schema_migrations", version, migration.Name)
+		}
+		if r.checksum != migration.Checksum() {
+			return nil, fmt.Errorf("migration %d_%s has been modified after it was applied", version, migration.Name)
+		}
+	}
+	return records, nil
+}
+
+func (m *Migrator) records(ctx context.Context, conn *sql.Conn) (map[int64]record, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, checksum, dirty, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make(map[int64]record)
+	for rows.Next() {
+		var version int64
+		var r record
+		err := rows.Scan(&version, &r.checksum, &r.dirty, &r.appliedAt)
+		if err != nil {
+			return nil, err
+		}
+		records[version] = r
+	}
+	return records, rows.Err()
+}
+
+// up マイグレーションを適用します
+func (m *Migrator) up(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	_, err := conn.ExecContext(ctx, m.Dialect.Rebind("INSERT INTO schema_migrations(version,name,checksum,dirty) VALUES(?,?,?,1)"),
+		migration.Version, migration.Name, migration.Checksum())
+	if err != nil {
+		return err
+	}
+	err = execStatements(ctx, conn, migration.Up)
+	if err != nil {
+		return fmt.Errorf("migration %d_%s up failed: %w", migration.Version, migration.Name, err)
+	}
+	_, err = conn.ExecContext(ctx, m.Dialect.Rebind("UPDATE schema_migrations SET dirty = 0 WHERE version = ?"), migration.Version)
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "migration applied", "version", migration.Version, "name", migration.Name)
+	return nil
+}
+
+// down マイグレーションを取り消します
+func (m *Migrator) down(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	_, err := conn.ExecContext(ctx, m.Dialect.Rebind("UPDATE schema_migrations SET dirty = 1 WHERE version = ?"), migration.Version)
+	if err != nil {
+		return err
+	}
+	err = execStatements(ctx, conn, migration.Down)
+	if err != nil {
+		return fmt.Errorf("migration %d_%s down failed: %w", migration.Version, migration.Name, err)
+	}
+	_, err = conn.ExecContext(ctx, m.Dialect.Rebind("DELETE FROM schema_migrations WHERE version = ?"), migration.Version)
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "migration rolled back", "version", migration.Version, "name", migration.Name)
+	return nil
+}
+
+func execStatements(ctx context.Context, conn *sql.Conn, sql string) error {
+	for _, statement := range splitStatements(sql) {
+		_, err := conn.ExecContext(ctx, statement)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.Migrations {
+		if m.Migrations[i].Version == version {
+			return &m.Migrations[i]
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) latestVersion() int64 {
+	if len(m.Migrations) == 0 {
+		return 0
+	}
+	return m.Migrations[len(m.Migrations)-1].Version
+}
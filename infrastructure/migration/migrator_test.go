@@ -0,0 +1,229 @@
+package migration_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	infrastructure "github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/migration"
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMigrations = []migration.Migration{
+	{Version: 1, Name: "create_a", Up: "CREATE TABLE a (id int);", Down: "DROP TABLE a;"},
+	{Version: 2, Name: "create_b", Up: "CREATE TABLE b (id int);\nCREATE INDEX idx_id ON b (id);", Down: "DROP TABLE b;"},
+}
+
+var (
+	lockQuery     = regexp.QuoteMeta("SELECT GET_LOCK(?, ?)")
+	releaseQuery  = regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")
+	createQuery   = regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")
+	selectQuery   = regexp.QuoteMeta("SELECT version, checksum, dirty, applied_at FROM schema_migrations")
+	insertQuery   = regexp.QuoteMeta("INSERT INTO schema_migrations(version,name,checksum,dirty) VALUES(?,?,?,1)")
+	cleanQuery    = regexp.QuoteMeta("UPDATE schema_migrations SET dirty = 0 WHERE version = ?")
+	dirtyQuery    = regexp.QuoteMeta("UPDATE schema_migrations SET dirty = 1 WHERE version = ?")
+	deleteQuery   = regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = ?")
+	recordColumns = []string{"version", "checksum", "dirty", "applied_at"}
+)
+
+func newMigrator(t *testing.T) (*migration.Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+	return migration.NewMigrator(db, database.MySQL, testMigrations), mock
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(lockQuery).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(1))
+	mock.ExpectExec(createQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectRelease(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(releaseQuery).WillReturnRows(sqlmock.NewRows([]string{"released"}).AddRow(1))
+}
+
+func TestMigratorUp(t *testing.T) {
+	appliedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系 未適用のマイグレーションが文ごとに実行され、記録されること", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+
+		expectLock(mock)
+		mock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows(recordColumns).
+			AddRow(1, testMigrations[0].Checksum(), false, appliedAt))
+		mock.ExpectExec(insertQuery).WithArgs(int64(2), "create_b", testMigrations[1].Checksum()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE b (id int);")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("CREATE INDEX idx_id ON b (id);")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(cleanQuery).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectRelease(mock)
+
+		err := migrator.Up(context.TODO())
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系 適用済みのマイグレーションが書き換えられている場合、エラーとなること", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+
+		expectLock(mock)
+		mock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows(recordColumns).
+			AddRow(1, "modified", false, appliedAt))
+		expectRelease(mock)
+
+		err := migrator.Up(context.TODO())
+		assert.ErrorContains(t, err, "modified")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系 途中で失敗したマイグレーションがある場合、エラーとなること", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+
+		expectLock(mock)
+		mock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows(recordColumns).
+			AddRow(1, testMigrations[0].Checksum(), true, appliedAt))
+		expectRelease(mock)
+
+		err := migrator.Up(context.TODO())
+		assert.ErrorContains(t, err, "dirty")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系 SQLの実行に失敗した場合、dirtyのままエラーとなること", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+
+		expectLock(mock)
+		mock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows(recordColumns))
+		mock.ExpectExec(insertQuery).WithArgs(int64(1), "create_a", testMigrations[0].Checksum()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE a (id int);")).WillReturnError(sql.ErrConnDone)
+		expectRelease(mock)
+
+		err := migrator.Up(context.TODO())
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系 ロックを取得できない場合、エラーとなること", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+
+		mock.ExpectQuery(lockQuery).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(0))
+
+		err := migrator.Up(context.TODO())
+		assert.ErrorContains(t, err, "lock")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigratorPostgres(t *testing.T) {
+	t.Run("正常系 アドバイザリロックを取得し、$nのプレースホルダーで記録されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		migrator := migration.NewMigrator(db, database.Postgres, testMigrations[:1])
+
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock(hashtext($1))")).WithArgs("go_clean_arch.schema_migrations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(createQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows(recordColumns))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations(version,name,checksum,dirty) VALUES($1,$2,$3,1)")).
+			WithArgs(int64(1), "create_a", testMigrations[0].Checksum()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE a (id int);")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE schema_migrations SET dirty = 0 WHERE version = $1")).
+			WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock(hashtext($1))")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = migrator.Up(context.TODO())
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigratorDown(t *testing.T) {
+	appliedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系 新しいマイグレーションから指定した件数が取り消されること", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+
+		expectLock(mock)
+		mock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows(recordColumns).
+			AddRow(1, testMigrations[0].Checksum(), false, appliedAt).
+			AddRow(2, testMigrations[1].Checksum(), false, appliedAt))
+		mock.ExpectExec(dirtyQuery).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("DROP TABLE b;")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(deleteQuery).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectRelease(mock)
+
+		err := migrator.Down(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigratorTo(t *testing.T) {
+	t.Run("異常系 存在しないバージョンを指定した場合、エラーとなること", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+
+		err := migrator.To(context.TODO(), 3)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigratorStatus(t *testing.T) {
+	t.Run("正常系 適用済みと未適用のマイグレーションが返却されること", func(t *testing.T) {
+		migrator, mock := newMigrator(t)
+		appliedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectExec(createQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows(recordColumns).
+			AddRow(1, testMigrations[0].Checksum(), false, appliedAt))
+
+		statuses, err := migrator.Status(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, []migration.Status{
+			{Migration: testMigrations[0], Applied: true, AppliedAt: appliedAt},
+			{Migration: testMigrations[1]},
+		}, statuses)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigratorSQLite(t *testing.T) {
+	t.Run("正常系 SQLiteに埋め込まれたマイグレーションをすべて適用し、取り消せること", func(t *testing.T) {
+		driver, err := infrastructure.OpenSQLite(filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatalf("sqlite open error: '%s'", err)
+		}
+		defer driver.Close()
+		migrations, err := migration.Migrations(database.SQLite)
+		if err != nil {
+			t.Fatal(err)
+		}
+		migrator := migration.NewMigrator(driver.Conn, database.SQLite, migrations)
+
+		err = migrator.Up(context.TODO())
+		assert.NoError(t, err)
+		statuses, err := migrator.Status(context.TODO())
+		assert.NoError(t, err)
+		for _, status := range statuses {
+			assert.True(t, status.Applied)
+			assert.False(t, status.Dirty)
+		}
+
+		err = migrator.To(context.TODO(), 0)
+		assert.NoError(t, err)
+		var tables int
+		err = driver.Conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_migrations', 'sqlite_sequence')").Scan(&tables)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, tables)
+	})
+}
@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+)
+
+// sweepInterval 不要になったバケットを破棄する間隔
+const sweepInterval = time.Minute
+
+// MemoryLimiter プロセス内のメモリにトークンバケットを保持します
+// 複数のレプリカで実行する場合、制限はレプリカごとになります
+type MemoryLimiter struct {
+	// Now 現在日時を返却します。nilの場合はtime.Nowを使用します
+	Now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	bucket ratelimit.Bucket
+	limit  ratelimit.Limit
+}
+
+// NewMemoryLimiter: メモリにトークンバケットを保持するLimiterを作成します
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: map[string]memoryBucket{}}
+}
+
+// Allow: キーのバケットからトークンを1個消費します
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b.bucket = ratelimit.NewBucket(limit, now)
+	}
+	bucket, result := b.bucket.Take(limit, now)
+	l.buckets[key] = memoryBucket{bucket: bucket, limit: limit}
+	return result, nil
+}
+
+// sweep: トークンが満たされたバケットを破棄し、メモリの使用量を抑えます
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if b.bucket.IsFull(b.limit, now) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *MemoryLimiter) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now()
+}
+
+// MemoryLockoutStore プロセス内のメモリにロック状態を保持します
+type MemoryLockoutStore struct {
+	// ResetAfter 最後の失敗からこの時間が経過した状態を破棄します。0の場合は破棄しません
+	ResetAfter time.Duration
+
+	mu        sync.Mutex
+	states    map[string]ratelimit.LockoutState
+	lastSweep time.Time
+}
+
+// NewMemoryLockoutStore: メモリにロック状態を保持するLockoutStoreを作成します
+func NewMemoryLockoutStore(resetAfter time.Duration) *MemoryLockoutStore {
+	return &MemoryLockoutStore{ResetAfter: resetAfter, states: map[string]ratelimit.LockoutState{}}
+}
+
+// Get: ロック状態を取得します
+func (s *MemoryLockoutStore) Get(ctx context.Context, key string) (ratelimit.LockoutState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.states[key], nil
+}
+
+// Update: ロック状態をfnで更新して保存し、期限切れの状態を破棄します
+func (s *MemoryLockoutStore) Update(ctx context.Context, key string, fn func(state ratelimit.LockoutState) ratelimit.LockoutState) (ratelimit.LockoutState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := fn(s.states[key])
+	s.sweep(state.UpdatedAt)
+	s.states[key] = state
+	return state, nil
+}
+
+// sweep: 最後の失敗からResetAfterが経過し、ロックも解除された状態を破棄します
+func (s *MemoryLockoutStore) sweep(now time.Time) {
+	if s.ResetAfter <= 0 || now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for k, v := range s.states {
+		if now.Sub(v.UpdatedAt) > s.ResetAfter && v.LockedUntil.Before(now) {
+			delete(s.states, k)
+		}
+	}
+}
+
+// Delete: ロック状態を削除します
+func (s *MemoryLockoutStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, key)
+	return nil
+}
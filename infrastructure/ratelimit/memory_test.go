@@ -0,0 +1,118 @@
+package ratelimit_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/ratelimit"
+	limiter "github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLimiter(t *testing.T) {
+	limit := limiter.Limit{Burst: 3, Interval: time.Second}
+
+	t.Run("正常系 キーごとに制限され、時間の経過で再び許可されること", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		l := ratelimit.NewMemoryLimiter()
+		l.Now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			result, err := l.Allow(context.TODO(), "a", limit)
+			assert.NoError(t, err)
+			assert.True(t, result.Allowed)
+		}
+		result, _ := l.Allow(context.TODO(), "a", limit)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, time.Second, result.RetryAfter)
+
+		result, _ = l.Allow(context.TODO(), "b", limit)
+		assert.True(t, result.Allowed)
+
+		now = now.Add(time.Second)
+		result, _ = l.Allow(context.TODO(), "a", limit)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("正常系 同時に実行してもBurstを超えて許可されないこと", func(t *testing.T) {
+		l := ratelimit.NewMemoryLimiter()
+		slow := limiter.Limit{Burst: 3, Interval: time.Hour}
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		allowed := 0
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, _ := l.Allow(context.TODO(), "a", slow)
+				if result.Allowed {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 3, allowed)
+	})
+}
+
+func TestMemoryLockoutStore(t *testing.T) {
+	t.Run("正常系 更新した状態を取得・削除できること", func(t *testing.T) {
+		store := ratelimit.NewMemoryLockoutStore(time.Hour)
+		state := limiter.LockoutState{Failures: 2, UpdatedAt: time.Now()}
+
+		updated, err := store.Update(context.TODO(), "key", func(limiter.LockoutState) limiter.LockoutState { return state })
+		assert.NoError(t, err)
+		assert.Equal(t, state, updated)
+		got, err := store.Get(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.Equal(t, state, got)
+
+		err = store.Delete(context.TODO(), "key")
+		assert.NoError(t, err)
+		got, _ = store.Get(context.TODO(), "key")
+		assert.Equal(t, limiter.LockoutState{}, got)
+	})
+
+	t.Run("正常系 ResetAfterを過ぎた状態は更新時に破棄されること", func(t *testing.T) {
+		store := ratelimit.NewMemoryLockoutStore(time.Hour)
+		now := time.Now()
+		store.Update(context.TODO(), "old", func(limiter.LockoutState) limiter.LockoutState {
+			return limiter.LockoutState{Failures: 1, UpdatedAt: now.Add(-2 * time.Hour)}
+		})
+		store.Update(context.TODO(), "new", func(limiter.LockoutState) limiter.LockoutState {
+			return limiter.LockoutState{Failures: 1, UpdatedAt: now}
+		})
+
+		got, _ := store.Get(context.TODO(), "old")
+		assert.Equal(t, limiter.LockoutState{}, got)
+	})
+
+	t.Run("正常系 同時に失敗した場合も、失敗回数が失われないこと", func(t *testing.T) {
+		lockout := limiter.NewLockout(ratelimit.NewMemoryLockoutStore(time.Hour), limiter.LockoutPolicy{Threshold: 1000, Duration: time.Minute})
+		failLockoutConcurrently(t, lockout, 50)
+
+		got, err := lockout.Store.Get(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.Equal(t, 50, got.Failures)
+	})
+}
+
+// failLockoutConcurrently 同じキーの失敗をn件同時に記録します
+func failLockoutConcurrently(t *testing.T, lockout *limiter.Lockout, n int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := lockout.Fail(context.TODO(), "key")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
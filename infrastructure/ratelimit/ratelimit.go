@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+)
+
+const (
+	MEMORY = "memory"
+	// DATABASE DB_DRIVER で接続したデータベースに保持します
+	DATABASE = "database"
+	// MYSQL DATABASE の別名です。MySQL以外のデータベースに接続している場合もそのデータベースに保持します
+	MYSQL = "mysql"
+)
+
+// Config サインイン・サインアップのレート制限の設定
+type Config struct {
+	// Store トークンバケットとロックの状態の保存先
+	Store string
+	// SignInPerIP IPアドレスごとのサインインの制限
+	SignInPerIP ratelimit.Limit
+	// SignInPerEmail メールアドレスごとのサインインの制限
+	SignInPerEmail ratelimit.Limit
+	// SignUpPerIP IPアドレスごとのサインアップの制限
+	SignUpPerIP ratelimit.Limit
+	// Lockout 連続したサインインの失敗によるロックの設定
+	Lockout ratelimit.LockoutPolicy
+}
+
+// LoadConfig: 設定値からレート制限の設定を読み込みます
+// 制限は "回数/期間" (例: 10/1m)の形式で指定します
+func LoadConfig(lookup envconfig.Lookup) (Config, error) {
+	p := envconfig.NewParser(lookup)
+	config := Config{
+		Store:          p.String("RATE_LIMIT_STORE", MEMORY),
+		SignInPerIP:    parseLimit(p, "RATE_LIMIT_SIGN_IN_PER_IP", ratelimit.Limit{Burst: 20, Interval: 3 * time.Second}),
+		SignInPerEmail: parseLimit(p, "RATE_LIMIT_SIGN_IN_PER_EMAIL", ratelimit.Limit{Burst: 5, Interval: 12 * time.Second}),
+		SignUpPerIP:    parseLimit(p, "RATE_LIMIT_SIGN_UP_PER_IP", ratelimit.Limit{Burst: 10, Interval: 6 * time.Second}),
+		Lockout: ratelimit.LockoutPolicy{
+			Threshold:   p.Int("LOCKOUT_THRESHOLD", 5),
+			Duration:    p.Duration("LOCKOUT_DURATION", time.Minute),
+			MaxDuration: p.Duration("LOCKOUT_MAX_DURATION", time.Hour),
+			ResetAfter:  p.Duration("LOCKOUT_RESET_AFTER", 24*time.Hour),
+		},
+	}
+	return config, p.Err()
+}
+
+// Validate: レート制限の設定が正しいかを検証します
+func (c Config) Validate() error {
+	switch c.Store {
+	case "", MEMORY, DATABASE, MYSQL:
+	default:
+		return fmt.Errorf("unknown RATE_LIMIT_STORE: '%s'", c.Store)
+	}
+	if c.Lockout.Threshold <= 0 {
+		return fmt.Errorf("invalid LOCKOUT_THRESHOLD: '%d'", c.Lockout.Threshold)
+	}
+	durations := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"LOCKOUT_DURATION", c.Lockout.Duration},
+		{"LOCKOUT_MAX_DURATION", c.Lockout.MaxDuration},
+		{"LOCKOUT_RESET_AFTER", c.Lockout.ResetAfter},
+	}
+	for _, d := range durations {
+		if d.value < 0 {
+			return fmt.Errorf("invalid %s: '%s'", d.key, d.value)
+		}
+	}
+	return nil
+}
+
+// NewLimiter: 設定の保存先に応じたLimiterを作成します
+func NewLimiter(sqlDriver database.SqlDriver, config Config) (ratelimit.Limiter, error) {
+	switch config.Store {
+	case "", MEMORY:
+		return NewMemoryLimiter(), nil
+	case DATABASE, MYSQL:
+		return NewSqlLimiter(sqlDriver), nil
+	default:
+		return nil, fmt.Errorf("unknown RATE_LIMIT_STORE: '%s'", config.Store)
+	}
+}
+
+// NewLockoutStore: 設定の保存先に応じたLockoutStoreを作成します
+func NewLockoutStore(sqlDriver database.SqlDriver, config Config) (ratelimit.LockoutStore, error) {
+	switch config.Store {
+	case "", MEMORY:
+		return NewMemoryLockoutStore(config.Lockout.ResetAfter), nil
+	case DATABASE, MYSQL:
+		return NewSqlLockoutStore(sqlDriver, config.Lockout.ResetAfter), nil
+	default:
+		return nil, fmt.Errorf("unknown RATE_LIMIT_STORE: '%s'", config.Store)
+	}
+}
+
+// parseLimit: 設定値を "回数/期間" の形式の制限として読み込みます。未設定の場合はdefを返却します
+func parseLimit(p *envconfig.Parser, key string, def ratelimit.Limit) ratelimit.Limit {
+	value := p.String(key, "")
+	if value == "" {
+		return def
+	}
+	count, period, ok := strings.Cut(value, "/")
+	if !ok {
+		p.Invalid(key, value)
+		return def
+	}
+	burst, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || burst <= 0 {
+		p.Invalid(key, value)
+		return def
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		p.Invalid(key, value)
+		return def
+	}
+	return ratelimit.Limit{Burst: burst, Interval: d / time.Duration(burst)}
+}
@@ -0,0 +1,51 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/ratelimit"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("正常系 設定されていない場合、既定値となること", func(t *testing.T) {
+		config, err := ratelimit.LoadConfig(envconfig.Map(nil))
+
+		assert.NoError(t, err)
+		assert.NoError(t, config.Validate())
+		assert.Equal(t, ratelimit.MEMORY, config.Store)
+		assert.Equal(t, 5, config.SignInPerEmail.Burst)
+		assert.Equal(t, 12*time.Second, config.SignInPerEmail.Interval)
+		assert.Equal(t, 5, config.Lockout.Threshold)
+		assert.Equal(t, time.Hour, config.Lockout.MaxDuration)
+	})
+
+	t.Run("正常系 回数/期間の形式で制限が読み込まれること", func(t *testing.T) {
+		config, err := ratelimit.LoadConfig(envconfig.Map(map[string]string{
+			"RATE_LIMIT_SIGN_IN_PER_IP": "30/1m",
+			"LOCKOUT_THRESHOLD":         "10",
+		}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 30, config.SignInPerIP.Burst)
+		assert.Equal(t, 2*time.Second, config.SignInPerIP.Interval)
+		assert.Equal(t, 10, config.Lockout.Threshold)
+	})
+
+	t.Run("異常系 制限の形式が不正な場合、エラーとなること", func(t *testing.T) {
+		_, err := ratelimit.LoadConfig(envconfig.Map(map[string]string{"RATE_LIMIT_SIGN_IN_PER_IP": "30"}))
+
+		assert.EqualError(t, err, "invalid RATE_LIMIT_SIGN_IN_PER_IP: '30'")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("異常系 不明な保存先の場合、エラーとなること", func(t *testing.T) {
+		config, err := ratelimit.LoadConfig(envconfig.Map(map[string]string{"RATE_LIMIT_STORE": "redis"}))
+
+		assert.NoError(t, err)
+		assert.EqualError(t, config.Validate(), "unknown RATE_LIMIT_STORE: 'redis'")
+	})
+}
@@ -0,0 +1,272 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+)
+
+// SqlLimiter データベースにトークンバケットを保持します
+// 複数のレプリカで同じテーブルを参照するため、制限はすべてのレプリカで共有されます
+type SqlLimiter struct {
+	SqlDriver database.SqlDriver
+	// Now 現在日時を返却します。nilの場合はtime.Nowを使用します
+	Now func() time.Time
+
+	mu        sync.Mutex
+	retention time.Duration
+	lastSweep time.Time
+}
+
+// NewSqlLimiter: データベースにトークンバケットを保持するLimiterを作成します
+func NewSqlLimiter(sqlDriver database.SqlDriver) *SqlLimiter {
+	return &SqlLimiter{SqlDriver: sqlDriver}
+}
+
+// Allow: キーのバケットからトークンを1個消費します
+// 同じキーへの同時リクエストで消費が重複しないよう、行ロックを取得して更新します
+// 存在しない行のロックは同時に挿入しようとするトランザクション同士でデッドロックするため、
+// 先にトークンが満たされたバケットを挿入してから行ロックを取得します
+func (l *SqlLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	now := l.now()
+	var result ratelimit.Result
+	err := l.SqlDriver.Transaction(ctx, func(ctx context.Context) error {
+		bucket := ratelimit.NewBucket(limit, now)
+		query := `
+			INSERT INTO rate_limit_buckets(bucket_key,tokens,updated_at) VALUES(?,?,?)
+			` + l.SqlDriver.Dialect().OnConflictDoNothing([]string{"bucket_key"}) + `
+		`
+		_, err := l.SqlDriver.ExecuteContext(database.WithOperation(ctx, "ratelimit.Allow"), query, key, bucket.Tokens, bucket.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		query = `
+			SELECT
+				tokens, updated_at
+			FROM
+				rate_limit_buckets
+			WHERE
+				bucket_key = ?
+			` + l.SqlDriver.Dialect().ForUpdate() + `
+		`
+		rows, err := l.SqlDriver.QueryContext(database.WithOperation(ctx, "ratelimit.Allow"), query, key)
+		if err != nil {
+			return err
+		}
+		if rows.Next() {
+			err = rows.Scan(&bucket.Tokens, &bucket.UpdatedAt)
+		}
+		closeRows(ctx, rows)
+		if err != nil {
+			return err
+		}
+
+		bucket, result = bucket.Take(limit, now)
+
+		query = `
+			UPDATE rate_limit_buckets SET tokens = ?, updated_at = ? WHERE bucket_key = ?
+		`
+		_, err = l.SqlDriver.ExecuteContext(database.WithOperation(ctx, "ratelimit.Allow"), query, bucket.Tokens, bucket.UpdatedAt, key)
+		return err
+	})
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	l.sweep(ctx, limit, now)
+	return result, nil
+}
+
+// sweep: トークンが満たされたバケットを一定の間隔で削除し、テーブルの肥大化を防ぎます
+// 空のバケットが満たされるまでの時間は使用された制限のうち最も長いものとし、それより前に更新された行を削除します
+// 削除に失敗してもリクエストは制限できるため、エラーはログに出力するのみとします
+func (l *SqlLimiter) sweep(ctx context.Context, limit ratelimit.Limit, now time.Time) {
+	l.mu.Lock()
+	if refill := time.Duration(limit.Burst) * limit.Interval; refill > l.retention {
+		l.retention = refill
+	}
+	if now.Sub(l.lastSweep) < sweepInterval {
+		l.mu.Unlock()
+		return
+	}
+	l.lastSweep = now
+	retention := l.retention
+	l.mu.Unlock()
+
+	query := `
+		DELETE FROM rate_limit_buckets WHERE updated_at < ?
+	`
+	_, err := l.SqlDriver.ExecuteContext(database.WithOperation(ctx, "ratelimit.SweepBuckets"), query, now.Add(-retention))
+	if err != nil {
+		slog.ErrorContext(ctx, "rate limit buckets sweep failed", "error", err)
+	}
+}
+
+func (l *SqlLimiter) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now().UTC()
+}
+
+// SqlLockoutStore データベースにロック状態を保持します
+type SqlLockoutStore struct {
+	SqlDriver database.SqlDriver
+	// ResetAfter 最後の失敗からこの時間が経過した状態を削除します。0の場合は削除しません
+	ResetAfter time.Duration
+	// Now 現在日時を返却します。nilの場合はtime.Nowを使用します
+	Now func() time.Time
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewSqlLockoutStore: データベースにロック状態を保持するLockoutStoreを作成します
+func NewSqlLockoutStore(sqlDriver database.SqlDriver, resetAfter time.Duration) *SqlLockoutStore {
+	return &SqlLockoutStore{SqlDriver: sqlDriver, ResetAfter: resetAfter}
+}
+
+// Get: ロック状態を取得します
+func (s *SqlLockoutStore) Get(ctx context.Context, key string) (ratelimit.LockoutState, error) {
+	query := `
+		SELECT
+			failures, lock_count, locked_until, updated_at
+		FROM
+			sign_in_lockouts
+		WHERE
+			lockout_key = ?
+	`
+	return s.get(database.WithOperation(ctx, "lockout.Get"), query, key)
+}
+
+// Update: ロック状態をfnで更新して保存します
+// 同じキーへの同時の更新で失敗回数が失われないよう、行ロックを取得してから読み込みます
+// 存在しない行のロックによるデッドロックを防ぐため、先に初期状態の行を挿入します
+func (s *SqlLockoutStore) Update(ctx context.Context, key string, fn func(state ratelimit.LockoutState) ratelimit.LockoutState) (ratelimit.LockoutState, error) {
+	now := s.now()
+	var state ratelimit.LockoutState
+	err := s.SqlDriver.Transaction(ctx, func(ctx context.Context) error {
+		// 初期状態の行は失敗回数・ロック回数が0のため、updated_atに関わらずゼロ値と同じ状態として扱えます
+		query := `
+			INSERT INTO sign_in_lockouts(lockout_key,failures,lock_count,locked_until,updated_at) VALUES(?,0,0,NULL,?)
+			` + s.SqlDriver.Dialect().OnConflictDoNothing([]string{"lockout_key"}) + `
+		`
+		_, err := s.SqlDriver.ExecuteContext(database.WithOperation(ctx, "lockout.Update"), query, key, now)
+		if err != nil {
+			return err
+		}
+
+		query = `
+			SELECT
+				failures, lock_count, locked_until, updated_at
+			FROM
+				sign_in_lockouts
+			WHERE
+				lockout_key = ?
+			` + s.SqlDriver.Dialect().ForUpdate() + `
+		`
+		state, err = s.get(database.WithOperation(ctx, "lockout.Update"), query, key)
+		if err != nil {
+			return err
+		}
+
+		state = fn(state)
+
+		query = `
+			UPDATE
+				sign_in_lockouts
+			SET
+				failures = ?, lock_count = ?, locked_until = ?, updated_at = ?
+			WHERE
+				lockout_key = ?
+		`
+		lockedUntil := sql.NullTime{Time: state.LockedUntil, Valid: !state.LockedUntil.IsZero()}
+		_, err = s.SqlDriver.ExecuteContext(database.WithOperation(ctx, "lockout.Update"), query, state.Failures, state.LockCount, lockedUntil, state.UpdatedAt, key)
+		return err
+	})
+	if err != nil {
+		return ratelimit.LockoutState{}, err
+	}
+	s.sweep(ctx, now)
+	return state, nil
+}
+
+// Delete: ロック状態を削除します
+func (s *SqlLockoutStore) Delete(ctx context.Context, key string) error {
+	query := `
+		DELETE FROM sign_in_lockouts WHERE lockout_key = ?
+	`
+	_, err := s.SqlDriver.ExecuteContext(database.WithOperation(ctx, "lockout.Delete"), query, key)
+	return err
+}
+
+// get: queryで1行のロック状態を取得します。存在しない場合はゼロ値を返却します
+// 操作名は呼び出し元でctxに設定します
+func (s *SqlLockoutStore) get(ctx context.Context, query string, key string) (ratelimit.LockoutState, error) {
+	rows, err := s.SqlDriver.QueryContext(ctx, query, key)
+	if err != nil {
+		return ratelimit.LockoutState{}, err
+	}
+	defer closeRows(ctx, rows)
+
+	if !rows.Next() {
+		return ratelimit.LockoutState{}, nil
+	}
+
+	state := ratelimit.LockoutState{}
+	var lockedUntil sql.NullTime
+	err = rows.Scan(
+		&state.Failures,
+		&state.LockCount,
+		&lockedUntil,
+		&state.UpdatedAt,
+	)
+	if err != nil {
+		return ratelimit.LockoutState{}, err
+	}
+	state.LockedUntil = lockedUntil.Time
+
+	return state, nil
+}
+
+// sweep: 最後の失敗からResetAfterが経過し、ロックも解除された状態を一定の間隔で削除します
+// 削除に失敗しても失敗は記録できているため、エラーはログに出力するのみとします
+func (s *SqlLockoutStore) sweep(ctx context.Context, now time.Time) {
+	if s.ResetAfter <= 0 {
+		return
+	}
+	s.mu.Lock()
+	if now.Sub(s.lastSweep) < sweepInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = now
+	s.mu.Unlock()
+
+	query := `
+		DELETE FROM sign_in_lockouts WHERE updated_at < ? AND (locked_until IS NULL OR locked_until < ?)
+	`
+	_, err := s.SqlDriver.ExecuteContext(database.WithOperation(ctx, "lockout.Sweep"), query, now.Add(-s.ResetAfter), now)
+	if err != nil {
+		slog.ErrorContext(ctx, "sign in lockouts sweep failed", "error", err)
+	}
+}
+
+func (s *SqlLockoutStore) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now().UTC()
+}
+
+func closeRows(ctx context.Context, rows database.Rows) {
+	err := rows.Close()
+	if err != nil {
+		slog.ErrorContext(ctx, "rows close failed", "error", err)
+	}
+}
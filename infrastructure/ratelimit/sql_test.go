@@ -0,0 +1,272 @@
+package ratelimit_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database/databasetest"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/ratelimit"
+	limiter "github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqlLimiter(t *testing.T) {
+	insertQuery := regexp.QuoteMeta("INSERT INTO rate_limit_buckets(bucket_key,tokens,updated_at) VALUES(?,?,?) ON DUPLICATE KEY UPDATE bucket_key = bucket_key")
+	selectQuery := regexp.QuoteMeta("FROM rate_limit_buckets WHERE bucket_key = ? FOR UPDATE")
+	updateQuery := regexp.QuoteMeta("UPDATE rate_limit_buckets SET tokens = ?, updated_at = ? WHERE bucket_key = ?")
+	sweepQuery := regexp.QuoteMeta("DELETE FROM rate_limit_buckets WHERE updated_at < ?")
+	limit := limiter.Limit{Burst: 2, Interval: 10 * time.Second}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系 バケットが存在しない場合、Burstから1個消費して保存されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		l := ratelimit.NewSqlLimiter(&database.SqlDriver{Conn: db})
+		l.Now = func() time.Time { return now }
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(insertQuery).ExpectExec().WithArgs("key", float64(2), now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(selectQuery).WithArgs("key").
+			WillReturnRows(sqlmock.NewRows([]string{"tokens", "updated_at"}).AddRow(float64(2), now))
+		mock.ExpectPrepare(updateQuery).ExpectExec().WithArgs(float64(1), now, "key").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectPrepare(sweepQuery).ExpectExec().WithArgs(now.Add(-20 * time.Second)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		result, err := l.Allow(context.TODO(), "key", limit)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1, result.Remaining)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系 トークンが不足している場合、許可されないこと", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		l := ratelimit.NewSqlLimiter(&database.SqlDriver{Conn: db})
+		l.Now = func() time.Time { return now }
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(insertQuery).ExpectExec().WithArgs("key", float64(2), now).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(selectQuery).WithArgs("key").
+			WillReturnRows(sqlmock.NewRows([]string{"tokens", "updated_at"}).AddRow(0.5, now))
+		mock.ExpectPrepare(updateQuery).ExpectExec().WithArgs(0.5, now, "key").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectPrepare(sweepQuery).ExpectExec().WithArgs(now.Add(-20 * time.Second)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		result, err := l.Allow(context.TODO(), "key", limit)
+		assert.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 5*time.Second, result.RetryAfter)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系 バケットの取得に失敗した場合、ロールバックされエラーとなること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		l := ratelimit.NewSqlLimiter(&database.SqlDriver{Conn: db})
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(insertQuery).ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(selectQuery).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		_, err = l.Allow(context.TODO(), "key", limit)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSqlLockoutStore(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系 ロック状態が取得できること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		store := ratelimit.NewSqlLockoutStore(&database.SqlDriver{Conn: db}, 0)
+
+		rows := sqlmock.NewRows([]string{"failures", "lock_count", "locked_until", "updated_at"}).
+			AddRow(0, 1, now.Add(time.Minute), now)
+		mock.ExpectQuery(regexp.QuoteMeta("FROM sign_in_lockouts WHERE lockout_key = ?")).
+			WithArgs("key").WillReturnRows(rows)
+
+		state, err := store.Get(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.Equal(t, limiter.LockoutState{LockCount: 1, LockedUntil: now.Add(time.Minute), UpdatedAt: now}, state)
+	})
+
+	t.Run("正常系 存在しない場合、ゼロ値が返却されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		store := ratelimit.NewSqlLockoutStore(&database.SqlDriver{Conn: db}, 0)
+
+		rows := sqlmock.NewRows([]string{"failures", "lock_count", "locked_until", "updated_at"})
+		mock.ExpectQuery(regexp.QuoteMeta("FROM sign_in_lockouts WHERE lockout_key = ?")).
+			WithArgs("key").WillReturnRows(rows)
+
+		state, err := store.Get(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.Equal(t, limiter.LockoutState{}, state)
+	})
+
+	t.Run("正常系 行を挿入してからロックし、更新した状態が保存されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		store := ratelimit.NewSqlLockoutStore(&database.SqlDriver{Conn: db}, 0)
+		store.Now = func() time.Time { return now }
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO sign_in_lockouts(lockout_key,failures,lock_count,locked_until,updated_at) VALUES(?,0,0,NULL,?) ON DUPLICATE KEY UPDATE lockout_key = lockout_key")).
+			ExpectExec().WithArgs("key", now).WillReturnResult(sqlmock.NewResult(0, 0))
+		rows := sqlmock.NewRows([]string{"failures", "lock_count", "locked_until", "updated_at"}).AddRow(1, 0, nil, now)
+		mock.ExpectQuery(regexp.QuoteMeta("FROM sign_in_lockouts WHERE lockout_key = ? FOR UPDATE")).
+			WithArgs("key").WillReturnRows(rows)
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE sign_in_lockouts SET failures = ?, lock_count = ?, locked_until = ?, updated_at = ? WHERE lockout_key = ?")).
+			ExpectExec().WithArgs(2, 0, nil, now, "key").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		state, err := store.Update(context.TODO(), "key", func(state limiter.LockoutState) limiter.LockoutState {
+			assert.Equal(t, 1, state.Failures)
+			state.Failures++
+			return state
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, state.Failures)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系 ResetAfterを過ぎ、ロックも解除された状態が削除されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		store := ratelimit.NewSqlLockoutStore(&database.SqlDriver{Conn: db}, time.Hour)
+		store.Now = func() time.Time { return now }
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO sign_in_lockouts")).ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("FROM sign_in_lockouts")).
+			WillReturnRows(sqlmock.NewRows([]string{"failures", "lock_count", "locked_until", "updated_at"}).AddRow(0, 0, nil, now))
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE sign_in_lockouts")).ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM sign_in_lockouts WHERE updated_at < ? AND (locked_until IS NULL OR locked_until < ?)")).
+			ExpectExec().WithArgs(now.Add(-time.Hour), now).WillReturnResult(sqlmock.NewResult(0, 3))
+
+		_, err = store.Update(context.TODO(), "key", func(state limiter.LockoutState) limiter.LockoutState { return state })
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系 ロック状態が削除されること", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock error: '%s'", err)
+		}
+		store := ratelimit.NewSqlLockoutStore(&database.SqlDriver{Conn: db}, 0)
+
+		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM sign_in_lockouts WHERE lockout_key = ?")).ExpectExec().
+			WithArgs("key").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = store.Delete(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSQLite(t *testing.T) {
+	sqlDriver := databasetest.NewSQLite(t)
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60))
+
+	t.Run("正常系 同じキーのバケットが更新され、Burstを超えると許可されないこと", func(t *testing.T) {
+		l := ratelimit.NewSqlLimiter(sqlDriver)
+		l.Now = func() time.Time { return now }
+		limit := limiter.Limit{Burst: 2, Interval: 10 * time.Second}
+
+		for _, allowed := range []bool{true, true, false} {
+			result, err := l.Allow(context.TODO(), "key", limit)
+			assert.NoError(t, err)
+			assert.Equal(t, allowed, result.Allowed)
+		}
+	})
+
+	t.Run("正常系 更新したロック状態が上書きされ、削除できること", func(t *testing.T) {
+		store := ratelimit.NewSqlLockoutStore(sqlDriver, time.Hour)
+		locked := limiter.LockoutState{Failures: 5, LockCount: 1, LockedUntil: now.Add(time.Minute), UpdatedAt: now}
+
+		_, err := store.Update(context.TODO(), "key", func(limiter.LockoutState) limiter.LockoutState {
+			return limiter.LockoutState{Failures: 1, UpdatedAt: now}
+		})
+		assert.NoError(t, err)
+		_, err = store.Update(context.TODO(), "key", func(limiter.LockoutState) limiter.LockoutState { return locked })
+		assert.NoError(t, err)
+
+		state, err := store.Get(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.Equal(t, locked.Failures, state.Failures)
+		assert.Equal(t, locked.LockCount, state.LockCount)
+		assert.True(t, locked.LockedUntil.Equal(state.LockedUntil))
+		assert.True(t, locked.UpdatedAt.Equal(state.UpdatedAt))
+
+		err = store.Delete(context.TODO(), "key")
+		assert.NoError(t, err)
+		state, err = store.Get(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.Equal(t, limiter.LockoutState{}, state)
+	})
+
+	t.Run("正常系 同時に失敗した場合も、失敗回数が失われないこと", func(t *testing.T) {
+		lockout := limiter.NewLockout(ratelimit.NewSqlLockoutStore(sqlDriver, time.Hour), limiter.LockoutPolicy{Threshold: 1000, Duration: time.Minute})
+		failLockoutConcurrently(t, lockout, 20)
+
+		state, err := lockout.Store.Get(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.Equal(t, 20, state.Failures)
+	})
+
+	t.Run("正常系 同時に消費した場合も、Burstを超えて許可されないこと", func(t *testing.T) {
+		l := ratelimit.NewSqlLimiter(sqlDriver)
+		limit := limiter.Limit{Burst: 5, Interval: time.Hour}
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		allowed := 0
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := l.Allow(context.TODO(), "concurrent", limit)
+				assert.NoError(t, err)
+				if result.Allowed {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 5, allowed)
+	})
+}
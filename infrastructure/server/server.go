@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
+)
+
+// Config HTTPサーバーの設定
+type Config struct {
+	// Addr 待ち受けるアドレス
+	Addr string
+	// ReadHeaderTimeout リクエストヘッダーの読み込みの制限時間
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout ボディを含むリクエスト全体の読み込みの制限時間
+	ReadTimeout time.Duration
+	// WriteTimeout レスポンスの書き込みの制限時間
+	WriteTimeout time.Duration
+	// IdleTimeout Keep-Aliveで次のリクエストを待つ時間
+	IdleTimeout time.Duration
+	// MaxHeaderBytes リクエストヘッダーの最大サイズ
+	MaxHeaderBytes int
+	// MaxJSONBodyBytes JSON形式のリクエストボディの最大サイズ
+	MaxJSONBodyBytes int64
+	// DefaultLanguage Accept-Languageに対応する言語がない場合に使用するメッセージの言語
+	DefaultLanguage i18n.Language
+	// ShutdownDelay 停止の通知後、ロードバランサーから切り離されるまで受け付けを続ける時間
+	ShutdownDelay time.Duration
+	// ShutdownTimeout 停止時に処理中のリクエストの完了を待つ時間
+	ShutdownTimeout time.Duration
+}
+
+// LoadConfig: 設定値からHTTPサーバーの設定を読み込みます
+// 時間はGoのDuration形式(例: 30s)で指定します
+func LoadConfig(lookup envconfig.Lookup) (Config, error) {
+	p := envconfig.NewParser(lookup)
+	config := Config{
+		Addr:              ":" + p.String("SERVER_PORT", "8080"),
+		ReadHeaderTimeout: p.Duration("SERVER_READ_HEADER_TIMEOUT", 5*time.Second),
+		ReadTimeout:       p.Duration("SERVER_READ_TIMEOUT", 30*time.Second),
+		WriteTimeout:      p.Duration("SERVER_WRITE_TIMEOUT", 60*time.Second),
+		IdleTimeout:       p.Duration("SERVER_IDLE_TIMEOUT", 120*time.Second),
+		MaxHeaderBytes:    p.Int("SERVER_MAX_HEADER_BYTES", http.DefaultMaxHeaderBytes),
+		MaxJSONBodyBytes:  int64(p.Int("SERVER_MAX_JSON_BODY_BYTES", 1<<20)),
+		DefaultLanguage:   i18n.Language(p.String("SERVER_DEFAULT_LANGUAGE", string(i18n.Japanese))),
+		ShutdownDelay:     p.Duration("SERVER_SHUTDOWN_DELAY", 0),
+		ShutdownTimeout:   p.Duration("SERVER_SHUTDOWN_TIMEOUT", 30*time.Second),
+	}
+	return config, p.Err()
+}
+
+// Validate: HTTPサーバーの設定が正しいかを検証します
+func (c Config) Validate() error {
+	durations := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"SERVER_READ_HEADER_TIMEOUT", c.ReadHeaderTimeout},
+		{"SERVER_READ_TIMEOUT", c.ReadTimeout},
+		{"SERVER_WRITE_TIMEOUT", c.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", c.IdleTimeout},
+		{"SERVER_SHUTDOWN_DELAY", c.ShutdownDelay},
+		{"SERVER_SHUTDOWN_TIMEOUT", c.ShutdownTimeout},
+	}
+	for _, d := range durations {
+		if d.value < 0 {
+			return fmt.Errorf("invalid %s: '%s'", d.key, d.value)
+		}
+	}
+	if c.MaxHeaderBytes <= 0 {
+		return fmt.Errorf("invalid SERVER_MAX_HEADER_BYTES: '%d'", c.MaxHeaderBytes)
+	}
+	if c.MaxJSONBodyBytes <= 0 {
+		return fmt.Errorf("invalid SERVER_MAX_JSON_BODY_BYTES: '%d'", c.MaxJSONBodyBytes)
+	}
+	if !i18n.Supported(c.DefaultLanguage) {
+		return fmt.Errorf("invalid SERVER_DEFAULT_LANGUAGE: '%s'", c.DefaultLanguage)
+	}
+	return nil
+}
+
+// NewServer: 設定からHTTPサーバーを作成します
+func NewServer(config Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              config.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		ReadTimeout:       config.ReadTimeout,
+		WriteTimeout:      config.WriteTimeout,
+		IdleTimeout:       config.IdleTimeout,
+		MaxHeaderBytes:    config.MaxHeaderBytes,
+	}
+}
+
+// Run: HTTPサーバーを起動し、ctxが終了するまでリクエストを処理します
+// ctxの終了後はonShutdownを実行し、ShutdownDelayの間はリクエストの受け付けを続けます
+// その後、新しい接続の受け付けを止め、ShutdownTimeoutまで処理中のリクエストの完了を待ちます
+func Run(ctx context.Context, srv *http.Server, config Config, onShutdown ...func()) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down server")
+	for _, fn := range onShutdown {
+		fn()
+	}
+	if config.ShutdownDelay > 0 {
+		select {
+		case err := <-errCh:
+			return err
+		case <-time.After(config.ShutdownDelay):
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+	err := srv.Shutdown(shutdownCtx)
+	if err != nil {
+		return err
+	}
+	err = <-errCh
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,136 @@
+package server_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/server"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("正常系 設定されていない場合、既定値となること", func(t *testing.T) {
+		config, err := server.LoadConfig(envconfig.Map(nil))
+
+		assert.NoError(t, err)
+		assert.NoError(t, config.Validate())
+		assert.Equal(t, ":8080", config.Addr)
+		assert.Equal(t, 5*time.Second, config.ReadHeaderTimeout)
+		assert.Equal(t, http.DefaultMaxHeaderBytes, config.MaxHeaderBytes)
+		assert.Equal(t, i18n.Japanese, config.DefaultLanguage)
+	})
+
+	t.Run("正常系 設定値が読み込まれること", func(t *testing.T) {
+		config, err := server.LoadConfig(envconfig.Map(map[string]string{
+			"SERVER_PORT":             "9090",
+			"SERVER_WRITE_TIMEOUT":    "15s",
+			"SERVER_MAX_HEADER_BYTES": "4096",
+		}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, ":9090", config.Addr)
+		assert.Equal(t, 15*time.Second, config.WriteTimeout)
+		assert.Equal(t, 4096, config.MaxHeaderBytes)
+	})
+
+	t.Run("異常系 形式が不正な場合、エラーとなること", func(t *testing.T) {
+		_, err := server.LoadConfig(envconfig.Map(map[string]string{"SERVER_READ_TIMEOUT": "30"}))
+
+		assert.EqualError(t, err, "invalid SERVER_READ_TIMEOUT: '30'")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	valid, err := server.LoadConfig(envconfig.Map(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("異常系 対応していない言語の場合、エラーとなること", func(t *testing.T) {
+		config := valid
+		config.DefaultLanguage = "fr"
+		assert.EqualError(t, config.Validate(), "invalid SERVER_DEFAULT_LANGUAGE: 'fr'")
+	})
+
+	t.Run("異常系 リクエストボディの最大サイズが0の場合、エラーとなること", func(t *testing.T) {
+		config := valid
+		config.MaxJSONBodyBytes = 0
+		assert.EqualError(t, config.Validate(), "invalid SERVER_MAX_JSON_BODY_BYTES: '0'")
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("正常系 停止時に処理中のリクエストが完了してから終了すること", func(t *testing.T) {
+		addr := freeAddr(t)
+		started := make(chan struct{})
+		shutdownCalled := false
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(200 * time.Millisecond)
+			w.Write([]byte("done"))
+		})
+		config := server.Config{Addr: addr, ShutdownTimeout: 5 * time.Second}
+		srv := server.NewServer(config, handler)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() {
+			runErr <- server.Run(ctx, srv, config, func() { shutdownCalled = true })
+		}()
+
+		type result struct {
+			body string
+			err  error
+		}
+		resCh := make(chan result, 1)
+		go func() {
+			res, err := getWithRetry("http://" + addr)
+			if err != nil {
+				resCh <- result{err: err}
+				return
+			}
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			resCh <- result{string(body), err}
+		}()
+
+		<-started
+		cancel()
+
+		res := <-resCh
+		assert.NoError(t, res.err)
+		assert.Equal(t, "done", res.body)
+		assert.NoError(t, <-runErr)
+		assert.True(t, shutdownCalled)
+	})
+}
+
+// freeAddr 空いているポートのアドレスを取得します
+func freeAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().String()
+}
+
+// getWithRetry サーバーが起動するまでリクエストを再試行します
+func getWithRetry(url string) (*http.Response, error) {
+	var err error
+	for i := 0; i < 50; i++ {
+		var res *http.Response
+		res, err = http.Get(url)
+		if err == nil {
+			return res, nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil, err
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Hajime3778/go-clean-arch/interface/storage"
+)
+
+// LocalBlobStore ローカルファイルシステムにファイルを保存します
+type LocalBlobStore struct {
+	Root string
+}
+
+// NewLocalBlobStore: 指定したディレクトリを保存先とするBlobStoreを作成します
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(abs, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalBlobStore{Root: abs}, nil
+}
+
+// Put: ファイルを保存します(一時ファイルに書き込んでからリネームします)
+func (s *LocalBlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("size mismatch: expected %d bytes, wrote %d bytes", size, written)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get: ファイルを読み込みます
+func (s *LocalBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, storage.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Delete: ファイルを削除します
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path: キーから保存先のパスを作成します(ルートディレクトリ外は指定できません)
+func (s *LocalBlobStore) path(key string) (string, error) {
+	path := filepath.Join(s.Root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, s.Root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid key: '%s'", key)
+	}
+	return path, nil
+}
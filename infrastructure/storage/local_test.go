@@ -0,0 +1,55 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	infrastructure "github.com/Hajime3778/go-clean-arch/infrastructure/storage"
+	"github.com/Hajime3778/go-clean-arch/interface/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	ctx := context.TODO()
+	store, err := infrastructure.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("正常系 保存したファイルを取得、削除できること", func(t *testing.T) {
+		content := "test content"
+		err := store.Put(ctx, "tasks/1/foo", strings.NewReader(content), int64(len(content)), "text/plain")
+		assert.NoError(t, err)
+
+		body, err := store.Get(ctx, "tasks/1/foo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(body)
+		body.Close()
+		assert.Equal(t, content, string(data))
+
+		assert.NoError(t, store.Delete(ctx, "tasks/1/foo"))
+		_, err = store.Get(ctx, "tasks/1/foo")
+		assert.Equal(t, storage.ErrObjectNotFound, err)
+	})
+
+	t.Run("準正常系 存在しないファイルを削除してもエラーとならないこと", func(t *testing.T) {
+		assert.NoError(t, store.Delete(ctx, "tasks/1/not_found"))
+	})
+
+	t.Run("異常系 サイズが一致しない場合、エラーとなり保存されないこと", func(t *testing.T) {
+		err := store.Put(ctx, "tasks/1/bar", strings.NewReader("12345"), 10, "text/plain")
+		assert.Error(t, err)
+
+		_, err = store.Get(ctx, "tasks/1/bar")
+		assert.Equal(t, storage.ErrObjectNotFound, err)
+	})
+
+	t.Run("異常系 ルートディレクトリ外のキーを指定した場合、エラーとなること", func(t *testing.T) {
+		err := store.Put(ctx, "../escape", strings.NewReader("x"), 1, "text/plain")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,95 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	infrastructure "github.com/Hajime3778/go-clean-arch/infrastructure/storage"
+	"github.com/Hajime3778/go-clean-arch/interface/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 テスト用のS3互換サーバー(パス形式のPUT/GET/DELETEのみ対応)
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=test-access-key/") ||
+		!strings.Contains(auth, "/ap-northeast-1/s3/aws4_request") ||
+		r.Header.Get("X-Amz-Date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3BlobStore(t *testing.T) {
+	ctx := context.TODO()
+	fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store := infrastructure.NewS3BlobStore(infrastructure.S3Config{
+		Endpoint:        server.URL,
+		Region:          "ap-northeast-1",
+		Bucket:          "attachments",
+		AccessKeyID:     "test-access-key",
+		SecretAccessKey: "test-secret-key",
+	})
+
+	t.Run("正常系 保存したオブジェクトを取得、削除できること", func(t *testing.T) {
+		content := "test content"
+		err := store.Put(ctx, "tasks/1/foo", strings.NewReader(content), int64(len(content)), "text/plain")
+		assert.NoError(t, err)
+		assert.Equal(t, "text/plain", fake.types["/attachments/tasks/1/foo"])
+
+		body, err := store.Get(ctx, "tasks/1/foo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(body)
+		body.Close()
+		assert.Equal(t, content, string(data))
+
+		assert.NoError(t, store.Delete(ctx, "tasks/1/foo"))
+		_, err = store.Get(ctx, "tasks/1/foo")
+		assert.Equal(t, storage.ErrObjectNotFound, err)
+	})
+
+	t.Run("異常系 認証に失敗した場合、エラーとなること", func(t *testing.T) {
+		invalid := infrastructure.NewS3BlobStore(infrastructure.S3Config{
+			Endpoint: server.URL,
+			Region:   "us-west-2",
+			Bucket:   "attachments",
+		})
+		err := invalid.Put(ctx, "tasks/1/foo", strings.NewReader("x"), 1, "text/plain")
+		assert.Error(t, err)
+	})
+}
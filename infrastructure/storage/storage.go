@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/Hajime3778/go-clean-arch/interface/storage"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+)
+
+const (
+	LOCAL = "local"
+	S3    = "s3"
+)
+
+// Config 添付ファイルの保存先の設定
+type Config struct {
+	// Store 保存先
+	Store string
+	// LocalDir Store が local の場合に保存するディレクトリ
+	LocalDir string
+	// S3 Store が s3 の場合の接続設定
+	S3 S3Config
+}
+
+// LoadConfig: 設定値から添付ファイルの保存先の設定を読み込みます
+func LoadConfig(lookup envconfig.Lookup) (Config, error) {
+	p := envconfig.NewParser(lookup)
+	config := Config{
+		Store:    p.String("BLOB_STORE", LOCAL),
+		LocalDir: p.String("BLOB_LOCAL_DIR", "./data/attachments"),
+		S3: S3Config{
+			Endpoint:        p.String("S3_ENDPOINT", ""),
+			Region:          p.String("S3_REGION", ""),
+			Bucket:          p.String("S3_BUCKET", ""),
+			AccessKeyID:     p.String("S3_ACCESS_KEY_ID", ""),
+			SecretAccessKey: p.String("S3_SECRET_ACCESS_KEY", ""),
+		},
+	}
+	return config, p.Err()
+}
+
+// Validate: 添付ファイルの保存先の設定が正しいかを検証します
+func (c Config) Validate() error {
+	switch c.Store {
+	case LOCAL:
+		if c.LocalDir == "" {
+			return fmt.Errorf("BLOB_LOCAL_DIR is required when BLOB_STORE is '%s'", LOCAL)
+		}
+	case S3:
+		if c.S3.Endpoint == "" || c.S3.Bucket == "" {
+			return fmt.Errorf("S3_ENDPOINT and S3_BUCKET are required when BLOB_STORE is '%s'", S3)
+		}
+	default:
+		return fmt.Errorf("unknown BLOB_STORE: '%s'", c.Store)
+	}
+	return nil
+}
+
+// NewBlobStore: 設定の保存先に応じたBlobStoreを作成します
+func NewBlobStore(config Config) (storage.BlobStore, error) {
+	switch config.Store {
+	case LOCAL:
+		store, err := NewLocalBlobStore(config.LocalDir)
+		if err != nil {
+			return nil, fmt.Errorf("local blob store init failed: %w", err)
+		}
+		return store, nil
+	case S3:
+		return NewS3BlobStore(config.S3), nil
+	default:
+		return nil, fmt.Errorf("unknown BLOB_STORE: '%s'", config.Store)
+	}
+}
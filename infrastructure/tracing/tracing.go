@@ -0,0 +1,145 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// OTEL_TRACES_EXPORTERに指定できるエクスポーター
+const (
+	NONE   = "none"
+	STDOUT = "stdout"
+	FILE   = "file"
+	OTLP   = "otlp"
+)
+
+// defaultServiceName OTEL_SERVICE_NAMEが設定されていない場合のサービス名
+const defaultServiceName = "go-clean-arch"
+
+// Config トレースの設定
+type Config struct {
+	// Exporter スパンの出力先
+	Exporter string
+	// ServiceName スパンに付与するサービス名
+	ServiceName string
+	// File Exporter が file の場合に追記するファイル
+	File string
+	// OTLPEndpoint Exporter が otlp の場合に送信するコレクターのURL
+	// 未設定の場合はOpenTelemetryの既定値(http://localhost:4318)を使用します
+	OTLPEndpoint string
+}
+
+// LoadConfig: 設定値からトレースの設定を読み込みます
+func LoadConfig(lookup envconfig.Lookup) (Config, error) {
+	p := envconfig.NewParser(lookup)
+	config := Config{
+		Exporter:     p.String("OTEL_TRACES_EXPORTER", NONE),
+		ServiceName:  p.String("OTEL_SERVICE_NAME", defaultServiceName),
+		File:         p.String("OTEL_TRACES_FILE", ""),
+		OTLPEndpoint: p.String("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+	}
+	return config, p.Err()
+}
+
+// Validate: トレースの設定が正しいかを検証します
+func (c Config) Validate() error {
+	switch c.Exporter {
+	case "", NONE, STDOUT, OTLP:
+	case FILE:
+		if c.File == "" {
+			return fmt.Errorf("OTEL_TRACES_FILE is required when OTEL_TRACES_EXPORTER is '%s'", FILE)
+		}
+	default:
+		return fmt.Errorf("unknown OTEL_TRACES_EXPORTER: '%s'", c.Exporter)
+	}
+	return nil
+}
+
+// Init: 設定のエクスポーターでTracerProviderを作成し、標準に設定します
+// W3C Trace Contextで伝搬するように設定します
+//   - none(既定): スパンを出力しません
+//   - stdout: 標準出力にJSON形式で出力します
+//   - file: FileのファイルにJSON形式で追記します
+//   - otlp: OTLPEndpointのコレクターにOTLP/HTTPで送信します
+//
+// 返却した関数は、停止時にバッファのスパンを出力してから終了します
+func Init(ctx context.Context, config Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if config.Exporter == "" || config.Exporter == NONE {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closer, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if closeErr := closer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+// newExporter: エクスポーターを作成します。ファイルに出力する場合は、停止時に閉じるファイルも返却します
+func newExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch config.Exporter {
+	case STDOUT:
+		exporter, err := stdouttrace.New()
+		return exporter, nil, err
+	case FILE:
+		if config.File == "" {
+			return nil, nil, fmt.Errorf("OTEL_TRACES_FILE is required when OTEL_TRACES_EXPORTER is '%s'", FILE)
+		}
+		file, err := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return exporter, file, nil
+	case OTLP:
+		var options []otlptracehttp.Option
+		if config.OTLPEndpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(config.OTLPEndpoint))
+		}
+		exporter, err := otlptracehttp.New(ctx, options...)
+		return exporter, nil, err
+	default:
+		return nil, nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER: '%s'", config.Exporter)
+	}
+}
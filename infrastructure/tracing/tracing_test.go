@@ -0,0 +1,57 @@
+package tracing_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/tracing"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+	utilTracing "github.com/Hajime3778/go-clean-arch/util/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+)
+
+func TestInit(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	t.Run("正常系 fileを指定した場合、停止時にスパンがファイルに出力されること", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "traces.json")
+		config, err := tracing.LoadConfig(envconfig.Map(map[string]string{
+			"OTEL_TRACES_EXPORTER": tracing.FILE,
+			"OTEL_TRACES_FILE":     path,
+		}))
+		assert.NoError(t, err)
+
+		shutdown, err := tracing.Init(context.TODO(), config)
+		assert.NoError(t, err)
+
+		_, span := utilTracing.Start(context.TODO(), "test span")
+		span.End()
+		err = shutdown(context.TODO())
+		assert.NoError(t, err)
+
+		b, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), `"Name":"test span"`)
+		assert.Contains(t, string(b), `"go-clean-arch"`)
+	})
+
+	t.Run("準正常系 fileを指定しOTEL_TRACES_FILEがない場合、エラーとなること", func(t *testing.T) {
+		config := tracing.Config{Exporter: tracing.FILE}
+
+		assert.Error(t, config.Validate())
+		_, err := tracing.Init(context.TODO(), config)
+		assert.Error(t, err)
+	})
+
+	t.Run("準正常系 未対応のエクスポーターの場合、エラーとなること", func(t *testing.T) {
+		config := tracing.Config{Exporter: "zipkin"}
+
+		assert.EqualError(t, config.Validate(), "unknown OTEL_TRACES_EXPORTER: 'zipkin'")
+		_, err := tracing.Init(context.TODO(), config)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,91 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+)
+
+type activityRepository struct {
+	SqlDriver database.SqlDriver
+}
+
+// NewActivityRepository タスク変更履歴のRepositoryオブジェクトを作成します
+func NewActivityRepository(sqlDriver database.SqlDriver) ActivityRepository {
+	return &activityRepository{sqlDriver}
+}
+
+// FindByTaskID 変更履歴をタスクIDで古い順に全件取得します
+func (ar *activityRepository) FindByTaskID(ctx context.Context, taskID int64) ([]domain.TaskActivity, error) {
+	query := `
+		SELECT
+			id, task_id, actor_id, action, changes, created_at
+		FROM
+			task_activities
+		WHERE
+			task_id = ?
+		ORDER BY
+			id
+	`
+
+	rows, err := ar.SqlDriver.QueryContext(database.WithOperation(ctx, "activity.FindByTaskID"), query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "rows close failed", "error", err)
+		}
+	}()
+
+	activities := make([]domain.TaskActivity, 0)
+	for rows.Next() {
+		activity := domain.TaskActivity{}
+		var changes string
+		err = rows.Scan(
+			&activity.ID,
+			&activity.TaskID,
+			&activity.ActorID,
+			&activity.Action,
+			&changes,
+			&activity.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(changes), &activity.Changes)
+		if err != nil {
+			return nil, err
+		}
+		activities = append(activities, activity)
+	}
+
+	return activities, nil
+}
+
+// Create 変更履歴を1件作成します
+func (ar *activityRepository) Create(ctx context.Context, activity domain.TaskActivity) (int64, error) {
+	query := `
+		INSERT INTO task_activities(task_id,actor_id,action,changes) VALUES(?,?,?,?)
+	`
+	if activity.Changes == nil {
+		activity.Changes = []domain.FieldChange{}
+	}
+	changes, err := json.Marshal(activity.Changes)
+	if err != nil {
+		return 0, err
+	}
+
+	createdId, err := ar.SqlDriver.InsertContext(database.WithOperation(ctx, "activity.Create"), query, activity.TaskID, activity.ActorID, activity.Action, string(changes))
+	if err != nil {
+		return 0, err
+	}
+
+	return createdId, nil
+}
@@ -0,0 +1,121 @@
+package activity_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Hajime3778/go-clean-arch/domain"
+	infrastructure "github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	activityRepository "github.com/Hajime3778/go-clean-arch/interface/database/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindByTaskID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := activityRepository.NewActivityRepository(sqlDriver)
+	query := "SELECT id, task_id, actor_id, action, changes, created_at FROM task_activities WHERE task_id = ? ORDER BY id"
+	columns := []string{"id", "task_id", "actor_id", "action", "changes", "created_at"}
+
+	t.Run("正常系 変更前後の値が復元されること", func(t *testing.T) {
+		createdAt := time.Now()
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, 1, 2, domain.ActivityUpdate, `[{"field":"title","before":"old","after":"new"}]`, createdAt)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(1)).WillReturnRows(rows)
+
+		got, err := repo.FindByTaskID(context.TODO(), int64(1))
+		assert.NoError(t, err)
+
+		before, after := "old", "new"
+		expected := []domain.TaskActivity{{
+			ID:        1,
+			TaskID:    1,
+			ActorID:   2,
+			Action:    domain.ActivityUpdate,
+			Changes:   []domain.FieldChange{{Field: "title", Before: &before, After: &after}},
+			CreatedAt: createdAt,
+		}}
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("異常系 変更内容がJSONでない場合エラーが返却されること", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).AddRow(1, 1, 2, domain.ActivityUpdate, "foo", time.Now())
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(1)).WillReturnRows(rows)
+
+		got, err := repo.FindByTaskID(context.TODO(), int64(1))
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("異常系 クエリ実行で失敗した場合エラーが返却されること", func(t *testing.T) {
+		mockErr := errors.New("query failed error")
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(1)).WillReturnError(mockErr)
+
+		got, err := repo.FindByTaskID(context.TODO(), int64(1))
+		assert.Equal(t, mockErr, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := activityRepository.NewActivityRepository(sqlDriver)
+	query := "INSERT INTO task_activities(task_id,actor_id,action,changes) VALUES(?,?,?,?)"
+
+	t.Run("正常系 変更内容がJSONで保存されること", func(t *testing.T) {
+		after := "new"
+		activity := domain.TaskActivity{
+			TaskID:  1,
+			ActorID: 2,
+			Action:  domain.ActivityCreate,
+			Changes: []domain.FieldChange{{Field: "title", After: &after}},
+		}
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().
+			WithArgs(activity.TaskID, activity.ActorID, activity.Action, `[{"field":"title","before":null,"after":"new"}]`).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+
+		id, err := repo.Create(context.TODO(), activity)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), id)
+	})
+
+	t.Run("正常系 変更内容がない場合、空の配列で保存されること", func(t *testing.T) {
+		activity := domain.TaskActivity{TaskID: 1, ActorID: 2, Action: domain.ActivityUpdate}
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().
+			WithArgs(activity.TaskID, activity.ActorID, activity.Action, "[]").
+			WillReturnResult(sqlmock.NewResult(6, 1))
+
+		id, err := repo.Create(context.TODO(), activity)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), id)
+	})
+
+	t.Run("異常系 クエリ実行で失敗した場合エラーが返却されること", func(t *testing.T) {
+		mockErr := errors.New("query failed error")
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().WillReturnError(mockErr)
+
+		id, err := repo.Create(context.TODO(), domain.TaskActivity{})
+		assert.Equal(t, mockErr, err)
+		assert.Equal(t, int64(0), id)
+	})
+}
@@ -0,0 +1,13 @@
+package activity
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// ActivityRepository
+type ActivityRepository interface {
+	FindByTaskID(ctx context.Context, taskID int64) ([]domain.TaskActivity, error)
+	Create(ctx context.Context, activity domain.TaskActivity) (int64, error)
+}
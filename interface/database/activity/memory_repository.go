@@ -0,0 +1,64 @@
+package activity
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// memoryActivityRepository プロセス内のメモリに変更履歴を保持します
+type memoryActivityRepository struct {
+	mu         sync.RWMutex
+	activities []domain.TaskActivity
+	lastID     int64
+}
+
+// NewMemoryActivityRepository メモリに変更履歴を保持するRepositoryオブジェクトを作成します
+func NewMemoryActivityRepository() ActivityRepository {
+	return &memoryActivityRepository{}
+}
+
+// Snapshot 現在の状態を保存し、その状態に戻す関数を返却します
+// 保持している値は置き換えるのみで直接変更しないため、コピーは浅いコピーとしています
+func (ar *memoryActivityRepository) Snapshot() func() {
+	ar.mu.RLock()
+	defer ar.mu.RUnlock()
+
+	activities := slices.Clone(ar.activities)
+	lastID := ar.lastID
+	return func() {
+		ar.mu.Lock()
+		defer ar.mu.Unlock()
+		ar.activities = activities
+		ar.lastID = lastID
+	}
+}
+
+// FindByTaskID タスクの変更履歴を古い順に取得します
+func (ar *memoryActivityRepository) FindByTaskID(ctx context.Context, taskID int64) ([]domain.TaskActivity, error) {
+	ar.mu.RLock()
+	defer ar.mu.RUnlock()
+
+	activities := make([]domain.TaskActivity, 0)
+	for _, activity := range ar.activities {
+		if activity.TaskID == taskID {
+			activities = append(activities, activity)
+		}
+	}
+	return activities, nil
+}
+
+// Create 変更履歴を1件作成します
+func (ar *memoryActivityRepository) Create(ctx context.Context, activity domain.TaskActivity) (int64, error) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	ar.lastID++
+	activity.ID = ar.lastID
+	activity.CreatedAt = time.Now()
+	ar.activities = append(ar.activities, activity)
+	return ar.lastID, nil
+}
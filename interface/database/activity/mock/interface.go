@@ -0,0 +1,22 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	repo "github.com/Hajime3778/go-clean-arch/interface/database/activity"
+)
+
+type MockActivityRepo struct {
+	repo.ActivityRepository
+	MockFindByTaskID func(ctx context.Context, taskID int64) ([]domain.TaskActivity, error)
+	MockCreate       func(ctx context.Context, activity domain.TaskActivity) (int64, error)
+}
+
+func (m *MockActivityRepo) FindByTaskID(ctx context.Context, taskID int64) ([]domain.TaskActivity, error) {
+	return m.MockFindByTaskID(ctx, taskID)
+}
+
+func (m *MockActivityRepo) Create(ctx context.Context, activity domain.TaskActivity) (int64, error) {
+	return m.MockCreate(ctx, activity)
+}
@@ -0,0 +1,125 @@
+package attachment
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+)
+
+// attachmentMapper attachmentsテーブルの列と添付ファイルのフィールドの対応
+var attachmentMapper = database.NewMapper(
+	database.Column("id", func(a *domain.Attachment) interface{} { return &a.ID }),
+	database.Column("task_id", func(a *domain.Attachment) interface{} { return &a.TaskID }),
+	database.Column("file_name", func(a *domain.Attachment) interface{} { return &a.FileName }),
+	database.Column("content_type", func(a *domain.Attachment) interface{} { return &a.ContentType }),
+	database.Column("size", func(a *domain.Attachment) interface{} { return &a.Size }),
+	database.Column("storage_key", func(a *domain.Attachment) interface{} { return &a.StorageKey }),
+	database.Column("created_at", func(a *domain.Attachment) interface{} { return &a.CreatedAt }),
+)
+
+type attachmentRepository struct {
+	SqlDriver database.SqlDriver
+}
+
+// NewAttachmentRepository 添付ファイル機能のRepositoryオブジェクトを作成します
+func NewAttachmentRepository(sqlDriver database.SqlDriver) AttachmentRepository {
+	return &attachmentRepository{sqlDriver}
+}
+
+// FindByTaskID 添付ファイルをタスクIDで複数件取得します
+func (ar *attachmentRepository) FindByTaskID(ctx context.Context, taskID int64) ([]domain.Attachment, error) {
+	query := `
+		SELECT
+			` + attachmentMapper.Columns() + `
+		FROM
+			attachments
+		WHERE
+			task_id = ?
+		ORDER BY
+			id
+	`
+
+	rows, err := ar.SqlDriver.QueryContext(database.WithOperation(ctx, "attachment.FindByTaskID"), query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "rows close failed", "error", err)
+		}
+	}()
+
+	return attachmentMapper.ScanAll(rows)
+}
+
+// GetByID IDで添付ファイルを1件取得します
+func (ar *attachmentRepository) GetByID(ctx context.Context, id int64) (domain.Attachment, error) {
+	query := `
+		SELECT
+			` + attachmentMapper.Columns() + `
+		FROM
+			attachments
+		WHERE
+			id = ?
+	`
+	rows, err := ar.SqlDriver.QueryContext(database.WithOperation(ctx, "attachment.GetByID"), query, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "rows close failed", "error", err)
+		}
+	}()
+
+	if !rows.Next() {
+		return domain.Attachment{}, domain.ErrRecordNotFound
+	}
+
+	return attachmentMapper.Scan(rows)
+}
+
+// Create 添付ファイルを1件作成します
+func (ar *attachmentRepository) Create(ctx context.Context, attachment domain.Attachment) (int64, error) {
+	query := `
+		INSERT INTO attachments(task_id,file_name,content_type,size,storage_key,created_at) VALUES(?,?,?,?,?,?)
+	`
+	createdId, err := ar.SqlDriver.InsertContext(database.WithOperation(ctx, "attachment.Create"), query, attachment.TaskID, attachment.FileName, attachment.ContentType, attachment.Size, attachment.StorageKey, attachment.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return createdId, nil
+}
+
+// Delete IDで添付ファイルを1件削除します
+func (ar *attachmentRepository) Delete(ctx context.Context, id int64) error {
+	query := `
+		DELETE FROM attachments where id = ?
+	`
+	_, err := ar.SqlDriver.ExecuteContext(database.WithOperation(ctx, "attachment.Delete"), query, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteByTaskID タスクの添付ファイルを全件削除します
+func (ar *attachmentRepository) DeleteByTaskID(ctx context.Context, taskID int64) error {
+	query := `
+		DELETE FROM attachments where task_id = ?
+	`
+	_, err := ar.SqlDriver.ExecuteContext(database.WithOperation(ctx, "attachment.DeleteByTaskID"), query, taskID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package attachment_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Hajime3778/go-clean-arch/domain"
+	infrastructure "github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	attachmentRepository "github.com/Hajime3778/go-clean-arch/interface/database/attachment"
+	"github.com/stretchr/testify/assert"
+)
+
+var columns = []string{"id", "task_id", "file_name", "content_type", "size", "storage_key", "created_at"}
+
+func TestFindByTaskID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := attachmentRepository.NewAttachmentRepository(sqlDriver)
+	query := "SELECT id, task_id, file_name, content_type, size, storage_key, created_at FROM attachments WHERE task_id = ? ORDER BY id"
+
+	t.Run("正常系 指定したタスクIDで取得", func(t *testing.T) {
+		mockAttachment := createMockAttachment()
+		rows := sqlmock.NewRows(columns).
+			AddRow(mockAttachment.ID, mockAttachment.TaskID, mockAttachment.FileName, mockAttachment.ContentType, mockAttachment.Size, mockAttachment.StorageKey, mockAttachment.CreatedAt)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockAttachment.TaskID).WillReturnRows(rows)
+
+		got, err := repo.FindByTaskID(context.TODO(), mockAttachment.TaskID)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Attachment{mockAttachment}, got)
+	})
+
+	t.Run("準正常系 データが存在しない場合、エラーとならないこと", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows(columns))
+
+		got, err := repo.FindByTaskID(context.TODO(), int64(2))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Attachment{}, got)
+	})
+
+	t.Run("異常系 クエリ実行で失敗した場合エラーが返却されること", func(t *testing.T) {
+		mockErr := errors.New("query failed error")
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(2)).WillReturnError(mockErr)
+
+		got, err := repo.FindByTaskID(context.TODO(), int64(2))
+		assert.Equal(t, mockErr, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := attachmentRepository.NewAttachmentRepository(sqlDriver)
+	query := "SELECT id, task_id, file_name, content_type, size, storage_key, created_at FROM attachments WHERE id = ?"
+
+	t.Run("正常系 存在するIDで1件取得", func(t *testing.T) {
+		mockAttachment := createMockAttachment()
+		rows := sqlmock.NewRows(columns).
+			AddRow(mockAttachment.ID, mockAttachment.TaskID, mockAttachment.FileName, mockAttachment.ContentType, mockAttachment.Size, mockAttachment.StorageKey, mockAttachment.CreatedAt)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockAttachment.ID).WillReturnRows(rows)
+
+		got, err := repo.GetByID(context.TODO(), mockAttachment.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, mockAttachment, got)
+	})
+
+	t.Run("準正常系 存在しないIDで検索してエラーとなること", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(2)).WillReturnRows(sqlmock.NewRows(columns))
+
+		got, err := repo.GetByID(context.TODO(), int64(2))
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+		assert.Equal(t, domain.Attachment{}, got)
+	})
+
+	t.Run("異常系 Scan実行で失敗した場合エラーが返却されること", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"foo"}).AddRow("bar")
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(2)).WillReturnRows(rows)
+
+		got, err := repo.GetByID(context.TODO(), int64(2))
+		assert.NotNil(t, err)
+		assert.Equal(t, domain.Attachment{}, got)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := attachmentRepository.NewAttachmentRepository(sqlDriver)
+	query := "INSERT INTO attachments(task_id,file_name,content_type,size,storage_key,created_at) VALUES(?,?,?,?,?,?)"
+
+	t.Run("正常系 1件追加", func(t *testing.T) {
+		mockAttachment := createMockAttachment()
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().
+			WithArgs(mockAttachment.TaskID, mockAttachment.FileName, mockAttachment.ContentType, mockAttachment.Size, mockAttachment.StorageKey, mockAttachment.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(12, 1))
+
+		id, err := repo.Create(context.TODO(), mockAttachment)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), id)
+	})
+
+	t.Run("異常系 クエリ実行で失敗した場合エラーが返却されること", func(t *testing.T) {
+		mockAttachment := createMockAttachment()
+		mockErr := errors.New("query failed error")
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().
+			WithArgs(mockAttachment.TaskID, mockAttachment.FileName, mockAttachment.ContentType, mockAttachment.Size, mockAttachment.StorageKey, mockAttachment.CreatedAt).
+			WillReturnError(mockErr)
+
+		id, err := repo.Create(context.TODO(), mockAttachment)
+		assert.Equal(t, mockErr, err)
+		assert.Equal(t, int64(0), id)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := attachmentRepository.NewAttachmentRepository(sqlDriver)
+	query := "DELETE FROM attachments where id = ?"
+
+	t.Run("正常系 1件削除", func(t *testing.T) {
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Delete(context.TODO(), int64(1))
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系 クエリ実行で失敗した場合エラーが返却されること", func(t *testing.T) {
+		mockErr := errors.New("query failed error")
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().WithArgs(int64(1)).WillReturnError(mockErr)
+
+		err := repo.Delete(context.TODO(), int64(1))
+		assert.Equal(t, mockErr, err)
+	})
+}
+
+func TestDeleteByTaskID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := attachmentRepository.NewAttachmentRepository(sqlDriver)
+	query := "DELETE FROM attachments where task_id = ?"
+
+	t.Run("正常系 タスクの添付ファイルを全件削除", func(t *testing.T) {
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.DeleteByTaskID(context.TODO(), int64(1))
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系 クエリ実行で失敗した場合エラーが返却されること", func(t *testing.T) {
+		mockErr := errors.New("query failed error")
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().WithArgs(int64(1)).WillReturnError(mockErr)
+
+		err := repo.DeleteByTaskID(context.TODO(), int64(1))
+		assert.Equal(t, mockErr, err)
+	})
+}
+
+// createMockAttachment モックの添付ファイルを作成します
+func createMockAttachment() domain.Attachment {
+	return domain.Attachment{
+		ID:          1,
+		TaskID:      1,
+		FileName:    "screenshot.png",
+		ContentType: "image/png",
+		Size:        1024,
+		StorageKey:  "tasks/1/abcdefghij",
+		CreatedAt:   time.Now(),
+	}
+}
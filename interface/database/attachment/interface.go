@@ -0,0 +1,16 @@
+package attachment
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// AttachmentRepository
+type AttachmentRepository interface {
+	FindByTaskID(ctx context.Context, taskID int64) ([]domain.Attachment, error)
+	GetByID(ctx context.Context, id int64) (domain.Attachment, error)
+	Create(ctx context.Context, attachment domain.Attachment) (int64, error)
+	Delete(ctx context.Context, id int64) error
+	DeleteByTaskID(ctx context.Context, taskID int64) error
+}
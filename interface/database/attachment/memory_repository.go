@@ -0,0 +1,102 @@
+package attachment
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// memoryAttachmentRepository プロセス内のメモリに添付ファイルの情報を保持します
+type memoryAttachmentRepository struct {
+	mu          sync.RWMutex
+	attachments map[int64]domain.Attachment
+	lastID      int64
+}
+
+// NewMemoryAttachmentRepository メモリに添付ファイルの情報を保持するRepositoryオブジェクトを作成します
+func NewMemoryAttachmentRepository() AttachmentRepository {
+	return &memoryAttachmentRepository{attachments: map[int64]domain.Attachment{}}
+}
+
+// Snapshot 現在の状態を保存し、その状態に戻す関数を返却します
+// 保持している値は置き換えるのみで直接変更しないため、コピーは浅いコピーとしています
+func (ar *memoryAttachmentRepository) Snapshot() func() {
+	ar.mu.RLock()
+	defer ar.mu.RUnlock()
+
+	attachments := maps.Clone(ar.attachments)
+	lastID := ar.lastID
+	return func() {
+		ar.mu.Lock()
+		defer ar.mu.Unlock()
+		ar.attachments = attachments
+		ar.lastID = lastID
+	}
+}
+
+// FindByTaskID タスクの添付ファイルを作成順に取得します
+func (ar *memoryAttachmentRepository) FindByTaskID(ctx context.Context, taskID int64) ([]domain.Attachment, error) {
+	ar.mu.RLock()
+	defer ar.mu.RUnlock()
+
+	attachments := make([]domain.Attachment, 0)
+	for _, attachment := range ar.attachments {
+		if attachment.TaskID == taskID {
+			attachments = append(attachments, attachment)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].ID < attachments[j].ID })
+	return attachments, nil
+}
+
+// GetByID 添付ファイルを1件取得します
+func (ar *memoryAttachmentRepository) GetByID(ctx context.Context, id int64) (domain.Attachment, error) {
+	ar.mu.RLock()
+	defer ar.mu.RUnlock()
+
+	attachment, ok := ar.attachments[id]
+	if !ok {
+		return domain.Attachment{}, domain.ErrRecordNotFound
+	}
+	return attachment, nil
+}
+
+// Create 添付ファイルを1件作成します
+func (ar *memoryAttachmentRepository) Create(ctx context.Context, attachment domain.Attachment) (int64, error) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	ar.lastID++
+	attachment.ID = ar.lastID
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now()
+	}
+	ar.attachments[ar.lastID] = attachment
+	return ar.lastID, nil
+}
+
+// Delete IDで添付ファイルを1件削除します
+func (ar *memoryAttachmentRepository) Delete(ctx context.Context, id int64) error {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	delete(ar.attachments, id)
+	return nil
+}
+
+// DeleteByTaskID タスクの添付ファイルを全件削除します
+func (ar *memoryAttachmentRepository) DeleteByTaskID(ctx context.Context, taskID int64) error {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	for id, attachment := range ar.attachments {
+		if attachment.TaskID == taskID {
+			delete(ar.attachments, id)
+		}
+	}
+	return nil
+}
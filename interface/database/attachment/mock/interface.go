@@ -0,0 +1,37 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	repo "github.com/Hajime3778/go-clean-arch/interface/database/attachment"
+)
+
+type MockAttachmentRepo struct {
+	repo.AttachmentRepository
+	MockFindByTaskID   func(ctx context.Context, taskID int64) ([]domain.Attachment, error)
+	MockGetByID        func(ctx context.Context, id int64) (domain.Attachment, error)
+	MockCreate         func(ctx context.Context, attachment domain.Attachment) (int64, error)
+	MockDelete         func(ctx context.Context, id int64) error
+	MockDeleteByTaskID func(ctx context.Context, taskID int64) error
+}
+
+func (m *MockAttachmentRepo) FindByTaskID(ctx context.Context, taskID int64) ([]domain.Attachment, error) {
+	return m.MockFindByTaskID(ctx, taskID)
+}
+
+func (m *MockAttachmentRepo) GetByID(ctx context.Context, id int64) (domain.Attachment, error) {
+	return m.MockGetByID(ctx, id)
+}
+
+func (m *MockAttachmentRepo) Create(ctx context.Context, attachment domain.Attachment) (int64, error) {
+	return m.MockCreate(ctx, attachment)
+}
+
+func (m *MockAttachmentRepo) Delete(ctx context.Context, id int64) error {
+	return m.MockDelete(ctx, id)
+}
+
+func (m *MockAttachmentRepo) DeleteByTaskID(ctx context.Context, taskID int64) error {
+	return m.MockDeleteByTaskID(ctx, taskID)
+}
@@ -0,0 +1,87 @@
+package calendar
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+)
+
+// calendarFeedMapper calendar_feedsテーブルの列とフィードのフィールドの対応
+var calendarFeedMapper = database.NewMapper(
+	database.Column("user_id", func(f *domain.CalendarFeed) interface{} { return &f.UserID }),
+	database.Column("token", func(f *domain.CalendarFeed) interface{} { return &f.Token }),
+	database.Column("created_at", func(f *domain.CalendarFeed) interface{} { return &f.CreatedAt }),
+	database.Column("updated_at", func(f *domain.CalendarFeed) interface{} { return &f.UpdatedAt }),
+)
+
+type calendarFeedRepository struct {
+	SqlDriver database.SqlDriver
+}
+
+// NewCalendarFeedRepository カレンダーフィード機能のRepositoryオブジェクトを作成します
+func NewCalendarFeedRepository(sqlDriver database.SqlDriver) CalendarFeedRepository {
+	return &calendarFeedRepository{sqlDriver}
+}
+
+// GetByUserID ユーザーIDでフィードを1件取得します
+func (cr *calendarFeedRepository) GetByUserID(ctx context.Context, userID int64) (domain.CalendarFeed, error) {
+	query := `
+		SELECT
+			` + calendarFeedMapper.Columns() + `
+		FROM
+			calendar_feeds
+		WHERE
+			user_id = ?
+	`
+	return cr.get(database.WithOperation(ctx, "calendar.GetByUserID"), query, userID)
+}
+
+// GetByToken トークンでフィードを1件取得します
+func (cr *calendarFeedRepository) GetByToken(ctx context.Context, token string) (domain.CalendarFeed, error) {
+	query := `
+		SELECT
+			` + calendarFeedMapper.Columns() + `
+		FROM
+			calendar_feeds
+		WHERE
+			token = ?
+	`
+	return cr.get(database.WithOperation(ctx, "calendar.GetByToken"), query, token)
+}
+
+// Save フィードを作成します(既に存在する場合はトークンを更新します)
+func (cr *calendarFeedRepository) Save(ctx context.Context, feed domain.CalendarFeed) error {
+	query := `
+		INSERT INTO calendar_feeds(user_id,token) VALUES(?,?)
+		` + cr.SqlDriver.Dialect().Upsert([]string{"user_id"}, "token") + `
+	`
+	_, err := cr.SqlDriver.ExecuteContext(database.WithOperation(ctx, "calendar.Save"), query, feed.UserID, feed.Token)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// get: queryでフィードを1件取得します。操作名は呼び出し元でctxに設定します
+func (cr *calendarFeedRepository) get(ctx context.Context, query string, args ...interface{}) (domain.CalendarFeed, error) {
+	rows, err := cr.SqlDriver.QueryContext(ctx, query, args...)
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "rows close failed", "error", err)
+		}
+	}()
+
+	if !rows.Next() {
+		return domain.CalendarFeed{}, domain.ErrRecordNotFound
+	}
+
+	return calendarFeedMapper.Scan(rows)
+}
@@ -0,0 +1,36 @@
+package calendar_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database/databasetest"
+	calendarRepository "github.com/Hajime3778/go-clean-arch/interface/database/calendar"
+	userRepository "github.com/Hajime3778/go-clean-arch/interface/database/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLite(t *testing.T) {
+	ctx := context.TODO()
+	sqlDriver := databasetest.NewSQLite(t)
+	repo := calendarRepository.NewCalendarFeedRepository(sqlDriver)
+	userID, err := userRepository.NewUserRepository(sqlDriver).Create(ctx, domain.User{Name: "test", Email: "test@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("正常系 既に存在するフィードを保存した場合、トークンが更新されること", func(t *testing.T) {
+		err := repo.Save(ctx, domain.CalendarFeed{UserID: userID, Token: "before"})
+		assert.NoError(t, err)
+		err = repo.Save(ctx, domain.CalendarFeed{UserID: userID, Token: "after"})
+		assert.NoError(t, err)
+
+		got, err := repo.GetByUserID(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "after", got.Token)
+
+		_, err = repo.GetByToken(ctx, "before")
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+	})
+}
@@ -0,0 +1,110 @@
+package calendar_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Hajime3778/go-clean-arch/domain"
+	infrastructure "github.com/Hajime3778/go-clean-arch/infrastructure/database"
+	calendarRepository "github.com/Hajime3778/go-clean-arch/interface/database/calendar"
+	"github.com/stretchr/testify/assert"
+)
+
+var columns = []string{"user_id", "token", "created_at", "updated_at"}
+
+func TestGetByToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := calendarRepository.NewCalendarFeedRepository(sqlDriver)
+	query := "SELECT user_id, token, created_at, updated_at FROM calendar_feeds WHERE token = ?"
+
+	t.Run("正常系 存在するトークンで1件取得", func(t *testing.T) {
+		mockFeed := domain.CalendarFeed{UserID: 1, Token: "token", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		rows := sqlmock.NewRows(columns).AddRow(mockFeed.UserID, mockFeed.Token, mockFeed.CreatedAt, mockFeed.UpdatedAt)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockFeed.Token).WillReturnRows(rows)
+
+		got, err := repo.GetByToken(context.TODO(), mockFeed.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, mockFeed, got)
+	})
+
+	t.Run("準正常系 存在しないトークンで検索してエラーとなること", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("foo").WillReturnRows(sqlmock.NewRows(columns))
+
+		got, err := repo.GetByToken(context.TODO(), "foo")
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+		assert.Equal(t, domain.CalendarFeed{}, got)
+	})
+
+	t.Run("異常系 クエリ実行で失敗した場合エラーが返却されること", func(t *testing.T) {
+		mockErr := errors.New("query failed error")
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("foo").WillReturnError(mockErr)
+
+		got, err := repo.GetByToken(context.TODO(), "foo")
+		assert.Equal(t, mockErr, err)
+		assert.Equal(t, domain.CalendarFeed{}, got)
+	})
+}
+
+func TestGetByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := calendarRepository.NewCalendarFeedRepository(sqlDriver)
+	query := "SELECT user_id, token, created_at, updated_at FROM calendar_feeds WHERE user_id = ?"
+
+	t.Run("正常系 存在するユーザーIDで1件取得", func(t *testing.T) {
+		mockFeed := domain.CalendarFeed{UserID: 1, Token: "token", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		rows := sqlmock.NewRows(columns).AddRow(mockFeed.UserID, mockFeed.Token, mockFeed.CreatedAt, mockFeed.UpdatedAt)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockFeed.UserID).WillReturnRows(rows)
+
+		got, err := repo.GetByUserID(context.TODO(), mockFeed.UserID)
+		assert.NoError(t, err)
+		assert.Equal(t, mockFeed, got)
+	})
+}
+
+func TestSave(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := calendarRepository.NewCalendarFeedRepository(sqlDriver)
+	query := "INSERT INTO calendar_feeds(user_id,token) VALUES(?,?) ON DUPLICATE KEY UPDATE token = VALUES(token)"
+
+	t.Run("正常系 1件保存", func(t *testing.T) {
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().WithArgs(int64(1), "token").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Save(context.TODO(), domain.CalendarFeed{UserID: 1, Token: "token"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系 クエリ実行で失敗した場合エラーが返却されること", func(t *testing.T) {
+		mockErr := errors.New("query failed error")
+		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
+		prep.ExpectExec().WithArgs(int64(1), "token").WillReturnError(mockErr)
+
+		err := repo.Save(context.TODO(), domain.CalendarFeed{UserID: 1, Token: "token"})
+		assert.Equal(t, mockErr, err)
+	})
+}
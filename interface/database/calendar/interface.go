@@ -0,0 +1,14 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// CalendarFeedRepository
+type CalendarFeedRepository interface {
+	GetByUserID(ctx context.Context, userID int64) (domain.CalendarFeed, error)
+	GetByToken(ctx context.Context, token string) (domain.CalendarFeed, error)
+	Save(ctx context.Context, feed domain.CalendarFeed) error
+}
@@ -0,0 +1,76 @@
+package calendar
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// memoryCalendarFeedRepository プロセス内のメモリにカレンダーフィードを保持します
+type memoryCalendarFeedRepository struct {
+	mu    sync.RWMutex
+	feeds map[int64]domain.CalendarFeed
+}
+
+// NewMemoryCalendarFeedRepository メモリにカレンダーフィードを保持するRepositoryオブジェクトを作成します
+func NewMemoryCalendarFeedRepository() CalendarFeedRepository {
+	return &memoryCalendarFeedRepository{feeds: map[int64]domain.CalendarFeed{}}
+}
+
+// Snapshot 現在の状態を保存し、その状態に戻す関数を返却します
+// 保持している値は置き換えるのみで直接変更しないため、コピーは浅いコピーとしています
+func (cr *memoryCalendarFeedRepository) Snapshot() func() {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	feeds := maps.Clone(cr.feeds)
+	return func() {
+		cr.mu.Lock()
+		defer cr.mu.Unlock()
+		cr.feeds = feeds
+	}
+}
+
+// GetByUserID ユーザーIDでフィードを取得します
+func (cr *memoryCalendarFeedRepository) GetByUserID(ctx context.Context, userID int64) (domain.CalendarFeed, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	feed, ok := cr.feeds[userID]
+	if !ok {
+		return domain.CalendarFeed{}, domain.ErrRecordNotFound
+	}
+	return feed, nil
+}
+
+// GetByToken トークンでフィードを取得します
+func (cr *memoryCalendarFeedRepository) GetByToken(ctx context.Context, token string) (domain.CalendarFeed, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	for _, feed := range cr.feeds {
+		if feed.Token == token {
+			return feed, nil
+		}
+	}
+	return domain.CalendarFeed{}, domain.ErrRecordNotFound
+}
+
+// Save フィードを作成します(既に存在する場合はトークンを更新します)
+func (cr *memoryCalendarFeedRepository) Save(ctx context.Context, feed domain.CalendarFeed) error {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	now := time.Now()
+	stored, ok := cr.feeds[feed.UserID]
+	if !ok {
+		stored = domain.CalendarFeed{UserID: feed.UserID, CreatedAt: now}
+	}
+	stored.Token = feed.Token
+	stored.UpdatedAt = now
+	cr.feeds[feed.UserID] = stored
+	return nil
+}
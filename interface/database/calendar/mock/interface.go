@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	repo "github.com/Hajime3778/go-clean-arch/interface/database/calendar"
+)
+
+type MockCalendarFeedRepo struct {
+	repo.CalendarFeedRepository
+	MockGetByUserID func(ctx context.Context, userID int64) (domain.CalendarFeed, error)
+	MockGetByToken  func(ctx context.Context, token string) (domain.CalendarFeed, error)
+	MockSave        func(ctx context.Context, feed domain.CalendarFeed) error
+}
+
+func (m *MockCalendarFeedRepo) GetByUserID(ctx context.Context, userID int64) (domain.CalendarFeed, error) {
+	return m.MockGetByUserID(ctx, userID)
+}
+
+func (m *MockCalendarFeedRepo) GetByToken(ctx context.Context, token string) (domain.CalendarFeed, error) {
+	return m.MockGetByToken(ctx, token)
+}
+
+func (m *MockCalendarFeedRepo) Save(ctx context.Context, feed domain.CalendarFeed) error {
+	return m.MockSave(ctx, feed)
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect データベースごとのSQLの方言
+// リポジトリのクエリはプレースホルダーに ? を使用し、差異のある構文はDialectのメソッドで組み立てます
+type Dialect string
+
+const (
+	MySQL    Dialect = "mysql"
+	SQLite   Dialect = "sqlite"
+	Postgres Dialect = "postgres"
+)
+
+// Rebind クエリの ? のプレースホルダーを方言の形式に変換します
+// Postgresは $1, $2... の形式に変換し、文字列リテラルと引用符で囲んだ識別子の中の ? は変換しません
+func (d Dialect) Rebind(query string) string {
+	if d != Postgres || !strings.Contains(query, "?") {
+		return query
+	}
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	var quote rune
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Returning INSERT文に続けて、追加した行のcolumnを返却させる句を返却します
+// LastInsertIdに対応していないPostgresのみ RETURNING 句となり、それ以外は空文字となります
+func (d Dialect) Returning(column string) string {
+	if d == Postgres {
+		return "RETURNING " + column
+	}
+	return ""
+}
+
+// Upsert keysが重複した場合にcolumnsを挿入しようとした値で更新する、INSERT文に続ける句を返却します
+func (d Dialect) Upsert(keys []string, columns ...string) string {
+	assignments := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if d == MySQL {
+			assignments = append(assignments, fmt.Sprintf("%s = VALUES(%s)", column, column))
+		} else {
+			assignments = append(assignments, fmt.Sprintf("%s = excluded.%s", column, column))
+		}
+	}
+	if d != MySQL {
+		return fmt.Sprintf("ON CONFLICT(%s) DO UPDATE SET %s", strings.Join(keys, ","), strings.Join(assignments, ", "))
+	}
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(assignments, ", ")
+}
+
+// OnConflictDoNothing keysが重複した場合に挿入せず、既存の行を変更しない、INSERT文に続ける句を返却します
+// MySQLの INSERT IGNORE は重複以外のエラーも無視するため、keysを同じ値で更新する句とします
+func (d Dialect) OnConflictDoNothing(keys []string) string {
+	if d == MySQL {
+		return fmt.Sprintf("ON DUPLICATE KEY UPDATE %s = %s", keys[0], keys[0])
+	}
+	return fmt.Sprintf("ON CONFLICT(%s) DO NOTHING", strings.Join(keys, ","))
+}
+
+// ForUpdate 取得した行をトランザクションの終了までロックする、SELECT文に続ける句を返却します
+// SQLiteはトランザクションの開始時にデータベース全体の書き込みロックを取得するため、空文字となります
+func (d Dialect) ForUpdate() string {
+	if d == SQLite {
+		return ""
+	}
+	return "FOR UPDATE"
+}
+
+// SecondsBetween fromからtoまでの秒数を整数で求める式を返却します
+func (d Dialect) SecondsBetween(from string, to string) string {
+	switch d {
+	case Postgres:
+		return fmt.Sprintf("CAST(EXTRACT(EPOCH FROM (%s - %s)) AS BIGINT)", to, from)
+	case SQLite:
+		return fmt.Sprintf("(strftime('%%s', %s) - strftime('%%s', %s))", to, from)
+	default:
+		return fmt.Sprintf("TIMESTAMPDIFF(SECOND, %s, %s)", from, to)
+	}
+}
+
+// Date 日時をatと同じ時差での日付に変換する式を返却します
+// MySQLとPostgresは接続のタイムゾーンで日時を扱うため、そのまま日付に変換します
+// SQLiteはUTCで日時を保存するため、atの時差を加えてから日付に変換します
+func (d Dialect) Date(expr string, at time.Time) string {
+	if d == SQLite {
+		_, offset := at.Zone()
+		return fmt.Sprintf("DATE(%s, '%+d seconds')", expr, offset)
+	}
+	return fmt.Sprintf("DATE(%s)", expr)
+}
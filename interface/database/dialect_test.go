@@ -0,0 +1,42 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialect(t *testing.T) {
+	jst := time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60))
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"MySQLのRebind", database.MySQL.Rebind("SELECT * FROM a WHERE b = ? AND c = ?"), "SELECT * FROM a WHERE b = ? AND c = ?"},
+		{"PostgresのRebind", database.Postgres.Rebind("SELECT * FROM a WHERE b = ? AND c = '?' AND d = ?"), "SELECT * FROM a WHERE b = $1 AND c = '?' AND d = $2"},
+		{"MySQLのReturning", database.MySQL.Returning("id"), ""},
+		{"PostgresのReturning", database.Postgres.Returning("id"), "RETURNING id"},
+		{"MySQLのUpsert", database.MySQL.Upsert([]string{"id"}, "a", "b"), "ON DUPLICATE KEY UPDATE a = VALUES(a), b = VALUES(b)"},
+		{"SQLiteのUpsert", database.SQLite.Upsert([]string{"id"}, "a", "b"), "ON CONFLICT(id) DO UPDATE SET a = excluded.a, b = excluded.b"},
+		{"PostgresのUpsert", database.Postgres.Upsert([]string{"id"}, "a"), "ON CONFLICT(id) DO UPDATE SET a = excluded.a"},
+		{"MySQLのOnConflictDoNothing", database.MySQL.OnConflictDoNothing([]string{"id"}), "ON DUPLICATE KEY UPDATE id = id"},
+		{"SQLiteのOnConflictDoNothing", database.SQLite.OnConflictDoNothing([]string{"id"}), "ON CONFLICT(id) DO NOTHING"},
+		{"MySQLのForUpdate", database.MySQL.ForUpdate(), "FOR UPDATE"},
+		{"SQLiteのForUpdate", database.SQLite.ForUpdate(), ""},
+		{"MySQLのSecondsBetween", database.MySQL.SecondsBetween("a", "b"), "TIMESTAMPDIFF(SECOND, a, b)"},
+		{"SQLiteのSecondsBetween", database.SQLite.SecondsBetween("a", "b"), "(strftime('%s', b) - strftime('%s', a))"},
+		{"PostgresのSecondsBetween", database.Postgres.SecondsBetween("a", "b"), "CAST(EXTRACT(EPOCH FROM (b - a)) AS BIGINT)"},
+		{"MySQLのDate", database.MySQL.Date("a", jst), "DATE(a)"},
+		{"SQLiteのDate", database.SQLite.Date("a", jst), "DATE(a, '+32400 seconds')"},
+		{"PostgresのDate", database.Postgres.Date("a", jst), "DATE(a)"},
+	}
+	for _, tt := range tests {
+		t.Run("正常系 "+tt.name+"が方言に応じたSQLとなること", func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.got)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field 列名と、その列の値を読み込むフィールドの対応
+type Field[T any] struct {
+	// Name 列名
+	Name string
+	// Pointer 読み込み先となるフィールドのポインタを返却します
+	Pointer func(*T) interface{}
+}
+
+// Column Fieldを作成します
+func Column[T any](name string, pointer func(*T) interface{}) Field[T] {
+	return Field[T]{Name: name, Pointer: pointer}
+}
+
+// Mapper テーブルの列と構造体のフィールドの対応
+// SELECTする列の一覧と行の読み込みを同じ定義から行い、列名で読み込むため、
+// テーブルに列を追加したり列の順序が変わったりしても、フィールドがずれることはありません
+type Mapper[T any] struct {
+	names  []string
+	fields map[string]Field[T]
+}
+
+// NewMapper Mapperオブジェクトを作成します
+func NewMapper[T any](fields ...Field[T]) *Mapper[T] {
+	m := &Mapper[T]{
+		names:  make([]string, 0, len(fields)),
+		fields: make(map[string]Field[T], len(fields)),
+	}
+	for _, field := range fields {
+		if _, ok := m.fields[field.Name]; ok {
+			panic(fmt.Sprintf("duplicate column: %s", field.Name))
+		}
+		m.names = append(m.names, field.Name)
+		m.fields[field.Name] = field
+	}
+	return m
+}
+
+// Columns SELECT句に指定する列の一覧を返却します
+func (m *Mapper[T]) Columns() string {
+	return strings.Join(m.names, ", ")
+}
+
+// Scan 現在の行を列名でフィールドに読み込みます
+// 対応するフィールドがない列が含まれる場合はエラーとなります
+func (m *Mapper[T]) Scan(rows Rows) (T, error) {
+	var v T
+	columns, err := rows.Columns()
+	if err != nil {
+		return v, err
+	}
+	dest := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		field, ok := m.fields[column]
+		if !ok {
+			return v, fmt.Errorf("column %q is not mapped", column)
+		}
+		dest = append(dest, field.Pointer(&v))
+	}
+	err = rows.Scan(dest...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// ScanAll 残りのすべての行を読み込みます
+func (m *Mapper[T]) ScanAll(rows Rows) ([]T, error) {
+	values := make([]T, 0)
+	for rows.Next() {
+		v, err := m.Scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
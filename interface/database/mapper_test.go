@@ -0,0 +1,83 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	"github.com/Hajime3778/go-clean-arch/interface/database/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type mapperTestRow struct {
+	ID   int64
+	Name string
+}
+
+var testMapper = database.NewMapper(
+	database.Column("id", func(r *mapperTestRow) interface{} { return &r.ID }),
+	database.Column("name", func(r *mapperTestRow) interface{} { return &r.Name }),
+)
+
+// newMockRows 列名と1行分の値を返却するRowsを作成します
+func newMockRows(columns []string, values ...interface{}) *mock.MockRows {
+	return &mock.MockRows{
+		MockColumns: func() ([]string, error) {
+			return columns, nil
+		},
+		MockScan: func(args ...interface{}) error {
+			dest := args[0].([]interface{})
+			for i, v := range values {
+				switch d := dest[i].(type) {
+				case *int64:
+					*d = v.(int64)
+				case *string:
+					*d = v.(string)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestMapper(t *testing.T) {
+	t.Run("正常系 SELECT句の列の一覧が定義順で返却されること", func(t *testing.T) {
+		assert.Equal(t, "id, name", testMapper.Columns())
+	})
+
+	t.Run("正常系 列の順序によらず、列名でフィールドに読み込まれること", func(t *testing.T) {
+		rows := newMockRows([]string{"name", "id"}, "test name", int64(1))
+
+		got, err := testMapper.Scan(rows)
+		assert.NoError(t, err)
+		assert.Equal(t, mapperTestRow{ID: 1, Name: "test name"}, got)
+	})
+
+	t.Run("異常系 対応するフィールドがない列が含まれる場合、エラーとなること", func(t *testing.T) {
+		rows := newMockRows([]string{"id", "email"}, int64(1), "test email")
+
+		_, err := testMapper.Scan(rows)
+		assert.ErrorContains(t, err, "email")
+	})
+
+	t.Run("異常系 列名の取得に失敗した場合、エラーとなること", func(t *testing.T) {
+		mockErr := errors.New("columns error")
+		rows := &mock.MockRows{
+			MockColumns: func() ([]string, error) {
+				return nil, mockErr
+			},
+		}
+
+		_, err := testMapper.Scan(rows)
+		assert.Equal(t, mockErr, err)
+	})
+
+	t.Run("異常系 同じ列を重複して定義した場合、panicとなること", func(t *testing.T) {
+		assert.Panics(t, func() {
+			database.NewMapper(
+				database.Column("id", func(r *mapperTestRow) interface{} { return &r.ID }),
+				database.Column("id", func(r *mapperTestRow) interface{} { return &r.Name }),
+			)
+		})
+	})
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryTxKey contextにトランザクション内であることを保持するキー
+type memoryTxKey struct{}
+
+// Snapshotter 現在の状態を保存し、後から元に戻せるメモリ上のRepositoryです
+type Snapshotter interface {
+	// Snapshot 現在の状態を保存し、その状態に戻す関数を返却します
+	Snapshot() (restore func())
+}
+
+// MemoryTransactor メモリ上のRepositoryで使用するTransactorです
+// トランザクションを1つずつ順番に実行し、fnがエラーを返却した場合はRepositoryを実行前の状態に戻します
+// トランザクション外で同時に行われた変更も取り消されるため、デモやテストでの使用を想定しています
+type MemoryTransactor struct {
+	mu    sync.Mutex
+	repos []Snapshotter
+}
+
+// NewMemoryTransactor MemoryTransactorを作成します
+// reposには、エラー時に変更を取り消すRepositoryを指定します
+func NewMemoryTransactor(repos ...Snapshotter) *MemoryTransactor {
+	return &MemoryTransactor{repos: repos}
+}
+
+// Transaction fnを他のトランザクションと同時に実行されないように実行します
+// fnがエラーを返却した場合やpanicした場合は、Repositoryを実行前の状態に戻します
+// すでにトランザクション内の場合は、そのままfnを実行します
+func (t *MemoryTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(memoryTxKey{}).(bool); ok {
+		return fn(ctx)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	restores := make([]func(), 0, len(t.repos))
+	for _, repo := range t.repos {
+		restores = append(restores, repo.Snapshot())
+	}
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		for _, restore := range restores {
+			restore()
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, true)); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
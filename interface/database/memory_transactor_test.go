@@ -0,0 +1,99 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	taskRepository "github.com/Hajime3778/go-clean-arch/interface/database/task"
+	userRepository "github.com/Hajime3778/go-clean-arch/interface/database/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryTransactor(t *testing.T) {
+	newRepositories := func() (taskRepository.TaskRepository, userRepository.UserRepository, *database.MemoryTransactor) {
+		tasks := taskRepository.NewMemoryTaskRepository()
+		users := userRepository.NewMemoryUserRepository()
+		return tasks, users, database.NewMemoryTransactor(tasks.(database.Snapshotter), users.(database.Snapshotter))
+	}
+	dueDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系 fnが正常終了した場合、変更が保持されること", func(t *testing.T) {
+		tasks, _, transactor := newRepositories()
+
+		var id int64
+		err := transactor.Transaction(context.TODO(), func(ctx context.Context) error {
+			var err error
+			id, err = tasks.Create(ctx, domain.Task{UserID: 1, Title: "title", DueDate: dueDate})
+			return err
+		})
+
+		assert.NoError(t, err)
+		task, err := tasks.GetByID(context.TODO(), id)
+		assert.NoError(t, err)
+		assert.Equal(t, "title", task.Title)
+	})
+
+	t.Run("準正常系 fnがエラーを返却した場合、すべてのRepositoryの変更が取り消されること", func(t *testing.T) {
+		tasks, users, transactor := newRepositories()
+		existingID, _ := tasks.Create(context.TODO(), domain.Task{UserID: 1, Title: "before", DueDate: dueDate})
+		txErr := errors.New("error")
+
+		var createdID int64
+		err := transactor.Transaction(context.TODO(), func(ctx context.Context) error {
+			createdID, _ = tasks.Create(ctx, domain.Task{UserID: 1, Title: "created", DueDate: dueDate})
+			_ = tasks.Update(ctx, domain.Task{ID: existingID, Title: "after", DueDate: dueDate})
+			_, _ = users.Create(ctx, domain.User{Name: "name", Email: "test@example.com"})
+			return txErr
+		})
+
+		assert.Equal(t, txErr, err)
+		_, err = tasks.GetByID(context.TODO(), createdID)
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+		task, err := tasks.GetByID(context.TODO(), existingID)
+		assert.NoError(t, err)
+		assert.Equal(t, "before", task.Title)
+		_, err = users.GetByEmail(context.TODO(), "test@example.com")
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+
+		// 取り消した後に作成したタスクは、取り消されたタスクと同じIDで作成されること
+		id, err := tasks.Create(context.TODO(), domain.Task{UserID: 1, Title: "next", DueDate: dueDate})
+		assert.NoError(t, err)
+		assert.Equal(t, createdID, id)
+	})
+
+	t.Run("準正常系 fnがpanicした場合、変更が取り消されること", func(t *testing.T) {
+		tasks, _, transactor := newRepositories()
+
+		var createdID int64
+		assert.Panics(t, func() {
+			_ = transactor.Transaction(context.TODO(), func(ctx context.Context) error {
+				createdID, _ = tasks.Create(ctx, domain.Task{UserID: 1, Title: "created", DueDate: dueDate})
+				panic("panic")
+			})
+		})
+
+		_, err := tasks.GetByID(context.TODO(), createdID)
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+	})
+
+	t.Run("準正常系 ネストしたトランザクションがエラーの場合、外側のトランザクションの変更も取り消されること", func(t *testing.T) {
+		tasks, _, transactor := newRepositories()
+		txErr := errors.New("error")
+
+		var outerID int64
+		err := transactor.Transaction(context.TODO(), func(ctx context.Context) error {
+			outerID, _ = tasks.Create(ctx, domain.Task{UserID: 1, Title: "outer", DueDate: dueDate})
+			return transactor.Transaction(ctx, func(ctx context.Context) error {
+				return txErr
+			})
+		})
+
+		assert.Equal(t, txErr, err)
+		_, err = tasks.GetByID(context.TODO(), outerID)
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+	})
+}
@@ -12,7 +12,10 @@ type MockSqlDriver struct {
 	Conn               *sql.DB
 	MockQueryContext   func(context.Context, string, ...interface{}) (database.Rows, error)
 	MockExecuteContext func(context.Context, string, ...interface{}) (database.Result, error)
+	MockInsertContext  func(context.Context, string, ...interface{}) (int64, error)
 	MockErrNoRows      func() error
+	MockTransaction    func(context.Context, func(context.Context) error) error
+	MockDialect        func() database.Dialect
 }
 
 func (m *MockSqlDriver) QueryContext(ctx context.Context, query string, args ...interface{}) (database.Rows, error) {
@@ -23,15 +26,41 @@ func (m *MockSqlDriver) ExecuteContext(ctx context.Context, query string, args .
 	return m.MockExecuteContext(ctx, query, args)
 }
 
+func (m *MockSqlDriver) InsertContext(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	return m.MockInsertContext(ctx, query, args)
+}
+
 func (m *MockSqlDriver) ErrNoRows() error {
 	return m.MockErrNoRows()
 }
 
+func (m *MockSqlDriver) Dialect() database.Dialect {
+	return m.MockDialect()
+}
+
+func (m *MockSqlDriver) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	return m.MockTransaction(ctx, fn)
+}
+
+type MockTransactor struct {
+	database.Transactor
+	MockTransaction func(context.Context, func(context.Context) error) error
+}
+
+func (m *MockTransactor) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	return m.MockTransaction(ctx, fn)
+}
+
 type MockRows struct {
 	database.Rows
-	MockScan  func(...interface{}) error
-	MockNext  func() bool
-	MockClose func() error
+	MockColumns func() ([]string, error)
+	MockScan    func(...interface{}) error
+	MockNext    func() bool
+	MockClose   func() error
+}
+
+func (m *MockRows) Columns() ([]string, error) {
+	return m.MockColumns()
 }
 
 func (m *MockRows) Scan(args ...interface{}) error {
@@ -0,0 +1,18 @@
+package database
+
+import "context"
+
+// operationKey クエリの操作名をcontextに保持する際のキー
+type operationKey struct{}
+
+// WithOperation クエリのメトリクスとトレースに記録する操作名をcontextに設定します
+// 操作名は公開するメトリクスのラベルとなるため、「パッケージ名.メソッド名」(例: task.FindByUserID)の固定の文字列を指定します
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// OperationFromContext contextに設定されたクエリの操作名を取得します。設定されていない場合は空文字を返却します
+func OperationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
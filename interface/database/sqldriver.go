@@ -3,12 +3,28 @@ package database
 import "context"
 
 type SqlDriver interface {
+	Transactor
 	QueryContext(context.Context, string, ...interface{}) (Rows, error)
 	ExecuteContext(context.Context, string, ...interface{}) (Result, error)
+	// InsertContext INSERTのクエリを実行し、追加された行のidを返却します
+	InsertContext(context.Context, string, ...interface{}) (int64, error)
 	ErrNoRows() error
+	Close() error
+	// PingContext データベースに接続できるかどうかを確認します
+	PingContext(context.Context) error
+	// Dialect 接続しているデータベースのSQLの方言を返却します
+	Dialect() Dialect
+}
+
+// Transactor 複数のクエリを1つのトランザクションで実行します
+// fnに渡されたcontextを使用したクエリは、同じトランザクション内で実行されます
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Rows interface {
+	// Columns 取得結果の列名を返却します
+	Columns() ([]string, error)
 	Scan(...interface{}) error
 	Next() bool
 	Close() error
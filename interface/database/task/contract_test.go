@@ -0,0 +1,31 @@
+package task_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database/databasetest"
+	taskRepository "github.com/Hajime3778/go-clean-arch/interface/database/task"
+	"github.com/Hajime3778/go-clean-arch/interface/database/task/tasktest"
+	userRepository "github.com/Hajime3778/go-clean-arch/interface/database/user"
+)
+
+func TestContract(t *testing.T) {
+	t.Run("SQLite", func(t *testing.T) {
+		tasktest.RunContract(t, func(t *testing.T) (taskRepository.TaskRepository, int64) {
+			sqlDriver := databasetest.NewSQLite(t)
+			userID, err := userRepository.NewUserRepository(sqlDriver).Create(context.TODO(), domain.User{Name: "test", Email: "test@example.com"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return taskRepository.NewTaskRepository(sqlDriver), userID
+		})
+	})
+
+	t.Run("メモリ", func(t *testing.T) {
+		tasktest.RunContract(t, func(t *testing.T) (taskRepository.TaskRepository, int64) {
+			return taskRepository.NewMemoryTaskRepository(), 1
+		})
+	})
+}
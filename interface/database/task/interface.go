@@ -13,4 +13,5 @@ type TaskRepository interface {
 	Create(ctx context.Context, task domain.Task) (int64, error)
 	Update(ctx context.Context, task domain.Task) error
 	Delete(ctx context.Context, id int64) error
+	Stats(ctx context.Context, userID int64, period domain.StatsPeriod) (domain.TaskStats, error)
 }
@@ -0,0 +1,192 @@
+package task
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// memoryTaskRepository プロセス内のメモリにタスクを保持します
+// データはプロセスの終了とともに破棄されるため、デモやテストでの使用を想定しています
+type memoryTaskRepository struct {
+	mu     sync.RWMutex
+	tasks  map[int64]domain.Task
+	lastID int64
+}
+
+// NewMemoryTaskRepository メモリにタスクを保持するRepositoryオブジェクトを作成します
+func NewMemoryTaskRepository() TaskRepository {
+	return &memoryTaskRepository{tasks: map[int64]domain.Task{}}
+}
+
+// Snapshot 現在の状態を保存し、その状態に戻す関数を返却します
+// 保持している値は置き換えるのみで直接変更しないため、コピーは浅いコピーとしています
+func (tr *memoryTaskRepository) Snapshot() func() {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	tasks := maps.Clone(tr.tasks)
+	lastID := tr.lastID
+	return func() {
+		tr.mu.Lock()
+		defer tr.mu.Unlock()
+		tr.tasks = tasks
+		tr.lastID = lastID
+	}
+}
+
+// FindByUserID タスクをユーザーIDで期限の昇順に複数件取得します
+// 期限が同じタスクはIDの昇順とし、データベースの実装と同じ順序にします
+func (tr *memoryTaskRepository) FindByUserID(ctx context.Context, userID int64, limit int64, offset int64) ([]domain.Task, error) {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	tasks := make([]domain.Task, 0)
+	for _, task := range tr.tasks {
+		if task.UserID == userID {
+			tasks = append(tasks, copyTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].DueDate.Equal(tasks[j].DueDate) {
+			return tasks[i].DueDate.Before(tasks[j].DueDate)
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+
+	if offset >= int64(len(tasks)) {
+		return []domain.Task{}, nil
+	}
+	end := offset + limit
+	if end > int64(len(tasks)) {
+		end = int64(len(tasks))
+	}
+	return tasks[offset:end], nil
+}
+
+// GetByID タスクを1件取得します
+func (tr *memoryTaskRepository) GetByID(ctx context.Context, id int64) (domain.Task, error) {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	task, ok := tr.tasks[id]
+	if !ok {
+		return domain.Task{}, domain.ErrRecordNotFound
+	}
+	return copyTask(task), nil
+}
+
+// Create タスクを1件作成します
+func (tr *memoryTaskRepository) Create(ctx context.Context, task domain.Task) (int64, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.lastID++
+	now := time.Now()
+	tr.tasks[tr.lastID] = copyTask(domain.Task{
+		ID:          tr.lastID,
+		UserID:      task.UserID,
+		Title:       task.Title,
+		Content:     task.Content,
+		DueDate:     task.DueDate,
+		CompletedAt: task.CompletedAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	return tr.lastID, nil
+}
+
+// Update IDでタスクを1件更新します
+// タスクが存在しない場合は何もしません
+func (tr *memoryTaskRepository) Update(ctx context.Context, task domain.Task) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	stored, ok := tr.tasks[task.ID]
+	if !ok {
+		return nil
+	}
+	stored.Title = task.Title
+	stored.Content = task.Content
+	stored.DueDate = task.DueDate
+	stored.CompletedAt = task.CompletedAt
+	stored.UpdatedAt = time.Now()
+	tr.tasks[task.ID] = copyTask(stored)
+	return nil
+}
+
+// Delete IDでタスクを1件削除します
+func (tr *memoryTaskRepository) Delete(ctx context.Context, id int64) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	delete(tr.tasks, id)
+	return nil
+}
+
+// Stats ユーザーのタスクを集計します
+// 日ごとの完了数は、period.TrendStartのタイムゾーンでの日付で集計します
+func (tr *memoryTaskRepository) Stats(ctx context.Context, userID int64, period domain.StatsPeriod) (domain.TaskStats, error) {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	stats := domain.TaskStats{}
+	var total, seconds int64
+	daily := map[string]int64{}
+	for _, task := range tr.tasks {
+		if task.UserID != userID {
+			continue
+		}
+		total++
+		if task.CompletedAt == nil {
+			if task.DueDate.Before(period.Now) {
+				stats.Overdue++
+			}
+			if within(task.DueDate, period.TodayStart, period.TomorrowStart) {
+				stats.DueToday++
+			}
+			if within(task.DueDate, period.WeekStart, period.WeekEnd) {
+				stats.DueThisWeek++
+			}
+			continue
+		}
+
+		stats.ByStatus.Completed++
+		seconds += int64(task.CompletedAt.Sub(task.CreatedAt) / time.Second)
+		if within(*task.CompletedAt, period.TrendStart, period.TomorrowStart) {
+			daily[task.CompletedAt.In(period.TrendStart.Location()).Format(domain.DateLayout)]++
+		}
+	}
+	stats.ByStatus.Open = total - stats.ByStatus.Completed
+	if stats.ByStatus.Completed > 0 {
+		average := float64(seconds) / float64(stats.ByStatus.Completed)
+		stats.AverageCompletionSeconds = &average
+	}
+
+	stats.CompletionTrend = make([]domain.DailyCount, 0, len(daily))
+	for date, count := range daily {
+		stats.CompletionTrend = append(stats.CompletionTrend, domain.DailyCount{Date: date, Count: count})
+	}
+	sort.Slice(stats.CompletionTrend, func(i, j int) bool {
+		return stats.CompletionTrend[i].Date < stats.CompletionTrend[j].Date
+	})
+	return stats, nil
+}
+
+// within tが[start, end)に含まれるかどうかを返却します
+func within(t time.Time, start time.Time, end time.Time) bool {
+	return !t.Before(start) && t.Before(end)
+}
+
+// copyTask 呼び出し元と保持しているタスクでCompletedAtを共有しないよう複製します
+func copyTask(task domain.Task) domain.Task {
+	if task.CompletedAt != nil {
+		completedAt := *task.CompletedAt
+		task.CompletedAt = &completedAt
+	}
+	return task
+}
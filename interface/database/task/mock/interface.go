@@ -14,6 +14,7 @@ type MockTaskRepo struct {
 	MockCreate       func(ctx context.Context, task domain.Task) (int64, error)
 	MockUpdate       func(ctx context.Context, task domain.Task) error
 	MockDelete       func(ctx context.Context, id int64) error
+	MockStats        func(ctx context.Context, userID int64, period domain.StatsPeriod) (domain.TaskStats, error)
 }
 
 func (m *MockTaskRepo) FindByUserID(ctx context.Context, userID int64, limit int64, offset int64) ([]domain.Task, error) {
@@ -35,3 +36,7 @@ func (m *MockTaskRepo) Update(ctx context.Context, task domain.Task) error {
 func (m *MockTaskRepo) Delete(ctx context.Context, id int64) error {
 	return m.MockDelete(ctx, id)
 }
+
+func (m *MockTaskRepo) Stats(ctx context.Context, userID int64, period domain.StatsPeriod) (domain.TaskStats, error) {
+	return m.MockStats(ctx, userID, period)
+}
@@ -2,12 +2,27 @@ package task
 
 import (
 	"context"
-	"log"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
 	"github.com/Hajime3778/go-clean-arch/interface/database"
 )
 
+// taskMapper tasksテーブルの列とタスクのフィールドの対応
+var taskMapper = database.NewMapper(
+	database.Column("id", func(t *domain.Task) interface{} { return &t.ID }),
+	database.Column("user_id", func(t *domain.Task) interface{} { return &t.UserID }),
+	database.Column("title", func(t *domain.Task) interface{} { return &t.Title }),
+	database.Column("content", func(t *domain.Task) interface{} { return &t.Content }),
+	database.Column("due_date", func(t *domain.Task) interface{} { return &t.DueDate }),
+	database.Column("created_at", func(t *domain.Task) interface{} { return &t.CreatedAt }),
+	database.Column("updated_at", func(t *domain.Task) interface{} { return &t.UpdatedAt }),
+	database.Column("completed_at", func(t *domain.Task) interface{} { return &t.CompletedAt }),
+)
+
 type taskRepository struct {
 	SqlDriver database.SqlDriver
 }
@@ -17,21 +32,22 @@ func NewTaskRepository(sqlDriver database.SqlDriver) TaskRepository {
 	return &taskRepository{sqlDriver}
 }
 
-// FindByUserID タスクをユーザーIDで複数件取得します
+// FindByUserID タスクをユーザーIDで期限の昇順に複数件取得します
+// 期限が同じタスクはIDの昇順とし、ページの境界で重複・欠落しないようにします
 func (tr *taskRepository) FindByUserID(ctx context.Context, userID int64, limit int64, offset int64) ([]domain.Task, error) {
 	query := `
 		SELECT
-			*
+			` + taskMapper.Columns() + `
 		FROM
 			tasks
 		WHERE
 			user_id = ?
 		ORDER BY
-			due_date
+			due_date, id
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := tr.SqlDriver.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := tr.SqlDriver.QueryContext(database.WithOperation(ctx, "task.FindByUserID"), query, userID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -39,43 +55,24 @@ func (tr *taskRepository) FindByUserID(ctx context.Context, userID int64, limit
 	defer func() {
 		err := rows.Close()
 		if err != nil {
-			log.Println(err.Error())
+			slog.ErrorContext(ctx, "rows close failed", "error", err)
 		}
 	}()
 
-	tasks := make([]domain.Task, 0)
-	for rows.Next() {
-		task := domain.Task{}
-		err = rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&task.Title,
-			&task.Content,
-			&task.DueDate,
-			&task.UpdatedAt,
-			&task.CreatedAt,
-		)
-
-		if err != nil {
-			return nil, err
-		}
-		tasks = append(tasks, task)
-	}
-
-	return tasks, nil
+	return taskMapper.ScanAll(rows)
 }
 
 // GetByID IDでタスクを1件取得します
 func (tr *taskRepository) GetByID(ctx context.Context, id int64) (domain.Task, error) {
 	query := `
-		SELECT 
-			* 
-		FROM 
+		SELECT
+			` + taskMapper.Columns() + `
+		FROM
 			tasks
-		WHERE 
+		WHERE
 			id = ?
 	`
-	rows, err := tr.SqlDriver.QueryContext(ctx, query, id)
+	rows, err := tr.SqlDriver.QueryContext(database.WithOperation(ctx, "task.GetByID"), query, id)
 	if err != nil {
 		return domain.Task{}, err
 	}
@@ -83,7 +80,7 @@ func (tr *taskRepository) GetByID(ctx context.Context, id int64) (domain.Task, e
 	defer func() {
 		err := rows.Close()
 		if err != nil {
-			log.Println(err.Error())
+			slog.ErrorContext(ctx, "rows close failed", "error", err)
 		}
 	}()
 
@@ -91,22 +88,7 @@ func (tr *taskRepository) GetByID(ctx context.Context, id int64) (domain.Task, e
 		return domain.Task{}, domain.ErrRecordNotFound
 	}
 
-	task := domain.Task{}
-	err = rows.Scan(
-		&task.ID,
-		&task.UserID,
-		&task.Title,
-		&task.Content,
-		&task.DueDate,
-		&task.UpdatedAt,
-		&task.CreatedAt,
-	)
-
-	if err != nil {
-		return task, err
-	}
-
-	return task, nil
+	return taskMapper.Scan(rows)
 }
 
 // Create タスクを1件作成します
@@ -114,12 +96,7 @@ func (tr *taskRepository) Create(ctx context.Context, task domain.Task) (int64,
 	query := `
 		INSERT INTO tasks(user_id,title,content,due_date) VALUES(?,?,?,?)
 	`
-	result, err := tr.SqlDriver.ExecuteContext(ctx, query, task.UserID, task.Title, task.Content, task.DueDate)
-	if err != nil {
-		return 0, err
-	}
-
-	createdId, err := result.LastInsertId()
+	createdId, err := tr.SqlDriver.InsertContext(database.WithOperation(ctx, "task.Create"), query, task.UserID, task.Title, task.Content, task.DueDate)
 	if err != nil {
 		return 0, err
 	}
@@ -130,9 +107,9 @@ func (tr *taskRepository) Create(ctx context.Context, task domain.Task) (int64,
 // Update IDでタスクを1件更新します
 func (tr *taskRepository) Update(ctx context.Context, task domain.Task) error {
 	query := `
-		UPDATE tasks SET title = ?, content = ?, due_date = ? where id = ? 
+		UPDATE tasks SET title = ?, content = ?, due_date = ?, completed_at = ? where id = ? 
 	`
-	_, err := tr.SqlDriver.ExecuteContext(ctx, query, task.Title, task.Content, task.DueDate, task.ID)
+	_, err := tr.SqlDriver.ExecuteContext(database.WithOperation(ctx, "task.Update"), query, task.Title, task.Content, task.DueDate, task.CompletedAt, task.ID)
 	if err != nil {
 		return err
 	}
@@ -145,10 +122,131 @@ func (tr *taskRepository) Delete(ctx context.Context, id int64) error {
 	query := `
 		DELETE FROM tasks where id = ? 
 	`
-	_, err := tr.SqlDriver.ExecuteContext(ctx, query, id)
+	_, err := tr.SqlDriver.ExecuteContext(database.WithOperation(ctx, "task.Delete"), query, id)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// Stats ユーザーのタスクを集計します
+func (tr *taskRepository) Stats(ctx context.Context, userID int64, period domain.StatsPeriod) (domain.TaskStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(completed_at),
+			COUNT(CASE WHEN completed_at IS NULL AND due_date < ? THEN 1 END),
+			COUNT(CASE WHEN completed_at IS NULL AND due_date >= ? AND due_date < ? THEN 1 END),
+			COUNT(CASE WHEN completed_at IS NULL AND due_date >= ? AND due_date < ? THEN 1 END),
+			AVG(` + tr.SqlDriver.Dialect().SecondsBetween("created_at", "completed_at") + `)
+		FROM
+			tasks
+		WHERE
+			user_id = ?
+	`
+	rows, err := tr.SqlDriver.QueryContext(database.WithOperation(ctx, "task.Stats"), query,
+		period.Now,
+		period.TodayStart, period.TomorrowStart,
+		period.WeekStart, period.WeekEnd,
+		userID,
+	)
+	if err != nil {
+		return domain.TaskStats{}, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "rows close failed", "error", err)
+		}
+	}()
+
+	stats := domain.TaskStats{}
+	var total int64
+	var average sql.NullFloat64
+	if rows.Next() {
+		err = rows.Scan(
+			&total,
+			&stats.ByStatus.Completed,
+			&stats.Overdue,
+			&stats.DueToday,
+			&stats.DueThisWeek,
+			&average,
+		)
+		if err != nil {
+			return domain.TaskStats{}, err
+		}
+	}
+	stats.ByStatus.Open = total - stats.ByStatus.Completed
+	if average.Valid {
+		stats.AverageCompletionSeconds = &average.Float64
+	}
+
+	stats.CompletionTrend, err = tr.completionTrend(ctx, userID, period)
+	if err != nil {
+		return domain.TaskStats{}, err
+	}
+	return stats, nil
+}
+
+// completionTrend 完了したタスクの件数を日ごとに集計します
+// 完了したタスクがない日は含まれません
+func (tr *taskRepository) completionTrend(ctx context.Context, userID int64, period domain.StatsPeriod) ([]domain.DailyCount, error) {
+	query := `
+		SELECT
+			` + tr.SqlDriver.Dialect().Date("completed_at", period.TrendStart) + ` AS completed_date,
+			COUNT(*)
+		FROM
+			tasks
+		WHERE
+			user_id = ? AND completed_at >= ? AND completed_at < ?
+		GROUP BY
+			completed_date
+		ORDER BY
+			completed_date
+	`
+	rows, err := tr.SqlDriver.QueryContext(database.WithOperation(ctx, "task.CompletionTrend"), query, userID, period.TrendStart, period.TomorrowStart)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "rows close failed", "error", err)
+		}
+	}()
+
+	counts := make([]domain.DailyCount, 0)
+	for rows.Next() {
+		var date dateValue
+		count := domain.DailyCount{}
+		err = rows.Scan(&date, &count.Count)
+		if err != nil {
+			return nil, err
+		}
+		count.Date = string(date)
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
+
+// dateValue DATE関数の結果を YYYY-MM-DD 形式で読み込みます
+// MySQLは日時、SQLiteは文字列で返却するため、どちらも受け付けます
+type dateValue string
+
+// Scan sql.Scannerを実装します
+func (d *dateValue) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = dateValue(v.Format(domain.DateLayout))
+	case string:
+		*d = dateValue(v)
+	case []byte:
+		*d = dateValue(v)
+	default:
+		return fmt.Errorf("unsupported date value: %T", src)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package task_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database/databasetest"
+	taskRepository "github.com/Hajime3778/go-clean-arch/interface/database/task"
+	userRepository "github.com/Hajime3778/go-clean-arch/interface/database/user"
+	"github.com/Hajime3778/go-clean-arch/util/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLite(t *testing.T) {
+	ctx := context.TODO()
+	sqlDriver := databasetest.NewSQLite(t)
+	repo := taskRepository.NewTaskRepository(sqlDriver)
+	userID, err := userRepository.NewUserRepository(sqlDriver).Create(ctx, domain.User{Name: "test", Email: "test@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+
+	t.Run("正常系 更新した内容が取得でき、更新日時が更新されること", func(t *testing.T) {
+		id, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "title", DueDate: time.Now()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, err = sqlDriver.ExecuteContext(ctx, "UPDATE tasks SET created_at = ?, updated_at = ? WHERE id = ?", past, past, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		completedAt := time.Date(2021, 12, 8, 10, 0, 0, 0, jst)
+
+		err = repo.Update(ctx, domain.Task{ID: id, Title: "updated", DueDate: completedAt, CompletedAt: &completedAt})
+		assert.NoError(t, err)
+
+		got, err := repo.GetByID(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, "updated", got.Title)
+		assert.True(t, completedAt.Equal(*got.CompletedAt))
+		assert.True(t, past.Equal(got.CreatedAt))
+		assert.True(t, got.UpdatedAt.After(past))
+	})
+
+	t.Run("異常系 存在しないユーザーのタスクを作成した場合、外部キー制約のエラーとなること", func(t *testing.T) {
+		_, err := repo.Create(ctx, domain.Task{UserID: userID + 100, Title: "title", DueDate: time.Now()})
+		assert.Error(t, err)
+	})
+}
+
+func TestSQLiteStats(t *testing.T) {
+	ctx := context.TODO()
+	sqlDriver := databasetest.NewSQLite(t)
+	repo := taskRepository.NewTaskRepository(sqlDriver)
+	userID, err := userRepository.NewUserRepository(sqlDriver).Create(ctx, domain.User{Name: "test", Email: "test@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	today := time.Date(2021, 12, 8, 0, 0, 0, 0, jst)
+	period := domain.StatsPeriod{
+		Now:           today.Add(10 * time.Hour),
+		TodayStart:    today,
+		TomorrowStart: today.AddDate(0, 0, 1),
+		WeekStart:     today.AddDate(0, 0, -2),
+		WeekEnd:       today.AddDate(0, 0, 5),
+		TrendStart:    today.AddDate(0, 0, -6),
+	}
+
+	// 日本時間の0時台に完了したタスクは、UTCでは前日となるが日本時間の日付で集計されること
+	completed := []struct {
+		createdAt   time.Time
+		completedAt time.Time
+	}{
+		{today.Add(-24 * time.Hour), today.Add(-24*time.Hour + 1*time.Hour)},
+		{today.Add(-2 * time.Hour), today.Add(30 * time.Minute)},
+		{today.Add(-1 * time.Hour), today.Add(9 * time.Hour)},
+	}
+	for _, c := range completed {
+		id, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "completed", DueDate: c.completedAt})
+		if err != nil {
+			t.Fatal(err)
+		}
+		completedAt := c.completedAt
+		err = repo.Update(ctx, domain.Task{ID: id, Title: "completed", DueDate: c.completedAt, CompletedAt: &completedAt})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = sqlDriver.ExecuteContext(ctx, "UPDATE tasks SET created_at = ? WHERE id = ?", c.createdAt, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, dueDate := range []time.Time{
+		today.Add(-time.Hour),      // 期限切れ
+		today.Add(12 * time.Hour),  // 今日期限
+		today.AddDate(0, 0, 3),     // 今週期限
+		today.AddDate(0, 0, 10),    // 来週以降
+		today.Add(9 * time.Hour),   // 期限切れ(現在時刻より前)
+		today.Add(-48 * time.Hour), // 期限切れ
+	} {
+		_, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "open", DueDate: dueDate})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("正常系 集計結果がMySQLと同じ規則で取得できること", func(t *testing.T) {
+		got, err := repo.Stats(ctx, userID, period)
+		assert.NoError(t, err)
+
+		average := float64(1*60*60+150*60+10*60*60) / 3
+		assert.Equal(t, domain.TaskStats{
+			ByStatus:    domain.TaskStatusCounts{Open: 6, Completed: 3},
+			Overdue:     3,
+			DueToday:    2,
+			DueThisWeek: 5,
+			CompletionTrend: []domain.DailyCount{
+				{Date: "2021-12-07", Count: 1},
+				{Date: "2021-12-08", Count: 2},
+			},
+			AverageCompletionSeconds: &average,
+		}, got)
+	})
+
+	t.Run("正常系 クエリの実行時間がメソッドごとの操作名で記録されること", func(t *testing.T) {
+		operations := []string{"task.Create", "task.GetByID", "task.FindByUserID", "task.Update", "task.Delete", "task.Stats", "task.CompletionTrend"}
+		before := make(map[string]uint64, len(operations))
+		for _, operation := range operations {
+			before[operation] = querySampleCount(t, operation)
+		}
+
+		id, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "title", DueDate: time.Now()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = repo.GetByID(ctx, id)
+		_, _ = repo.FindByUserID(ctx, userID, 10, 0)
+		_ = repo.Update(ctx, domain.Task{ID: id, Title: "updated", DueDate: time.Now()})
+		now := time.Now()
+		_, _ = repo.Stats(ctx, userID, domain.StatsPeriod{Now: now, TodayStart: now, TomorrowStart: now, WeekStart: now, WeekEnd: now, TrendStart: now})
+		_ = repo.Delete(ctx, id)
+
+		for _, operation := range operations {
+			assert.Equal(t, before[operation]+1, querySampleCount(t, operation), operation)
+		}
+	})
+}
+
+// querySampleCount 操作名ごとのクエリの実行回数を取得します
+func querySampleCount(t *testing.T, operation string) uint64 {
+	histogram := metrics.DBQueryDuration.WithLabelValues(operation, metrics.ResultSuccess)
+	var m dto.Metric
+	err := histogram.(prometheus.Metric).Write(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
@@ -31,22 +31,22 @@ func TestFindByUserID(t *testing.T) {
 
 	query := `
 		SELECT
-			*
+			id, user_id, title, content, due_date, created_at, updated_at, completed_at
 		FROM
 			tasks
 		WHERE
 			user_id = ?
 		ORDER BY
-			due_date
+			due_date, id
 		LIMIT ? OFFSET ?
 	`
 
 	t.Run("正常系 指定したユーザーIDで取得", func(t *testing.T) {
 		userID := int64(1)
-		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "content", "due_date", "updated_at", "created_at"})
+		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "content", "due_date", "created_at", "updated_at", "completed_at"})
 		mockTasks := createMockTasks(5, userID)
 		for _, mockTask := range mockTasks {
-			rows.AddRow(mockTask.ID, mockTask.UserID, mockTask.Title, mockTask.Content, mockTask.DueDate, mockTask.UpdatedAt, mockTask.CreatedAt)
+			rows.AddRow(mockTask.ID, mockTask.UserID, mockTask.Title, mockTask.Content, mockTask.DueDate, mockTask.CreatedAt, mockTask.UpdatedAt, nil)
 		}
 		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(userID, 3, 1).WillReturnRows(rows)
 
@@ -57,7 +57,7 @@ func TestFindByUserID(t *testing.T) {
 
 	t.Run("準正常系 データが存在しない場合、エラーとならないこと", func(t *testing.T) {
 		userID := int64(1)
-		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "content", "due_date", "updated_at", "created_at"})
+		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "content", "due_date", "created_at", "updated_at", "completed_at"})
 		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(userID, 5, 0).WillReturnRows(rows)
 
 		got, err := repo.FindByUserID(context.TODO(), userID, 5, 0)
@@ -118,11 +118,11 @@ func TestGetByID(t *testing.T) {
 	}
 
 	repo := taskRepository.NewTaskRepository(sqlDriver)
-	query := "SELECT * FROM tasks WHERE id = ?"
+	query := "SELECT id, user_id, title, content, due_date, created_at, updated_at, completed_at FROM tasks WHERE id = ?"
 
 	t.Run("正常系 存在するIDで1件取得", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "content", "due_date", "updated_at", "created_at"}).
-			AddRow(mockTask.ID, mockTask.UserID, mockTask.Title, mockTask.Content, mockTask.DueDate, mockTask.UpdatedAt, mockTask.CreatedAt)
+		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "content", "due_date", "created_at", "updated_at", "completed_at"}).
+			AddRow(mockTask.ID, mockTask.UserID, mockTask.Title, mockTask.Content, mockTask.DueDate, mockTask.CreatedAt, mockTask.UpdatedAt, nil)
 		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockTask.ID).WillReturnRows(rows)
 
 		got, err := repo.GetByID(context.TODO(), mockTask.ID)
@@ -130,8 +130,34 @@ func TestGetByID(t *testing.T) {
 		assert.Equal(t, mockTask, got)
 	})
 
+	t.Run("正常系 完了済みのタスクの完了日時が取得できること", func(t *testing.T) {
+		completedAt := time.Now()
+		completedTask := mockTask
+		completedTask.CompletedAt = &completedAt
+		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "content", "due_date", "created_at", "updated_at", "completed_at"}).
+			AddRow(mockTask.ID, mockTask.UserID, mockTask.Title, mockTask.Content, mockTask.DueDate, mockTask.CreatedAt, mockTask.UpdatedAt, completedAt)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockTask.ID).WillReturnRows(rows)
+
+		got, err := repo.GetByID(context.TODO(), mockTask.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, completedTask, got)
+	})
+
+	t.Run("正常系 作成日時と更新日時が入れ替わらずに取得できること", func(t *testing.T) {
+		createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+		updatedAt := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "content", "due_date", "created_at", "updated_at", "completed_at"}).
+			AddRow(mockTask.ID, mockTask.UserID, mockTask.Title, mockTask.Content, mockTask.DueDate, createdAt, updatedAt, nil)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockTask.ID).WillReturnRows(rows)
+
+		got, err := repo.GetByID(context.TODO(), mockTask.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, createdAt, got.CreatedAt)
+		assert.Equal(t, updatedAt, got.UpdatedAt)
+	})
+
 	t.Run("準正常系 存在しないIDで検索してエラーとなること", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "content", "due_date", "updated_at", "created_at"})
+		rows := sqlmock.NewRows([]string{"id", "user_id", "title", "content", "due_date", "created_at", "updated_at", "completed_at"})
 		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(2)).WillReturnRows(rows)
 
 		got, err := repo.GetByID(context.TODO(), int64(2))
@@ -226,14 +252,9 @@ func TestCreate(t *testing.T) {
 	t.Run("異常系 追加後IDで失敗した場合エラーが返却されtること", func(t *testing.T) {
 
 		mockErr := errors.New("test error")
-		mockResult := &mockSqlDriver.MockResult{
-			MockLastInsertId: func() (int64, error) {
-				return 0, mockErr
-			},
-		}
 		mockDriver := &mockSqlDriver.MockSqlDriver{
-			MockExecuteContext: func(context.Context, string, ...interface{}) (database.Result, error) {
-				return mockResult, nil
+			MockInsertContext: func(context.Context, string, ...interface{}) (int64, error) {
+				return 0, mockErr
 			},
 		}
 		mockDriver.Conn = db
@@ -271,7 +292,7 @@ func TestUpdate(t *testing.T) {
 	sqlDriver.Conn = db
 
 	repo := taskRepository.NewTaskRepository(sqlDriver)
-	query := "UPDATE tasks SET title = ?, content = ?, due_date = ? where id = ?"
+	query := "UPDATE tasks SET title = ?, content = ?, due_date = ?, completed_at = ? where id = ?"
 
 	t.Run("正常系 1件更新", func(t *testing.T) {
 		mockTask := domain.Task{
@@ -282,7 +303,7 @@ func TestUpdate(t *testing.T) {
 		}
 		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
 		prep.ExpectExec().
-			WithArgs(mockTask.Title, mockTask.Content, mockTask.DueDate, mockTask.ID).
+			WithArgs(mockTask.Title, mockTask.Content, mockTask.DueDate, mockTask.CompletedAt, mockTask.ID).
 			WillReturnResult(sqlmock.NewResult(12, 1))
 
 		err = repo.Update(context.TODO(), mockTask)
@@ -299,7 +320,7 @@ func TestUpdate(t *testing.T) {
 		mockErr := errors.New("query failed error")
 		prep := mock.ExpectPrepare(regexp.QuoteMeta(query))
 		prep.ExpectExec().
-			WithArgs(mockTask.Title, mockTask.Content, mockTask.DueDate, mockTask.ID).
+			WithArgs(mockTask.Title, mockTask.Content, mockTask.DueDate, mockTask.CompletedAt, mockTask.ID).
 			WillReturnError(mockErr)
 
 		err = repo.Update(context.TODO(), mockTask)
@@ -342,6 +363,117 @@ func TestDelete(t *testing.T) {
 }
 
 // createMockTasks モックのタスクを指定したユーザーIDで作成します
+func TestStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+
+	sqlDriver := new(infrastructure.SqlDriver)
+	sqlDriver.Conn = db
+
+	repo := taskRepository.NewTaskRepository(sqlDriver)
+	query := "SELECT COUNT(*), COUNT(completed_at), COUNT(CASE WHEN completed_at IS NULL AND due_date < ? THEN 1 END), COUNT(CASE WHEN completed_at IS NULL AND due_date >= ? AND due_date < ? THEN 1 END), COUNT(CASE WHEN completed_at IS NULL AND due_date >= ? AND due_date < ? THEN 1 END), AVG(TIMESTAMPDIFF(SECOND, created_at, completed_at)) FROM tasks WHERE user_id = ?"
+	trendQuery := "SELECT DATE(completed_at) AS completed_date, COUNT(*) FROM tasks WHERE user_id = ? AND completed_at >= ? AND completed_at < ? GROUP BY completed_date ORDER BY completed_date"
+	columns := []string{"total", "completed", "overdue", "due_today", "due_this_week", "average"}
+
+	today := time.Date(2021, 12, 8, 0, 0, 0, 0, time.UTC)
+	period := domain.StatsPeriod{
+		Now:           today.Add(10 * time.Hour),
+		TodayStart:    today,
+		TomorrowStart: today.AddDate(0, 0, 1),
+		WeekStart:     today.AddDate(0, 0, -2),
+		WeekEnd:       today.AddDate(0, 0, 5),
+		TrendStart:    today.AddDate(0, 0, -6),
+	}
+
+	t.Run("正常系 集計結果が取得できること", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).AddRow(10, 4, 2, 1, 3, "3600.5000")
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(period.Now, period.TodayStart, period.TomorrowStart, period.WeekStart, period.WeekEnd, int64(1)).
+			WillReturnRows(rows)
+		trendRows := sqlmock.NewRows([]string{"completed_date", "count"}).
+			AddRow(today.AddDate(0, 0, -1), 1).
+			AddRow(today, 3)
+		mock.ExpectQuery(regexp.QuoteMeta(trendQuery)).
+			WithArgs(int64(1), period.TrendStart, period.TomorrowStart).
+			WillReturnRows(trendRows)
+
+		got, err := repo.Stats(context.TODO(), int64(1), period)
+		assert.NoError(t, err)
+
+		average := 3600.5
+		expected := domain.TaskStats{
+			ByStatus:    domain.TaskStatusCounts{Open: 6, Completed: 4},
+			Overdue:     2,
+			DueToday:    1,
+			DueThisWeek: 3,
+			CompletionTrend: []domain.DailyCount{
+				{Date: "2021-12-07", Count: 1},
+				{Date: "2021-12-08", Count: 3},
+			},
+			AverageCompletionSeconds: &average,
+		}
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("準正常系 完了したタスクがない場合、平均がnilとなること", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).AddRow(0, 0, 0, 0, 0, nil)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(trendQuery)).WillReturnRows(sqlmock.NewRows([]string{"completed_date", "count"}))
+
+		got, err := repo.Stats(context.TODO(), int64(1), period)
+		assert.NoError(t, err)
+		assert.Nil(t, got.AverageCompletionSeconds)
+		assert.Equal(t, []domain.DailyCount{}, got.CompletionTrend)
+	})
+
+	t.Run("異常系 クエリ実行で失敗した場合エラーが返却されること", func(t *testing.T) {
+		mockErr := errors.New("query failed error")
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(mockErr)
+
+		got, err := repo.Stats(context.TODO(), int64(1), period)
+		assert.Equal(t, mockErr, err)
+		assert.Equal(t, domain.TaskStats{}, got)
+	})
+}
+
+func TestPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+	repo := taskRepository.NewTaskRepository(infrastructure.NewSqlDriver(db, database.Postgres))
+
+	t.Run("正常系 作成時に$nのプレースホルダーとRETURNING句でIDが取得されること", func(t *testing.T) {
+		query := "INSERT INTO tasks(user_id,title,content,due_date) VALUES($1,$2,$3,$4) RETURNING id"
+		mockTask := domain.Task{UserID: 1, Title: "test title", Content: "test content", DueDate: time.Now()}
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(mockTask.UserID, mockTask.Title, mockTask.Content, mockTask.DueDate).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+
+		id, err := repo.Create(context.TODO(), mockTask)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), id)
+	})
+
+	t.Run("正常系 集計のクエリがPostgresの構文となること", func(t *testing.T) {
+		today := time.Date(2021, 12, 8, 0, 0, 0, 0, time.UTC)
+		period := domain.StatsPeriod{Now: today, TodayStart: today, TomorrowStart: today, WeekStart: today, WeekEnd: today, TrendStart: today}
+		query := "AVG(CAST(EXTRACT(EPOCH FROM (completed_at - created_at)) AS BIGINT)) FROM tasks WHERE user_id = $6"
+		trendQuery := "SELECT DATE(completed_at) AS completed_date, COUNT(*) FROM tasks WHERE user_id = $1 AND completed_at >= $2 AND completed_at < $3"
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WillReturnRows(sqlmock.NewRows([]string{"total", "completed", "overdue", "due_today", "due_this_week", "average"}).AddRow(0, 0, 0, 0, 0, nil))
+		mock.ExpectQuery(regexp.QuoteMeta(trendQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"completed_date", "count"}).AddRow(today, 1))
+
+		got, err := repo.Stats(context.TODO(), int64(1), period)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.DailyCount{{Date: "2021-12-08", Count: 1}}, got.CompletionTrend)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func createMockTasks(num int, userID int64) []domain.Task {
 	mockTasks := make([]domain.Task, 0)
 	for i := 0; i < num; i++ {
@@ -0,0 +1,222 @@
+// Package tasktest TaskRepositoryの実装が満たすべき振る舞いのテストを提供します
+package tasktest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	taskRepository "github.com/Hajime3778/go-clean-arch/interface/database/task"
+	"github.com/stretchr/testify/assert"
+)
+
+// Setup テストごとに空のTaskRepositoryと、タスクを作成できるユーザーのIDを返却します
+type Setup func(t *testing.T) (repo taskRepository.TaskRepository, userID int64)
+
+// RunContract TaskRepositoryの実装がデータベースの実装と同じ振る舞いをすることをテストします
+func RunContract(t *testing.T, setup Setup) {
+	ctx := context.TODO()
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+
+	t.Run("正常系 作成したタスクが期限の昇順で取得できること", func(t *testing.T) {
+		repo, userID := setup(t)
+		base := time.Date(2021, 12, 8, 9, 0, 0, 0, jst)
+		ids := make([]int64, 0)
+		for i := 2; i >= 0; i-- {
+			id, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "title", Content: "content", DueDate: base.Add(time.Duration(i) * time.Hour)})
+			assert.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		tasks, err := repo.FindByUserID(ctx, userID, 2, 0)
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 2)
+		assert.Equal(t, ids[2], tasks[0].ID)
+		assert.Equal(t, ids[1], tasks[1].ID)
+		assert.True(t, base.Equal(tasks[0].DueDate))
+		assert.Equal(t, "content", tasks[0].Content)
+		assert.False(t, tasks[0].CreatedAt.IsZero())
+
+		tasks, err = repo.FindByUserID(ctx, userID, 2, 2)
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, ids[0], tasks[0].ID)
+	})
+
+	t.Run("正常系 期限が同じタスクがIDの昇順で、ページの境界で重複・欠落せずに取得できること", func(t *testing.T) {
+		repo, userID := setup(t)
+		dueDate := time.Date(2021, 12, 8, 9, 0, 0, 0, jst)
+		ids := make([]int64, 0)
+		for i := 0; i < 5; i++ {
+			id, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "title", DueDate: dueDate})
+			assert.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		got := make([]int64, 0)
+		for offset := int64(0); offset < 6; offset += 2 {
+			tasks, err := repo.FindByUserID(ctx, userID, 2, offset)
+			assert.NoError(t, err)
+			for _, task := range tasks {
+				got = append(got, task.ID)
+			}
+		}
+		assert.Equal(t, ids, got)
+	})
+
+	t.Run("正常系 他のユーザーのタスクが取得されないこと", func(t *testing.T) {
+		repo, userID := setup(t)
+		_, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "title", DueDate: time.Now()})
+		assert.NoError(t, err)
+
+		tasks, err := repo.FindByUserID(ctx, userID+100, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 0)
+	})
+
+	t.Run("正常系 オフセットが件数を超える場合、空の一覧が返却されること", func(t *testing.T) {
+		repo, userID := setup(t)
+		_, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "title", DueDate: time.Now()})
+		assert.NoError(t, err)
+
+		tasks, err := repo.FindByUserID(ctx, userID, 10, 5)
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 0)
+	})
+
+	t.Run("正常系 更新した内容が取得できること", func(t *testing.T) {
+		repo, userID := setup(t)
+		id, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "title", DueDate: time.Now()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		completedAt := time.Date(2021, 12, 8, 10, 0, 0, 0, jst)
+
+		err = repo.Update(ctx, domain.Task{ID: id, Title: "updated", Content: "content", DueDate: completedAt, CompletedAt: &completedAt})
+		assert.NoError(t, err)
+
+		got, err := repo.GetByID(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, got.UserID)
+		assert.Equal(t, "updated", got.Title)
+		assert.Equal(t, "content", got.Content)
+		assert.True(t, completedAt.Equal(got.DueDate))
+		assert.True(t, completedAt.Equal(*got.CompletedAt))
+
+		err = repo.Update(ctx, domain.Task{ID: id, Title: "updated", DueDate: completedAt})
+		assert.NoError(t, err)
+		got, err = repo.GetByID(ctx, id)
+		assert.NoError(t, err)
+		assert.Nil(t, got.CompletedAt)
+	})
+
+	t.Run("正常系 存在しないタスクを更新、削除した場合、エラーとならないこと", func(t *testing.T) {
+		repo, _ := setup(t)
+		assert.NoError(t, repo.Update(ctx, domain.Task{ID: 100, Title: "title", DueDate: time.Now()}))
+		assert.NoError(t, repo.Delete(ctx, 100))
+	})
+
+	t.Run("準正常系 削除したタスクを取得した場合、ErrRecordNotFoundが返却されること", func(t *testing.T) {
+		repo, userID := setup(t)
+		id, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "title", DueDate: time.Now()})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = repo.Delete(ctx, id)
+		assert.NoError(t, err)
+		_, err = repo.GetByID(ctx, id)
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+	})
+
+	t.Run("正常系 同時に作成したタスクのIDが重複しないこと", func(t *testing.T) {
+		repo, userID := setup(t)
+		var wg sync.WaitGroup
+		ids := make([]int64, 10)
+		for i := range ids {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				id, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "title", DueDate: time.Now()})
+				assert.NoError(t, err)
+				ids[i] = id
+			}(i)
+		}
+		wg.Wait()
+
+		seen := map[int64]bool{}
+		for _, id := range ids {
+			assert.False(t, seen[id])
+			seen[id] = true
+		}
+		tasks, err := repo.FindByUserID(ctx, userID, 100, 0)
+		assert.NoError(t, err)
+		assert.Len(t, tasks, len(ids))
+	})
+
+	t.Run("正常系 集計結果が取得できること", func(t *testing.T) {
+		repo, userID := setup(t)
+		today := time.Date(2021, 12, 8, 0, 0, 0, 0, jst)
+		period := domain.StatsPeriod{
+			Now:           today.Add(10 * time.Hour),
+			TodayStart:    today,
+			TomorrowStart: today.AddDate(0, 0, 1),
+			WeekStart:     today.AddDate(0, 0, -2),
+			WeekEnd:       today.AddDate(0, 0, 5),
+			TrendStart:    today.AddDate(0, 0, -6),
+		}
+
+		// 日本時間の0時台に完了したタスクは、UTCでは前日となるが日本時間の日付で集計されること
+		for _, completedAt := range []time.Time{
+			today.Add(-23 * time.Hour),
+			today.Add(30 * time.Minute),
+			today.Add(9 * time.Hour),
+			today.AddDate(0, 0, -10), // 集計期間外
+		} {
+			id, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "completed", DueDate: completedAt})
+			if err != nil {
+				t.Fatal(err)
+			}
+			completedAt := completedAt
+			err = repo.Update(ctx, domain.Task{ID: id, Title: "completed", DueDate: completedAt, CompletedAt: &completedAt})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, dueDate := range []time.Time{
+			today.Add(9 * time.Hour),   // 期限切れ(今日期限)
+			today.AddDate(0, 0, 1),     // 今週期限
+			today.AddDate(0, 0, 10),    // 来週以降
+			today.Add(-48 * time.Hour), // 期限切れ(今週期限)
+		} {
+			_, err := repo.Create(ctx, domain.Task{UserID: userID, Title: "open", DueDate: dueDate})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		got, err := repo.Stats(ctx, userID, period)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusCounts{Open: 4, Completed: 4}, got.ByStatus)
+		assert.Equal(t, int64(2), got.Overdue)
+		assert.Equal(t, int64(1), got.DueToday)
+		assert.Equal(t, int64(3), got.DueThisWeek)
+		assert.Equal(t, []domain.DailyCount{
+			{Date: "2021-12-07", Count: 1},
+			{Date: "2021-12-08", Count: 2},
+		}, got.CompletionTrend)
+		assert.NotNil(t, got.AverageCompletionSeconds)
+	})
+
+	t.Run("正常系 タスクがない場合、空の集計結果が取得できること", func(t *testing.T) {
+		repo, userID := setup(t)
+
+		got, err := repo.Stats(ctx, userID, domain.StatsPeriod{Now: time.Now()})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusCounts{}, got.ByStatus)
+		assert.Len(t, got.CompletionTrend, 0)
+		assert.Nil(t, got.AverageCompletionSeconds)
+	})
+}
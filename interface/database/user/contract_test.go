@@ -0,0 +1,23 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/infrastructure/database/databasetest"
+	userRepository "github.com/Hajime3778/go-clean-arch/interface/database/user"
+	"github.com/Hajime3778/go-clean-arch/interface/database/user/usertest"
+)
+
+func TestContract(t *testing.T) {
+	t.Run("SQLite", func(t *testing.T) {
+		usertest.RunContract(t, func(t *testing.T) userRepository.UserRepository {
+			return userRepository.NewUserRepository(databasetest.NewSQLite(t))
+		})
+	})
+
+	t.Run("メモリ", func(t *testing.T) {
+		usertest.RunContract(t, func(t *testing.T) userRepository.UserRepository {
+			return userRepository.NewMemoryUserRepository()
+		})
+	})
+}
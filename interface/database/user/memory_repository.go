@@ -0,0 +1,90 @@
+package user
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// memoryUserRepository プロセス内のメモリにユーザーを保持します
+// データはプロセスの終了とともに破棄されるため、デモやテストでの使用を想定しています
+type memoryUserRepository struct {
+	mu     sync.RWMutex
+	users  map[int64]domain.User
+	emails map[string]int64
+	lastID int64
+}
+
+// NewMemoryUserRepository メモリにユーザーを保持するRepositoryオブジェクトを作成します
+func NewMemoryUserRepository() UserRepository {
+	return &memoryUserRepository{users: map[int64]domain.User{}, emails: map[string]int64{}}
+}
+
+// Snapshot 現在の状態を保存し、その状態に戻す関数を返却します
+// 保持している値は置き換えるのみで直接変更しないため、コピーは浅いコピーとしています
+func (ur *memoryUserRepository) Snapshot() func() {
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+
+	users := maps.Clone(ur.users)
+	emails := maps.Clone(ur.emails)
+	lastID := ur.lastID
+	return func() {
+		ur.mu.Lock()
+		defer ur.mu.Unlock()
+		ur.users = users
+		ur.emails = emails
+		ur.lastID = lastID
+	}
+}
+
+// GetByID ユーザーをIDで1件取得します
+func (ur *memoryUserRepository) GetByID(ctx context.Context, id int64) (domain.User, error) {
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+
+	user, ok := ur.users[id]
+	if !ok {
+		return domain.User{}, domain.ErrRecordNotFound
+	}
+	return user, nil
+}
+
+// GetByEmail ユーザーをメールアドレスで1件取得します
+func (ur *memoryUserRepository) GetByEmail(ctx context.Context, email string) (domain.User, error) {
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+
+	id, ok := ur.emails[email]
+	if !ok {
+		return domain.User{}, domain.ErrRecordNotFound
+	}
+	return ur.users[id], nil
+}
+
+// Create ユーザーを1件作成します
+// データベースの一意制約と同様に、メールアドレスが既に存在する場合はエラーとなります
+func (ur *memoryUserRepository) Create(ctx context.Context, user domain.User) (int64, error) {
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+
+	if _, ok := ur.emails[user.Email]; ok {
+		return 0, domain.ErrExistEmail
+	}
+	ur.lastID++
+	now := time.Now()
+	ur.users[ur.lastID] = domain.User{
+		ID:        ur.lastID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Password:  user.Password,
+		Salt:      user.Salt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	ur.emails[user.Email] = ur.lastID
+	return ur.lastID, nil
+}
@@ -2,12 +2,23 @@ package user
 
 import (
 	"context"
-	"log"
+	"log/slog"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
 	"github.com/Hajime3778/go-clean-arch/interface/database"
 )
 
+// userMapper usersテーブルの列とユーザーのフィールドの対応
+var userMapper = database.NewMapper(
+	database.Column("id", func(u *domain.User) interface{} { return &u.ID }),
+	database.Column("name", func(u *domain.User) interface{} { return &u.Name }),
+	database.Column("email", func(u *domain.User) interface{} { return &u.Email }),
+	database.Column("password", func(u *domain.User) interface{} { return &u.Password }),
+	database.Column("salt", func(u *domain.User) interface{} { return &u.Salt }),
+	database.Column("created_at", func(u *domain.User) interface{} { return &u.CreatedAt }),
+	database.Column("updated_at", func(u *domain.User) interface{} { return &u.UpdatedAt }),
+)
+
 type userRepository struct {
 	SqlDriver database.SqlDriver
 }
@@ -19,14 +30,14 @@ func NewUserRepository(sqlDriver database.SqlDriver) UserRepository {
 
 func (ur *userRepository) GetByID(ctx context.Context, id int64) (domain.User, error) {
 	query := `
-		SELECT 
-			* 
-		FROM 
+		SELECT
+			` + userMapper.Columns() + `
+		FROM
 			users
-		WHERE 
+		WHERE
 			id = ?
 	`
-	rows, err := ur.SqlDriver.QueryContext(ctx, query, id)
+	rows, err := ur.SqlDriver.QueryContext(database.WithOperation(ctx, "user.GetByID"), query, id)
 	if err != nil {
 		return domain.User{}, err
 	}
@@ -34,7 +45,7 @@ func (ur *userRepository) GetByID(ctx context.Context, id int64) (domain.User, e
 	defer func() {
 		err := rows.Close()
 		if err != nil {
-			log.Println(err.Error())
+			slog.ErrorContext(ctx, "rows close failed", "error", err)
 		}
 	}()
 
@@ -42,34 +53,19 @@ func (ur *userRepository) GetByID(ctx context.Context, id int64) (domain.User, e
 		return domain.User{}, domain.ErrRecordNotFound
 	}
 
-	user := domain.User{}
-	err = rows.Scan(
-		&user.ID,
-		&user.Name,
-		&user.Email,
-		&user.Password,
-		&user.Salt,
-		&user.UpdatedAt,
-		&user.CreatedAt,
-	)
-
-	if err != nil {
-		return user, err
-	}
-
-	return user, nil
+	return userMapper.Scan(rows)
 }
 
 func (ur *userRepository) GetByEmail(ctx context.Context, email string) (domain.User, error) {
 	query := `
-		SELECT 
-			* 
-		FROM 
+		SELECT
+			` + userMapper.Columns() + `
+		FROM
 			users
-		WHERE 
+		WHERE
 			email = ?
 	`
-	rows, err := ur.SqlDriver.QueryContext(ctx, query, email)
+	rows, err := ur.SqlDriver.QueryContext(database.WithOperation(ctx, "user.GetByEmail"), query, email)
 	if err != nil {
 		return domain.User{}, err
 	}
@@ -77,7 +73,7 @@ func (ur *userRepository) GetByEmail(ctx context.Context, email string) (domain.
 	defer func() {
 		err := rows.Close()
 		if err != nil {
-			log.Println(err.Error())
+			slog.ErrorContext(ctx, "rows close failed", "error", err)
 		}
 	}()
 
@@ -85,34 +81,14 @@ func (ur *userRepository) GetByEmail(ctx context.Context, email string) (domain.
 		return domain.User{}, domain.ErrRecordNotFound
 	}
 
-	user := domain.User{}
-	err = rows.Scan(
-		&user.ID,
-		&user.Name,
-		&user.Email,
-		&user.Password,
-		&user.Salt,
-		&user.UpdatedAt,
-		&user.CreatedAt,
-	)
-
-	if err != nil {
-		return user, err
-	}
-
-	return user, nil
+	return userMapper.Scan(rows)
 }
 
 func (ur *userRepository) Create(ctx context.Context, user domain.User) (int64, error) {
 	query := `
 		INSERT INTO users(name,email,password,salt) VALUES(?,?,?,?)
 	`
-	result, err := ur.SqlDriver.ExecuteContext(ctx, query, user.Name, user.Email, user.Password, user.Salt)
-	if err != nil {
-		return 0, err
-	}
-
-	createdId, err := result.LastInsertId()
+	createdId, err := ur.SqlDriver.InsertContext(database.WithOperation(ctx, "user.Create"), query, user.Name, user.Email, user.Password, user.Salt)
 	if err != nil {
 		return 0, err
 	}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Hajime3778/go-clean-arch/domain"
@@ -32,11 +33,11 @@ func TestGetByID(t *testing.T) {
 	}
 
 	repo := userRepository.NewUserRepository(sqlDriver)
-	query := "SELECT * FROM users WHERE id = ?"
+	query := "SELECT id, name, email, password, salt, created_at, updated_at FROM users WHERE id = ?"
 
 	t.Run("正常系 存在するIDで1件取得", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "updated_at", "created_at"}).
-			AddRow(mockUser.ID, mockUser.Name, mockUser.Email, mockUser.Password, mockUser.Salt, mockUser.UpdatedAt, mockUser.CreatedAt)
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "created_at", "updated_at"}).
+			AddRow(mockUser.ID, mockUser.Name, mockUser.Email, mockUser.Password, mockUser.Salt, mockUser.CreatedAt, mockUser.UpdatedAt)
 		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockUser.ID).WillReturnRows(rows)
 
 		got, err := repo.GetByID(context.TODO(), mockUser.ID)
@@ -44,8 +45,21 @@ func TestGetByID(t *testing.T) {
 		assert.Equal(t, mockUser, got)
 	})
 
+	t.Run("正常系 作成日時と更新日時が入れ替わらずに取得できること", func(t *testing.T) {
+		createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+		updatedAt := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "created_at", "updated_at"}).
+			AddRow(mockUser.ID, mockUser.Name, mockUser.Email, mockUser.Password, mockUser.Salt, createdAt, updatedAt)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockUser.ID).WillReturnRows(rows)
+
+		got, err := repo.GetByID(context.TODO(), mockUser.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, createdAt, got.CreatedAt)
+		assert.Equal(t, updatedAt, got.UpdatedAt)
+	})
+
 	t.Run("準正常系 存在しないIDで検索してエラーとなること", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "updated_at", "created_at"})
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "created_at", "updated_at"})
 		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(2)).WillReturnRows(rows)
 
 		got, err := repo.GetByID(context.TODO(), int64(2))
@@ -99,11 +113,11 @@ func TestGetByEmail(t *testing.T) {
 	}
 
 	repo := userRepository.NewUserRepository(sqlDriver)
-	query := "SELECT * FROM users WHERE email = ?"
+	query := "SELECT id, name, email, password, salt, created_at, updated_at FROM users WHERE email = ?"
 
 	t.Run("正常系 存在するEmailで1件取得", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "updated_at", "created_at"}).
-			AddRow(mockUser.ID, mockUser.Name, mockUser.Email, mockUser.Password, mockUser.Salt, mockUser.UpdatedAt, mockUser.CreatedAt)
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "created_at", "updated_at"}).
+			AddRow(mockUser.ID, mockUser.Name, mockUser.Email, mockUser.Password, mockUser.Salt, mockUser.CreatedAt, mockUser.UpdatedAt)
 		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockUser.Email).WillReturnRows(rows)
 
 		got, err := repo.GetByEmail(context.TODO(), mockUser.Email)
@@ -112,7 +126,7 @@ func TestGetByEmail(t *testing.T) {
 	})
 
 	t.Run("準正常系 存在しないEmailで検索してエラーとなること", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "updated_at", "created_at"})
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "created_at", "updated_at"})
 		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(mockUser.Email).WillReturnRows(rows)
 
 		got, err := repo.GetByEmail(context.TODO(), mockUser.Email)
@@ -204,14 +218,9 @@ func TestCreate(t *testing.T) {
 
 	t.Run("異常系 追加後IDで失敗した場合エラーが返却されること", func(t *testing.T) {
 		mockErr := errors.New("test error")
-		mockResult := &mockSqlDriver.MockResult{
-			MockLastInsertId: func() (int64, error) {
-				return 0, mockErr
-			},
-		}
 		mockDriver := &mockSqlDriver.MockSqlDriver{
-			MockExecuteContext: func(context.Context, string, ...interface{}) (database.Result, error) {
-				return mockResult, nil
+			MockInsertContext: func(context.Context, string, ...interface{}) (int64, error) {
+				return 0, mockErr
 			},
 		}
 		mockDriver.Conn = db
@@ -236,3 +245,35 @@ func TestCreate(t *testing.T) {
 		assert.Equal(t, int64(0), id)
 	})
 }
+
+func TestPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock error: '%s'", err)
+	}
+	repo := userRepository.NewUserRepository(infrastructure.NewSqlDriver(db, database.Postgres))
+
+	t.Run("正常系 メールアドレスでの取得で$nのプレースホルダーが使用されること", func(t *testing.T) {
+		query := "SELECT id, name, email, password, salt, created_at, updated_at FROM users WHERE email = $1"
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "salt", "created_at", "updated_at"}).
+			AddRow(1, "test user", "test@example.com", "password", "salt", time.Now(), time.Now())
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("test@example.com").WillReturnRows(rows)
+
+		got, err := repo.GetByEmail(context.TODO(), "test@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), got.ID)
+	})
+
+	t.Run("正常系 作成時にRETURNING句でIDが取得されること", func(t *testing.T) {
+		query := "INSERT INTO users(name,email,password,salt) VALUES($1,$2,$3,$4) RETURNING id"
+		mockUser := domain.User{Name: "test user", Email: "test@example.com", Password: "password", Salt: "salt"}
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(mockUser.Name, mockUser.Email, mockUser.Password, mockUser.Salt).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+
+		id, err := repo.Create(context.TODO(), mockUser)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,64 @@
+// Package usertest UserRepositoryの実装が満たすべき振る舞いのテストを提供します
+package usertest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	userRepository "github.com/Hajime3778/go-clean-arch/interface/database/user"
+	"github.com/stretchr/testify/assert"
+)
+
+// Setup テストごとに空のUserRepositoryを返却します
+type Setup func(t *testing.T) userRepository.UserRepository
+
+// RunContract UserRepositoryの実装がデータベースの実装と同じ振る舞いをすることをテストします
+func RunContract(t *testing.T, setup Setup) {
+	ctx := context.TODO()
+
+	t.Run("正常系 作成したユーザーがIDとメールアドレスで取得できること", func(t *testing.T) {
+		repo := setup(t)
+		user := domain.User{Name: "test", Email: "test@example.com", Password: "password", Salt: "salt"}
+		id, err := repo.Create(ctx, user)
+		assert.NoError(t, err)
+
+		got, err := repo.GetByID(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, id, got.ID)
+		assert.Equal(t, user.Name, got.Name)
+		assert.Equal(t, user.Email, got.Email)
+		assert.Equal(t, user.Password, got.Password)
+		assert.Equal(t, user.Salt, got.Salt)
+		assert.False(t, got.CreatedAt.IsZero())
+
+		got, err = repo.GetByEmail(ctx, user.Email)
+		assert.NoError(t, err)
+		assert.Equal(t, id, got.ID)
+	})
+
+	t.Run("準正常系 存在しないIDの場合、ErrRecordNotFoundが返却されること", func(t *testing.T) {
+		repo := setup(t)
+		_, err := repo.GetByID(ctx, 100)
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+	})
+
+	t.Run("準正常系 存在しないメールアドレスの場合、ErrRecordNotFoundが返却されること", func(t *testing.T) {
+		repo := setup(t)
+		_, err := repo.GetByEmail(ctx, "notfound@example.com")
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+	})
+
+	t.Run("異常系 同じメールアドレスで作成した場合、エラーとなること", func(t *testing.T) {
+		repo := setup(t)
+		first, err := repo.Create(ctx, domain.User{Name: "test", Email: "duplicate@example.com"})
+		assert.NoError(t, err)
+		_, err = repo.Create(ctx, domain.User{Name: "other", Email: "duplicate@example.com"})
+		assert.Error(t, err)
+
+		got, err := repo.GetByEmail(ctx, "duplicate@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, first, got.ID)
+		assert.Equal(t, "test", got.Name)
+	})
+}
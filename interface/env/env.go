@@ -1,6 +0,0 @@
-package env
-
-type Env interface {
-	Init()
-	LoadEnvFile(string)
-}
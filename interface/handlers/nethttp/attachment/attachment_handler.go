@@ -0,0 +1,121 @@
+package attachment
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	usecase "github.com/Hajime3778/go-clean-arch/usecase/attachment"
+)
+
+const AttachmentIndexPath string = "/tasks/{id:int}/attachments"
+const AttachmentPath string = "/tasks/{id:int}/attachments/{attachment_id:int}"
+
+// FileFormKey アップロードするファイルのフォーム名
+const FileFormKey string = "file"
+
+// multipartOverhead ファイル以外のmultipartのデータに許容するサイズ
+const multipartOverhead int64 = 1 << 20
+
+// maxMemory multipartのデータをメモリ上に保持する最大サイズ(超えた分は一時ファイルに保存されます)
+const maxMemory int64 = 1 << 20
+
+type attachmentHandler struct {
+	attachmentUsecase usecase.AttachmentUsecase
+}
+
+// NewAttachmentHandler 添付ファイル機能のHandlerオブジェクトを作成します
+func NewAttachmentHandler(u usecase.AttachmentUsecase) *attachmentHandler {
+	return &attachmentHandler{u}
+}
+
+// FindByTaskID タスクの添付ファイルの一覧を取得します
+func (a *attachmentHandler) FindByTaskID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID := httpUtil.PathParamInt64(r, "id")
+	attachments, err := a.attachmentUsecase.FindByTaskID(ctx, taskID)
+	if err != nil {
+		httpUtil.WriteError(w, r, err)
+		return
+	}
+	httpUtil.WriteJSONResponse(w, http.StatusOK, attachments)
+}
+
+// Upload multipart/form-dataで送信されたファイルをタスクに添付します
+func (a *attachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID := httpUtil.PathParamInt64(r, "id")
+	r.Body = http.MaxBytesReader(w, r.Body, usecase.MaxAttachmentSize+multipartOverhead)
+	err := r.ParseMultipartForm(maxMemory)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			httpUtil.WriteError(w, r, domain.ErrFileTooLarge)
+			return
+		}
+		httpUtil.WriteError(w, r, domain.ErrBadRequest.WithMessageKey("request.multipart_required").Wrap(err))
+		return
+	}
+	defer func() {
+		err := r.MultipartForm.RemoveAll()
+		if err != nil {
+			slog.ErrorContext(ctx, "multipart cleanup failed", "error", err)
+		}
+	}()
+
+	file, header, err := r.FormFile(FileFormKey)
+	if err != nil {
+		httpUtil.WriteError(w, r, domain.ErrValidation.WithDetails(domain.NewErrorDetail(FileFormKey, "required", "validation.required")).Wrap(err))
+		return
+	}
+	defer file.Close()
+
+	attachment, err := a.attachmentUsecase.Upload(ctx, taskID, header.Filename, header.Size, file)
+	if err != nil {
+		httpUtil.WriteError(w, r, err)
+		return
+	}
+	httpUtil.WriteJSONResponse(w, http.StatusCreated, attachment)
+}
+
+// Download 添付ファイルをダウンロードします
+func (a *attachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID := httpUtil.PathParamInt64(r, "id")
+	id := httpUtil.PathParamInt64(r, "attachment_id")
+	attachment, body, err := a.attachmentUsecase.Download(ctx, taskID, id)
+	if err != nil {
+		httpUtil.WriteError(w, r, err)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	_, err = io.Copy(w, body)
+	if err != nil {
+		slog.ErrorContext(ctx, "attachment download failed", "error", err)
+	}
+}
+
+// Delete 添付ファイルを1件削除します
+func (a *attachmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID := httpUtil.PathParamInt64(r, "id")
+	id := httpUtil.PathParamInt64(r, "attachment_id")
+	err := a.attachmentUsecase.Delete(ctx, taskID, id)
+	if err != nil {
+		httpUtil.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
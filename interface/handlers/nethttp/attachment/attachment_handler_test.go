@@ -0,0 +1,198 @@
+package attachment_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/attachment"
+	"github.com/Hajime3778/go-clean-arch/usecase/attachment/mock"
+	"github.com/Hajime3778/go-clean-arch/util/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpload(t *testing.T) {
+	t.Run("正常系 ファイルをアップロードし、201が返却されること", func(t *testing.T) {
+		body, contentType := createMultipartBody(t, "screenshot.png", []byte("file content"))
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/1/attachments", body)
+		r.Header.Set("Content-Type", contentType)
+		r.Header.Set("Authorization", generateToken())
+		w := httptest.NewRecorder()
+
+		var gotFileName string
+		var gotContent []byte
+		mockUsecase := &mock.MockAttachmentUsecase{
+			MockUpload: func(ctx context.Context, taskID int64, fileName string, size int64, body io.Reader) (domain.Attachment, error) {
+				gotFileName = fileName
+				gotContent, _ = io.ReadAll(body)
+				return domain.Attachment{ID: 1, TaskID: taskID, FileName: fileName, Size: size}, nil
+			},
+		}
+		handler := attachment.NewAttachmentHandler(mockUsecase)
+		serve(w, r, attachment.AttachmentIndexPath, handler.Upload)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var resAttachment domain.Attachment
+		err := json.NewDecoder(res.Body).Decode(&resAttachment)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		assert.Equal(t, "screenshot.png", gotFileName)
+		assert.Equal(t, "file content", string(gotContent))
+		assert.Equal(t, int64(12), resAttachment.Size)
+	})
+
+	t.Run("準正常系 ファイルが指定されていない場合、400エラーとなること", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		writer := multipart.NewWriter(buf)
+		writer.WriteField("foo", "bar")
+		writer.Close()
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/1/attachments", buf)
+		r.Header.Set("Content-Type", writer.FormDataContentType())
+		r.Header.Set("Authorization", generateToken())
+		w := httptest.NewRecorder()
+		handler := attachment.NewAttachmentHandler(&mock.MockAttachmentUsecase{})
+		serve(w, r, attachment.AttachmentIndexPath, handler.Upload)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("準正常系 Usecaseで形式エラーとなった場合、415エラーとなること", func(t *testing.T) {
+		body, contentType := createMultipartBody(t, "index.html", []byte("<html></html>"))
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/1/attachments", body)
+		r.Header.Set("Content-Type", contentType)
+		r.Header.Set("Authorization", generateToken())
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockAttachmentUsecase{
+			MockUpload: func(ctx context.Context, taskID int64, fileName string, size int64, body io.Reader) (domain.Attachment, error) {
+				return domain.Attachment{}, domain.ErrUnsupportedMediaType
+			},
+		}
+		handler := attachment.NewAttachmentHandler(mockUsecase)
+		serve(w, r, attachment.AttachmentIndexPath, handler.Upload)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, res.StatusCode)
+	})
+
+}
+
+func TestDownload(t *testing.T) {
+	t.Run("正常系 Content-Dispositionが設定され、ファイルがダウンロードできること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/1/attachments/2", nil)
+		r.Header.Set("Authorization", generateToken())
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockAttachmentUsecase{
+			MockDownload: func(ctx context.Context, taskID int64, id int64) (domain.Attachment, io.ReadCloser, error) {
+				return domain.Attachment{ID: id, TaskID: taskID, FileName: "議事録.pdf", ContentType: "application/pdf", Size: 7},
+					io.NopCloser(strings.NewReader("content")), nil
+			},
+		}
+		handler := attachment.NewAttachmentHandler(mockUsecase)
+		serve(w, r, attachment.AttachmentPath, handler.Download)
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		_, params, err := mime.ParseMediaType(res.Header.Get("Content-Disposition"))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/pdf", res.Header.Get("Content-Type"))
+		assert.Equal(t, "議事録.pdf", params["filename"])
+		assert.Equal(t, "content", string(data))
+	})
+
+	t.Run("準正常系 添付ファイルが存在しない場合、404エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/1/attachments/2", nil)
+		r.Header.Set("Authorization", generateToken())
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockAttachmentUsecase{
+			MockDownload: func(ctx context.Context, taskID int64, id int64) (domain.Attachment, io.ReadCloser, error) {
+				return domain.Attachment{}, nil, domain.ErrRecordNotFound
+			},
+		}
+		handler := attachment.NewAttachmentHandler(mockUsecase)
+		serve(w, r, attachment.AttachmentPath, handler.Download)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("準正常系 IDが整数でない場合、404エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/1/attachments/foo", nil)
+		r.Header.Set("Authorization", generateToken())
+		w := httptest.NewRecorder()
+		handler := attachment.NewAttachmentHandler(&mock.MockAttachmentUsecase{})
+		serve(w, r, attachment.AttachmentPath, handler.Download)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("正常系 1件削除", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodDelete, "http://example.com/tasks/1/attachments/2", nil)
+		r.Header.Set("Authorization", generateToken())
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockAttachmentUsecase{
+			MockDelete: func(ctx context.Context, taskID int64, id int64) error {
+				return nil
+			},
+		}
+		handler := attachment.NewAttachmentHandler(mockUsecase)
+		serve(w, r, attachment.AttachmentPath, handler.Delete)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+}
+
+// createMultipartBody ファイルを1件含むmultipart/form-dataのリクエストボディを作成します
+func createMultipartBody(t *testing.T, fileName string, content []byte) (io.Reader, string) {
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+	part, err := writer.CreateFormFile(attachment.FileFormKey, fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	writer.Close()
+	return buf, writer.FormDataContentType()
+}
+
+func generateToken() string {
+	user := domain.User{
+		ID:    1,
+		Name:  "test user",
+		Email: fmt.Sprintf("%d@example.com", time.Now().UnixNano()),
+	}
+	return token.GenerateAccessToken(user, []byte("test_secret_key_0123456789abcdef"))
+}
+
+// serve patternにhandlerを登録したRouterでリクエストを処理します
+func serve(w http.ResponseWriter, r *http.Request, pattern string, handler http.HandlerFunc) {
+	router := httpUtil.NewRouter()
+	router.Handle(r.Method, pattern, handler)
+	router.ServeHTTP(w, r)
+}
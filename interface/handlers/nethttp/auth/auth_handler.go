@@ -1,7 +1,6 @@
 package auth
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
@@ -14,33 +13,28 @@ const SignInPath string = "/auth/sign_in"
 
 type authHandler struct {
 	authUsecase usecase.AuthUsecase
+	decoder     httpUtil.JSONDecoder
 }
 
 // NewAuthHandler 認証機能のHandlerオブジェクトを作成します
-func NewAuthHandler(u usecase.AuthUsecase) *authHandler {
-	return &authHandler{u}
+func NewAuthHandler(u usecase.AuthUsecase, decoder httpUtil.JSONDecoder) *authHandler {
+	return &authHandler{u, decoder}
 }
 
 // SignUpHandler
 func (t *authHandler) SignUpHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusNotFound)
-		return
-	}
 
 	var request SignUpRequest
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&request)
+	err := t.decoder.DecodeJSON(w, r, &request)
 	if err != nil {
-		httpUtil.WriteJSONResponse(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
 
 	var ok bool
 	if ok, err = request.IsSignUpRequestValid(); !ok {
-		httpUtil.WriteJSONResponse(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
 
@@ -52,7 +46,7 @@ func (t *authHandler) SignUpHandler(w http.ResponseWriter, r *http.Request) {
 
 	token, err := t.authUsecase.SignUp(ctx, user)
 	if err != nil {
-		httpUtil.WriteJSONResponse(w, httpUtil.GetStatusCode(err), domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
 
@@ -62,29 +56,23 @@ func (t *authHandler) SignUpHandler(w http.ResponseWriter, r *http.Request) {
 // SignInHandler
 func (t *authHandler) SignInHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusNotFound)
-		return
-	}
 
 	var request SignInRequest
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&request)
+	err := t.decoder.DecodeJSON(w, r, &request)
 	if err != nil {
-		httpUtil.WriteJSONResponse(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
 
 	var ok bool
 	if ok, err = request.IsSignInRequestValid(); !ok {
-		httpUtil.WriteJSONResponse(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
 
 	token, err := t.authUsecase.SignIn(ctx, request.Email, request.Password)
 	if err != nil {
-		httpUtil.WriteJSONResponse(w, httpUtil.GetStatusCode(err), domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
 
@@ -8,31 +8,37 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
 	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/auth"
 	"github.com/Hajime3778/go-clean-arch/usecase/auth/mock"
 	"github.com/stretchr/testify/assert"
 )
 
+// decoder テストで使用するJSONDecoder
+var decoder = httpUtil.NewJSONDecoder(httpUtil.DefaultMaxJSONBodySize)
+
 func TestSignUp(t *testing.T) {
 	t.Run("正常系 新規登録成功", func(t *testing.T) {
 		req := auth.SignUpRequest{
 			Name:     "test name",
 			Email:    "test@example.com",
-			Password: "test password",
+			Password: "test password1",
 		}
 		byteReq, _ := json.Marshal(req)
 		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_up",
 			bytes.NewBuffer(byteReq),
 		)
+		r.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockAuthUsecase{
 			MockSignUp: func(ctx context.Context, task domain.User) (string, error) {
 				return "mock token", nil
 			},
 		}
-		handler := auth.NewAuthHandler(mockUsecase)
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
 		handler.SignUpHandler(w, r)
 		res := w.Result()
 		defer res.Body.Close()
@@ -49,39 +55,43 @@ func TestSignUp(t *testing.T) {
 		assert.Equal(t, "mock token", response.Token)
 	})
 
-	t.Run("異常系 実装していないメソッドでリクエストした場合、404エラーとなること", func(t *testing.T) {
-		r := httptest.NewRequest(http.MethodOptions, "http://example.com/auth/sign_up", nil)
+	t.Run("異常系 実装していないメソッドでリクエストした場合、405エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/auth/sign_up", nil)
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockAuthUsecase{}
-		handler := auth.NewAuthHandler(mockUsecase)
-		handler.SignUpHandler(w, r)
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
+		router := httpUtil.NewRouter()
+		router.Handle(http.MethodPost, auth.SignUpPath, handler.SignUpHandler)
+		router.ServeHTTP(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+		assert.Equal(t, "OPTIONS, POST", res.Header.Get("Allow"))
 	})
 
 	t.Run("準正常系 パラメータが指定されていない場合、400エラーとなること", func(t *testing.T) {
 		req := auth.SignUpRequest{
 			Email:    "test@example.com",
-			Password: "test password",
+			Password: "test password1",
 		}
 		byteReq, _ := json.Marshal(req)
 		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_up",
 			bytes.NewBuffer(byteReq),
 		)
+		r.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockAuthUsecase{
 			MockSignUp: func(ctx context.Context, task domain.User) (string, error) {
 				return "mock token", nil
 			},
 		}
-		handler := auth.NewAuthHandler(mockUsecase)
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
 		handler.SignUpHandler(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&response)
 		if err != nil {
@@ -89,29 +99,30 @@ func TestSignUp(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, response.Message)
+		assert.NotEmpty(t, response.Code)
 	})
 
 	t.Run("準正常系 リクエスト形式が間違っている場合、400エラーとなること", func(t *testing.T) {
-		req := domain.ErrorResponse{
-			Message: "test",
+		req := map[string]string{
+			"message": "test",
 		}
 		byteReq, _ := json.Marshal(req)
 		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_up",
 			bytes.NewBuffer(byteReq),
 		)
+		r.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockAuthUsecase{
 			MockSignUp: func(ctx context.Context, task domain.User) (string, error) {
 				return "mock token", nil
 			},
 		}
-		handler := auth.NewAuthHandler(mockUsecase)
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
 		handler.SignUpHandler(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&response)
 		if err != nil {
@@ -119,19 +130,20 @@ func TestSignUp(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, response.Message)
+		assert.NotEmpty(t, response.Code)
 	})
 
 	t.Run("異常系 Usecase実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
 		req := auth.SignUpRequest{
 			Name:     "test name",
 			Email:    "test@example.com",
-			Password: "test password",
+			Password: "test password1",
 		}
 		byteReq, _ := json.Marshal(req)
 		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_up",
 			bytes.NewBuffer(byteReq),
 		)
+		r.Header.Set("Content-Type", "application/json")
 		mockErr := errors.New("test error")
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockAuthUsecase{
@@ -139,12 +151,12 @@ func TestSignUp(t *testing.T) {
 				return "", mockErr
 			},
 		}
-		handler := auth.NewAuthHandler(mockUsecase)
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
 		handler.SignUpHandler(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&response)
 		if err != nil {
@@ -152,7 +164,7 @@ func TestSignUp(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
-		assert.Equal(t, domain.ErrorResponse{Message: mockErr.Error()}, response)
+		assert.Equal(t, domain.ErrInternalServerError.Code, response.Code)
 	})
 }
 
@@ -160,19 +172,20 @@ func TestSignIn(t *testing.T) {
 	t.Run("正常系 サインイン成功", func(t *testing.T) {
 		req := auth.SignInRequest{
 			Email:    "test@example.com",
-			Password: "test password",
+			Password: "test password1",
 		}
 		byteReq, _ := json.Marshal(req)
 		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in",
 			bytes.NewBuffer(byteReq),
 		)
+		r.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockAuthUsecase{
 			MockSignIn: func(ctx context.Context, email string, password string) (string, error) {
 				return "mock token", nil
 			},
 		}
-		handler := auth.NewAuthHandler(mockUsecase)
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
 		handler.SignInHandler(w, r)
 		res := w.Result()
 		defer res.Body.Close()
@@ -189,38 +202,42 @@ func TestSignIn(t *testing.T) {
 		assert.Equal(t, "mock token", response.Token)
 	})
 
-	t.Run("異常系 実装していないメソッドでリクエストした場合、404エラーとなること", func(t *testing.T) {
-		r := httptest.NewRequest(http.MethodOptions, "http://example.com/auth/sign_in", nil)
+	t.Run("異常系 実装していないメソッドでリクエストした場合、405エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/auth/sign_in", nil)
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockAuthUsecase{}
-		handler := auth.NewAuthHandler(mockUsecase)
-		handler.SignInHandler(w, r)
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
+		router := httpUtil.NewRouter()
+		router.Handle(http.MethodPost, auth.SignInPath, handler.SignInHandler)
+		router.ServeHTTP(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+		assert.Equal(t, "OPTIONS, POST", res.Header.Get("Allow"))
 	})
 
 	t.Run("準正常系 パラメータが指定されていない場合、400エラーとなること", func(t *testing.T) {
 		req := auth.SignInRequest{
-			Password: "test password",
+			Password: "test password1",
 		}
 		byteReq, _ := json.Marshal(req)
 		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in",
 			bytes.NewBuffer(byteReq),
 		)
+		r.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockAuthUsecase{
 			MockSignIn: func(ctx context.Context, email string, password string) (string, error) {
 				return "mock token", nil
 			},
 		}
-		handler := auth.NewAuthHandler(mockUsecase)
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
 		handler.SignInHandler(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&response)
 		if err != nil {
@@ -228,29 +245,30 @@ func TestSignIn(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, response.Message)
+		assert.NotEmpty(t, response.Code)
 	})
 
 	t.Run("準正常系 リクエスト形式が間違っている場合、400エラーとなること", func(t *testing.T) {
-		req := domain.ErrorResponse{
-			Message: "test",
+		req := map[string]string{
+			"message": "test",
 		}
 		byteReq, _ := json.Marshal(req)
 		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in",
 			bytes.NewBuffer(byteReq),
 		)
+		r.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockAuthUsecase{
 			MockSignIn: func(ctx context.Context, email string, password string) (string, error) {
 				return "mock token", nil
 			},
 		}
-		handler := auth.NewAuthHandler(mockUsecase)
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
 		handler.SignInHandler(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&response)
 		if err != nil {
@@ -258,18 +276,19 @@ func TestSignIn(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, response.Message)
+		assert.NotEmpty(t, response.Code)
 	})
 
 	t.Run("異常系 Usecase実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
 		req := auth.SignInRequest{
 			Email:    "test@example.com",
-			Password: "test password",
+			Password: "test password1",
 		}
 		byteReq, _ := json.Marshal(req)
 		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in",
 			bytes.NewBuffer(byteReq),
 		)
+		r.Header.Set("Content-Type", "application/json")
 		mockErr := errors.New("test error")
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockAuthUsecase{
@@ -277,12 +296,12 @@ func TestSignIn(t *testing.T) {
 				return "", mockErr
 			},
 		}
-		handler := auth.NewAuthHandler(mockUsecase)
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
 		handler.SignInHandler(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var response domain.ErrorResponse
+		var response domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&response)
 		if err != nil {
@@ -290,6 +309,53 @@ func TestSignIn(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
-		assert.Equal(t, domain.ErrorResponse{Message: mockErr.Error()}, response)
+		assert.Equal(t, domain.ErrInternalServerError.Code, response.Code)
+	})
+	t.Run("準正常系 Content-TypeがJSONでない場合、415エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in",
+			bytes.NewBufferString(`{"email":"test@example.com","password":"test password"}`),
+		)
+		r.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockAuthUsecase{}
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
+		handler.SignInHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, res.StatusCode)
+	})
+
+	t.Run("準正常系 ロック中の場合、429エラーとRetry-Afterが返却されること", func(t *testing.T) {
+		req := auth.SignInRequest{
+			Email:    "test@example.com",
+			Password: "test password1",
+		}
+		byteReq, _ := json.Marshal(req)
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in",
+			bytes.NewBuffer(byteReq),
+		)
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockAuthUsecase{
+			MockSignIn: func(ctx context.Context, email string, password string) (string, error) {
+				return "", &domain.RateLimitError{RetryAfter: 90 * time.Second}
+			},
+		}
+		handler := auth.NewAuthHandler(mockUsecase, decoder)
+		handler.SignInHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var response domain.ProblemDetails
+		decoder := json.NewDecoder(res.Body)
+		err := decoder.Decode(&response)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+		assert.Equal(t, "90", res.Header.Get("Retry-After"))
+		assert.Equal(t, domain.ErrTooManyRequests.Code, response.Code)
 	})
 }
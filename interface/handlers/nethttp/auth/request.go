@@ -1,23 +1,26 @@
 package auth
 
-import "gopkg.in/go-playground/validator.v9"
+import httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
 
+// SignUpRequest: サインアップ時のリクエスト
+// 文字数の上限はusersテーブルの列の長さに合わせています
 type SignUpRequest struct {
-	Name     string `json:"name" validate:"required"`
-	Email    string `json:"email" validate:"required"`
-	Password string `json:"password" validate:"required"`
+	Name     string `json:"name" validate:"required,max=50"`
+	Email    string `json:"email" validate:"required,max=50,email"`
+	Password string `json:"password" validate:"required,password"`
 }
 
 // IsSignUpRequestValid:
 func (r SignUpRequest) IsSignUpRequestValid() (bool, error) {
-	validate := validator.New()
-	err := validate.Struct(r)
+	err := httpUtil.Validate(r)
 	if err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
+// SignInRequest: サインイン時のリクエスト
+// 登録済みのパスワードを検証するため、パスワードの強度は検証しません
 type SignInRequest struct {
 	Email    string `json:"email" validate:"required"`
 	Password string `json:"password" validate:"required"`
@@ -25,8 +28,7 @@ type SignInRequest struct {
 
 // IsSignUpRequestValid:
 func (r SignInRequest) IsSignInRequestValid() (bool, error) {
-	validate := validator.New()
-	err := validate.Struct(r)
+	err := httpUtil.Validate(r)
 	if err != nil {
 		return false, err
 	}
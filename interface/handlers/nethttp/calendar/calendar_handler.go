@@ -0,0 +1,96 @@
+package calendar
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	usecase "github.com/Hajime3778/go-clean-arch/usecase/calendar"
+)
+
+const CalendarFeedPath string = "/calendar/{feed}"
+const CalendarTokenPath string = "/calendar/token"
+
+// calendarPathPrefix フィードのURLの接頭辞
+const calendarPathPrefix = "/calendar/"
+
+// feedExtension フィードのURLの拡張子
+const feedExtension = ".ics"
+
+type calendarHandler struct {
+	calendarUsecase usecase.CalendarUsecase
+}
+
+// NewCalendarHandler カレンダーフィード機能のHandlerオブジェクトを作成します
+func NewCalendarHandler(u usecase.CalendarUsecase) *calendarHandler {
+	return &calendarHandler{u}
+}
+
+// FeedHandler /calendar/:token.ics でタスクをiCalendar形式で出力します
+// URLのトークンで認証するため、アクセストークンは不要です
+// ?component=vtodo を指定した場合はVTODO、それ以外はVEVENTで出力します
+func (c *calendarHandler) FeedHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	name := httpUtil.PathParam(r, "feed")
+	token := strings.TrimSuffix(name, feedExtension)
+	if !strings.HasSuffix(name, feedExtension) || token == "" {
+		httpUtil.WriteError(w, r, domain.ErrRecordNotFound)
+		return
+	}
+
+	component := ComponentEvent
+	if strings.EqualFold(r.URL.Query().Get("component"), ComponentTodo) {
+		component = ComponentTodo
+	}
+
+	tasks, err := c.calendarUsecase.FindTasksByToken(ctx, token)
+	if err != nil {
+		httpUtil.WriteError(w, r, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = WriteICS(&buf, tasks, component)
+	if err != nil {
+		httpUtil.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="tasks.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(buf.Bytes())
+	if err != nil {
+		slog.ErrorContext(ctx, "calendar feed write failed", "error", err)
+	}
+}
+
+// GetToken /calendar/token でフィードのURLを取得します
+func (c *calendarHandler) GetToken(w http.ResponseWriter, r *http.Request) {
+	feed, err := c.calendarUsecase.GetFeed(r.Context())
+	c.writeFeed(w, r, feed, err)
+}
+
+// RotateToken /calendar/token でフィードのURLを再発行します
+func (c *calendarHandler) RotateToken(w http.ResponseWriter, r *http.Request) {
+	feed, err := c.calendarUsecase.RotateFeed(r.Context())
+	c.writeFeed(w, r, feed, err)
+}
+
+// writeFeed フィードのURLを出力します
+func (c *calendarHandler) writeFeed(w http.ResponseWriter, r *http.Request, feed domain.CalendarFeed, err error) {
+	if err != nil {
+		httpUtil.WriteError(w, r, err)
+		return
+	}
+
+	httpUtil.WriteJSONResponse(w, http.StatusOK, CalendarFeedResponse{
+		Token: feed.Token,
+		Path:  calendarPathPrefix + feed.Token + feedExtension,
+	})
+}
@@ -0,0 +1,153 @@
+package calendar_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/calendar"
+	"github.com/Hajime3778/go-clean-arch/usecase/calendar/mock"
+	"github.com/Hajime3778/go-clean-arch/util/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedHandler(t *testing.T) {
+	t.Run("正常系 トークンのユーザーのタスクがiCalendar形式で出力されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/calendar/abc123.ics", nil)
+		w := httptest.NewRecorder()
+		var gotToken string
+		mockUsecase := &mock.MockCalendarUsecase{
+			MockFindTasksByToken: func(ctx context.Context, token string) ([]domain.Task, error) {
+				gotToken = token
+				return []domain.Task{{ID: 1, Title: "test title", DueDate: time.Now()}}, nil
+			},
+		}
+		handler := calendar.NewCalendarHandler(mockUsecase)
+		serve(w, r, calendar.CalendarFeedPath, handler.FeedHandler)
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "abc123", gotToken)
+		assert.Equal(t, "text/calendar; charset=utf-8", res.Header.Get("Content-Type"))
+		assert.Contains(t, string(data), "BEGIN:VEVENT")
+	})
+
+	t.Run("正常系 componentにvtodoを指定した場合、VTODOで出力されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/calendar/abc123.ics?component=vtodo", nil)
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockCalendarUsecase{
+			MockFindTasksByToken: func(ctx context.Context, token string) ([]domain.Task, error) {
+				return []domain.Task{{ID: 1, Title: "test title", DueDate: time.Now()}}, nil
+			},
+		}
+		handler := calendar.NewCalendarHandler(mockUsecase)
+		serve(w, r, calendar.CalendarFeedPath, handler.FeedHandler)
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		assert.Contains(t, string(data), "BEGIN:VTODO")
+	})
+
+	t.Run("準正常系 拡張子がicsでない場合、404エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/calendar/abc123", nil)
+		w := httptest.NewRecorder()
+		handler := calendar.NewCalendarHandler(&mock.MockCalendarUsecase{})
+		serve(w, r, calendar.CalendarFeedPath, handler.FeedHandler)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("準正常系 存在しないトークンの場合、404エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/calendar/abc123.ics", nil)
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockCalendarUsecase{
+			MockFindTasksByToken: func(ctx context.Context, token string) ([]domain.Task, error) {
+				return nil, domain.ErrRecordNotFound
+			},
+		}
+		handler := calendar.NewCalendarHandler(mockUsecase)
+		serve(w, r, calendar.CalendarFeedPath, handler.FeedHandler)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
+
+func TestTokenHandler(t *testing.T) {
+	t.Run("正常系 GETでフィードのパスが取得できること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/calendar/token", nil)
+		r.Header.Set("Authorization", generateToken())
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockCalendarUsecase{
+			MockGetFeed: func(ctx context.Context) (domain.CalendarFeed, error) {
+				return domain.CalendarFeed{UserID: 1, Token: "abc123"}, nil
+			},
+		}
+		handler := calendar.NewCalendarHandler(mockUsecase)
+		serve(w, r, calendar.CalendarTokenPath, handler.GetToken)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var response calendar.CalendarFeedResponse
+		err := json.NewDecoder(res.Body).Decode(&response)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, calendar.CalendarFeedResponse{Token: "abc123", Path: "/calendar/abc123.ics"}, response)
+	})
+
+	t.Run("正常系 POSTでトークンが再発行されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/calendar/token", nil)
+		r.Header.Set("Authorization", generateToken())
+		w := httptest.NewRecorder()
+		called := false
+		mockUsecase := &mock.MockCalendarUsecase{
+			MockRotateFeed: func(ctx context.Context) (domain.CalendarFeed, error) {
+				called = true
+				return domain.CalendarFeed{UserID: 1, Token: "def456"}, nil
+			},
+		}
+		handler := calendar.NewCalendarHandler(mockUsecase)
+		serve(w, r, calendar.CalendarTokenPath, handler.RotateToken)
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.True(t, called)
+		assert.True(t, strings.Contains(string(data), "def456"))
+	})
+
+}
+
+func generateToken() string {
+	user := domain.User{
+		ID:    1,
+		Name:  "test user",
+		Email: fmt.Sprintf("%d@example.com", time.Now().UnixNano()),
+	}
+	return token.GenerateAccessToken(user, []byte("test_secret_key_0123456789abcdef"))
+}
+
+// serve patternにhandlerを登録したRouterでリクエストを処理します
+func serve(w http.ResponseWriter, r *http.Request, pattern string, handler http.HandlerFunc) {
+	router := httpUtil.NewRouter()
+	router.Handle(r.Method, pattern, handler)
+	router.ServeHTTP(w, r)
+}
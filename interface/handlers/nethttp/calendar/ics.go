@@ -0,0 +1,120 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+const (
+	ComponentEvent = "VEVENT"
+	ComponentTodo  = "VTODO"
+)
+
+// icsTimeFormat RFC 5545 のUTC形式の日時(すべてUTCで出力するため、VTIMEZONEは不要です)
+const icsTimeFormat = "20060102T150405Z"
+
+// maxLineOctets 1行の最大オクテット数(超える場合は折り返します)
+const maxLineOctets = 75
+
+// uidDomain UIDのドメイン部分(タスクIDと組み合わせて、常に同じUIDとなるようにします)
+const uidDomain = "go-clean-arch"
+
+// WriteICS タスクをRFC 5545形式のカレンダーとして出力します
+// 期限が設定されていないタスクは出力しません
+// 完了したタスクはVTODOではSTATUS:COMPLETEDとCOMPLETEDで出力します
+// VEVENTには完了の状態を表すプロパティがないため、完了の有無に関わらず同じ形式で出力します
+func WriteICS(w io.Writer, tasks []domain.Task, component string) error {
+	iw := &icsWriter{w: w}
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:-//go-clean-arch//Tasks//JA")
+	iw.line("CALSCALE:GREGORIAN")
+	iw.line("METHOD:PUBLISH")
+	iw.property("X-WR-CALNAME", "Tasks")
+
+	for _, task := range tasks {
+		if task.DueDate.IsZero() {
+			continue
+		}
+		iw.line("BEGIN:" + component)
+		iw.line(fmt.Sprintf("UID:task-%d@%s", task.ID, uidDomain))
+		iw.line("DTSTAMP:" + formatTime(task.UpdatedAt))
+		iw.line("CREATED:" + formatTime(task.CreatedAt))
+		iw.line("LAST-MODIFIED:" + formatTime(task.UpdatedAt))
+		if component == ComponentTodo {
+			iw.line("DUE:" + formatTime(task.DueDate))
+			if task.CompletedAt != nil {
+				iw.line("STATUS:COMPLETED")
+				iw.line("COMPLETED:" + formatTime(*task.CompletedAt))
+			} else {
+				iw.line("STATUS:NEEDS-ACTION")
+			}
+		} else {
+			// DTENDはDTSTARTより後である必要があるため出力せず、期限の時刻のみの予定とします
+			iw.line("DTSTART:" + formatTime(task.DueDate))
+			iw.line("TRANSP:TRANSPARENT")
+		}
+		iw.property("SUMMARY", task.Title)
+		iw.property("DESCRIPTION", task.Content)
+		iw.line("END:" + component)
+	}
+
+	iw.line("END:VCALENDAR")
+	return iw.err
+}
+
+// icsWriter 行の折り返しとCRLFの改行を行います
+type icsWriter struct {
+	w   io.Writer
+	err error
+}
+
+// property テキストの値をエスケープして出力します
+func (iw *icsWriter) property(name string, value string) {
+	iw.line(name + ":" + escapeText(value))
+}
+
+// line 75オクテットを超える行を、UTF-8の文字の途中で分割しないように折り返して出力します
+func (iw *icsWriter) line(s string) {
+	if iw.err != nil {
+		return
+	}
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// 継続行は先頭の空白を含めて75オクテットとします
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	_, iw.err = io.WriteString(iw.w, b.String())
+}
+
+// escapeText RFC 5545 のTEXT型の値をエスケープします
+func escapeText(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	)
+	return replacer.Replace(s)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(icsTimeFormat)
+}
@@ -0,0 +1,117 @@
+package calendar_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/calendar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteICS(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	task := domain.Task{
+		ID:        12,
+		Title:     "買い出し, 晩御飯; 準備",
+		Content:   "卵\n鶏肉",
+		DueDate:   time.Date(2021, 12, 5, 20, 30, 0, 0, jst),
+		CreatedAt: time.Date(2021, 12, 1, 9, 0, 0, 0, jst),
+		UpdatedAt: time.Date(2021, 12, 2, 9, 0, 0, 0, jst),
+	}
+
+	t.Run("正常系 VEVENTとしてUTCの日時、固定のUIDで出力されること", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := calendar.WriteICS(&buf, []domain.Task{task}, calendar.ComponentEvent)
+		assert.NoError(t, err)
+
+		ics := buf.String()
+		assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+		assert.Contains(t, ics, "BEGIN:VEVENT\r\n")
+		assert.Contains(t, ics, "UID:task-12@go-clean-arch\r\n")
+		assert.Contains(t, ics, "DTSTART:20211205T113000Z\r\n")
+		assert.Contains(t, ics, "DTSTAMP:20211202T000000Z\r\n")
+		assert.Contains(t, ics, `SUMMARY:買い出し\, 晩御飯\; 準備`+"\r\n")
+		assert.Contains(t, ics, `DESCRIPTION:卵\n鶏肉`+"\r\n")
+	})
+
+	t.Run("正常系 VEVENTが期待するカレンダーと完全に一致すること", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := calendar.WriteICS(&buf, []domain.Task{task}, calendar.ComponentEvent)
+		assert.NoError(t, err)
+
+		expected := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//go-clean-arch//Tasks//JA",
+			"CALSCALE:GREGORIAN",
+			"METHOD:PUBLISH",
+			"X-WR-CALNAME:Tasks",
+			"BEGIN:VEVENT",
+			"UID:task-12@go-clean-arch",
+			"DTSTAMP:20211202T000000Z",
+			"CREATED:20211201T000000Z",
+			"LAST-MODIFIED:20211202T000000Z",
+			"DTSTART:20211205T113000Z",
+			"TRANSP:TRANSPARENT",
+			`SUMMARY:買い出し\, 晩御飯\; 準備`,
+			`DESCRIPTION:卵\n鶏肉`,
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n") + "\r\n"
+		assert.Equal(t, expected, buf.String())
+		assert.NotContains(t, buf.String(), "DTEND")
+	})
+
+	t.Run("正常系 VTODOとして期限がDUEで出力されること", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := calendar.WriteICS(&buf, []domain.Task{task}, calendar.ComponentTodo)
+		assert.NoError(t, err)
+
+		ics := buf.String()
+		assert.Contains(t, ics, "BEGIN:VTODO\r\n")
+		assert.Contains(t, ics, "DUE:20211205T113000Z\r\n")
+		assert.Contains(t, ics, "STATUS:NEEDS-ACTION\r\n")
+		assert.NotContains(t, ics, "DTSTART")
+		assert.NotContains(t, ics, "COMPLETED")
+	})
+
+	t.Run("正常系 完了したタスクはVTODOのSTATUS:COMPLETEDと完了日時で出力されること", func(t *testing.T) {
+		completedAt := time.Date(2021, 12, 5, 19, 0, 0, 0, jst)
+		completed := task
+		completed.CompletedAt = &completedAt
+		var buf bytes.Buffer
+		err := calendar.WriteICS(&buf, []domain.Task{completed}, calendar.ComponentTodo)
+		assert.NoError(t, err)
+
+		ics := buf.String()
+		assert.Contains(t, ics, "STATUS:COMPLETED\r\n")
+		assert.Contains(t, ics, "COMPLETED:20211205T100000Z\r\n")
+		assert.NotContains(t, ics, "NEEDS-ACTION")
+	})
+
+	t.Run("正常系 期限が設定されていないタスクは出力されないこと", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := calendar.WriteICS(&buf, []domain.Task{{ID: 1, Title: "no due date"}}, calendar.ComponentEvent)
+		assert.NoError(t, err)
+		assert.NotContains(t, buf.String(), "BEGIN:VEVENT")
+	})
+
+	t.Run("正常系 75オクテットを超える行がUTF-8の文字を分割せずに折り返されること", func(t *testing.T) {
+		long := task
+		long.Content = strings.Repeat("あ", 60)
+		var buf bytes.Buffer
+		err := calendar.WriteICS(&buf, []domain.Task{long}, calendar.ComponentEvent)
+		assert.NoError(t, err)
+
+		for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+			assert.True(t, strings.ToValidUTF8(line, "?") == line)
+		}
+		unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+		assert.Contains(t, unfolded, "DESCRIPTION:"+long.Content+"\r\n")
+	})
+}
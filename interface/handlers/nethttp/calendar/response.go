@@ -0,0 +1,6 @@
+package calendar
+
+type CalendarFeedResponse struct {
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
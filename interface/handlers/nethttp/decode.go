@@ -0,0 +1,121 @@
+package nethttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// DefaultMaxJSONBodySize JSON形式のリクエストボディの最大サイズの既定値
+const DefaultMaxJSONBodySize int64 = 1 << 20
+
+// JSONDecoder JSON形式のリクエストボディを読み込みます
+type JSONDecoder struct {
+	// MaxBodySize 読み込むリクエストボディの最大サイズ
+	MaxBodySize int64
+}
+
+// NewJSONDecoder リクエストボディをmaxBodySizeまで読み込むJSONDecoderを作成します
+func NewJSONDecoder(maxBodySize int64) JSONDecoder {
+	return JSONDecoder{MaxBodySize: maxBodySize}
+}
+
+// DecodeJSON JSON形式のリクエストボディをvに読み込みます
+// Content-Typeがapplication/jsonでない場合は415、MaxBodySizeを超える場合は413、
+// 不正なJSON・未知のフィールド・複数のJSONが含まれる場合は400のAppErrorを返却します
+func (d JSONDecoder) DecodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	if !isJSONContentType(r.Header.Get("Content-Type")) {
+		return domain.ErrUnsupportedMediaType.WithMessageKey("request.json_content_type")
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, d.MaxBodySize)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(v)
+	if err != nil {
+		return decodeError(err)
+	}
+
+	// 2つ目のJSONや余分なデータが続く場合は、不正なリクエストとします
+	err = decoder.Decode(&struct{}{})
+	if err != io.EOF {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return decodeError(err)
+		}
+		return domain.ErrInvalidJSON.WithMessageKey("request.json_single_value")
+	}
+	return nil
+}
+
+// PeekJSON JSON形式のリクエストボディをvに読み込み、後続の処理で再度読み込めるようボディを復元します
+// DecodeJSONと同じ最大サイズまで読み込み、DecodeJSONで受け付けられないボディは同じAppErrorを返却します
+// ミドルウェアでボディの値を参照する場合に使用し、未知のフィールドは後続のDecodeJSONで検証します
+func (d JSONDecoder) PeekJSON(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	if !isJSONContentType(r.Header.Get("Content-Type")) {
+		return domain.ErrUnsupportedMediaType.WithMessageKey("request.json_content_type")
+	}
+
+	body := r.Body
+	data, err := io.ReadAll(http.MaxBytesReader(w, body, d.MaxBodySize))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{bytes.NewReader(data), body}
+	if err != nil {
+		return decodeError(err)
+	}
+
+	err = json.NewDecoder(bytes.NewReader(data)).Decode(v)
+	if err != nil {
+		return decodeError(err)
+	}
+	return nil
+}
+
+// decodeError json.Decoderのエラーをクライアントに返却できるAppErrorに変換します
+func decodeError(err error) *domain.AppError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return domain.ErrFileTooLarge.WithMessageKey("request.body_too_large", maxBytesErr.Limit)
+	case errors.Is(err, io.EOF):
+		return domain.ErrInvalidJSON.WithMessageKey("request.body_empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return domain.ErrInvalidJSON.WithMessageKey("request.json_malformed")
+	case errors.As(err, &syntaxErr):
+		return domain.ErrInvalidJSON.WithMessageKey("request.json_malformed_at", syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		if typeErr.Field != "" {
+			return domain.ErrInvalidJSON.
+				WithMessageKey("request.json_invalid_field", typeErr.Field, typeErr.Offset).
+				WithDetails(domain.NewErrorDetail(typeErr.Field, "type", "validation.type", typeErr.Type.String()))
+		}
+		return domain.ErrInvalidJSON.WithMessageKey("request.json_invalid_value_at", typeErr.Offset)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// DisallowUnknownFieldsのエラーは型が公開されていないため、メッセージで判定します
+		field := strings.TrimPrefix(err.Error(), "json: unknown field ")
+		return domain.ErrInvalidJSON.WithMessageKey("request.json_unknown_field", field)
+	default:
+		return domain.ErrInvalidJSON.Wrap(err)
+	}
+}
+
+// isJSONContentType Content-TypeがJSON形式かどうかを判定します
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
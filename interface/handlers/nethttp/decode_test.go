@@ -0,0 +1,146 @@
+package nethttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/stretchr/testify/assert"
+)
+
+type decodeTestRequest struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	t.Run("正常系 JSONが読み込まれること", func(t *testing.T) {
+		r := newJSONRequest(`{"name":"foo","count":1}`, "application/json; charset=utf-8")
+		w := httptest.NewRecorder()
+
+		var request decodeTestRequest
+		err := nethttp.NewJSONDecoder(nethttp.DefaultMaxJSONBodySize).DecodeJSON(w, r, &request)
+		assert.NoError(t, err)
+		assert.Equal(t, decodeTestRequest{Name: "foo", Count: 1}, request)
+	})
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		status      int
+		message     string
+	}{
+		{
+			name:        "Content-Typeが指定されていない場合、415エラーとなること",
+			body:        `{"name":"foo"}`,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+			message:     "content type must be application/json",
+		},
+		{
+			name:        "Content-TypeがJSONでない場合、415エラーとなること",
+			body:        `{"name":"foo"}`,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+			message:     "content type must be application/json",
+		},
+		{
+			name:        "ボディが空の場合、400エラーとなること",
+			body:        ``,
+			contentType: "application/json",
+			status:      http.StatusBadRequest,
+			message:     "request body must not be empty",
+		},
+		{
+			name:        "JSONの形式が不正な場合、位置を含む400エラーとなること",
+			body:        `{"name":"foo",}`,
+			contentType: "application/json",
+			status:      http.StatusBadRequest,
+			message:     "request body contains badly-formed JSON (at position 15)",
+		},
+		{
+			name:        "JSONが途中で終わっている場合、400エラーとなること",
+			body:        `{"name":"foo"`,
+			contentType: "application/json",
+			status:      http.StatusBadRequest,
+			message:     "request body contains badly-formed JSON",
+		},
+		{
+			name:        "型が異なる場合、フィールド名を含む400エラーとなること",
+			body:        `{"count":"1"}`,
+			contentType: "application/json",
+			status:      http.StatusBadRequest,
+			message:     `request body contains an invalid value for the "count" field (at position 12)`,
+		},
+		{
+			name:        "未知のフィールドが含まれる場合、400エラーとなること",
+			body:        `{"foo":"bar"}`,
+			contentType: "application/json",
+			status:      http.StatusBadRequest,
+			message:     `request body contains unknown field "foo"`,
+		},
+		{
+			name:        "複数のJSONが含まれる場合、400エラーとなること",
+			body:        `{"name":"foo"}{"name":"bar"}`,
+			contentType: "application/json",
+			status:      http.StatusBadRequest,
+			message:     "request body must only contain a single JSON value",
+		},
+		{
+			name:        "最大サイズを超える場合、413エラーとなること",
+			body:        `{"name":"` + strings.Repeat("a", 1<<20) + `"}`,
+			contentType: "application/json",
+			status:      http.StatusRequestEntityTooLarge,
+			message:     "request body must not be larger than 1048576 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run("準正常系 "+tt.name, func(t *testing.T) {
+			r := newJSONRequest(tt.body, tt.contentType)
+			w := httptest.NewRecorder()
+
+			var request decodeTestRequest
+			err := nethttp.NewJSONDecoder(nethttp.DefaultMaxJSONBodySize).DecodeJSON(w, r, &request)
+			assert.Equal(t, tt.status, nethttp.GetStatusCode(err))
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+}
+
+func TestPeekJSON(t *testing.T) {
+	t.Run("正常系 読み込んだボディが復元され、DecodeJSONで再度読み込めること", func(t *testing.T) {
+		r := newJSONRequest(`{"name":"test"}`, "application/json")
+		w := httptest.NewRecorder()
+		decoder := nethttp.NewJSONDecoder(nethttp.DefaultMaxJSONBodySize)
+
+		var peeked map[string]interface{}
+		err := decoder.PeekJSON(w, r, &peeked)
+		assert.NoError(t, err)
+		assert.Equal(t, "test", peeked["name"])
+
+		var request decodeTestRequest
+		err = decoder.DecodeJSON(w, r, &request)
+		assert.NoError(t, err)
+		assert.Equal(t, "test", request.Name)
+	})
+
+	t.Run("準正常系 最大サイズを超える場合、DecodeJSONと同じ413エラーとなること", func(t *testing.T) {
+		r := newJSONRequest(`{"name":"`+strings.Repeat("a", 16)+`"}`, "application/json")
+
+		var peeked map[string]interface{}
+		err := nethttp.NewJSONDecoder(16).PeekJSON(httptest.NewRecorder(), r, &peeked)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, nethttp.GetStatusCode(err))
+		assert.EqualError(t, err, "request body must not be larger than 16 bytes")
+	})
+}
+
+func newJSONRequest(body string, contentType string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
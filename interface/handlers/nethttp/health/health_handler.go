@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+)
+
+const LivenessPath string = "/healthz"
+const ReadinessPath string = "/readyz"
+
+const (
+	StatusOK           = "ok"
+	StatusReady        = "ready"
+	StatusNotReady     = "not_ready"
+	StatusShuttingDown = "shutting_down"
+	StatusUp           = "up"
+	StatusDown         = "down"
+)
+
+// checkTimeout 依存先1件あたりの確認の制限時間
+const checkTimeout = 2 * time.Second
+
+// Dependency 準備完了の確認に使用する依存先
+type Dependency struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type healthHandler struct {
+	dependencies []Dependency
+	shuttingDown atomic.Bool
+}
+
+// NewHealthHandler 死活監視のHandlerオブジェクトを作成します
+func NewHealthHandler(dependencies ...Dependency) *healthHandler {
+	return &healthHandler{dependencies: dependencies}
+}
+
+// Liveness /healthz でプロセスが応答できることを返却します
+func (h *healthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	httpUtil.WriteJSONResponse(w, http.StatusOK, HealthResponse{Status: StatusOK})
+}
+
+// Readiness /readyz で依存先をすべて確認し、リクエストを受け付けられるかどうかを返却します
+// 停止処理中、または1件でも依存先に接続できない場合は503を返却します
+func (h *healthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	if h.shuttingDown.Load() {
+		httpUtil.WriteJSONResponse(w, http.StatusServiceUnavailable, HealthResponse{Status: StatusShuttingDown})
+		return
+	}
+
+	status := StatusReady
+	code := http.StatusOK
+	dependencies := make([]DependencyResponse, 0, len(h.dependencies))
+	for _, dep := range h.dependencies {
+		res := check(r.Context(), dep)
+		if res.Status != StatusUp {
+			status = StatusNotReady
+			code = http.StatusServiceUnavailable
+		}
+		dependencies = append(dependencies, res)
+	}
+	httpUtil.WriteJSONResponse(w, code, HealthResponse{Status: status, Dependencies: dependencies})
+}
+
+// Shutdown 停止処理の開始を通知し、以降の /readyz を503とします
+func (h *healthHandler) Shutdown() {
+	h.shuttingDown.Store(true)
+}
+
+// check 依存先を1件確認し、結果と所要時間を返却します
+// 接続できない場合は、エラーの内容をログにのみ出力します
+func check(ctx context.Context, dep Dependency) DependencyResponse {
+	checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dep.Check(checkCtx)
+	res := DependencyResponse{
+		Name:      dep.Name,
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "readiness check failed", "dependency", dep.Name, "latency_ms", res.LatencyMs, "error", err)
+		res.Status = StatusDown
+	}
+	return res
+}
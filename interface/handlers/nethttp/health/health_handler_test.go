@@ -0,0 +1,106 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveness(t *testing.T) {
+	t.Run("正常系 依存先に関わらず200が返却されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/healthz", nil)
+		w := httptest.NewRecorder()
+		handler := health.NewHealthHandler(health.Dependency{Name: "mysql", Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}})
+		handler.Liveness(w, r)
+
+		response := decodeResponse(t, w)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, health.StatusOK, response.Status)
+	})
+}
+
+func TestReadiness(t *testing.T) {
+	t.Run("正常系 すべての依存先に接続できる場合、200が返却されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/readyz", nil)
+		w := httptest.NewRecorder()
+		handler := health.NewHealthHandler(health.Dependency{Name: "mysql", Check: func(ctx context.Context) error {
+			return nil
+		}})
+		handler.Readiness(w, r)
+
+		response := decodeResponse(t, w)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, health.StatusReady, response.Status)
+		assert.Len(t, response.Dependencies, 1)
+		assert.Equal(t, "mysql", response.Dependencies[0].Name)
+		assert.Equal(t, health.StatusUp, response.Dependencies[0].Status)
+	})
+
+	t.Run("正常系 依存先ごとに確認の所要時間が返却されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/readyz", nil)
+		w := httptest.NewRecorder()
+		handler := health.NewHealthHandler(health.Dependency{Name: "mysql", Check: func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}})
+		handler.Readiness(w, r)
+
+		assert.Contains(t, w.Body.String(), `"latency_ms":`)
+		response := decodeResponse(t, w)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.GreaterOrEqual(t, response.Dependencies[0].LatencyMs, float64(10))
+	})
+
+	t.Run("準正常系 依存先に接続できない場合、エラーの内容を含まずに503が返却されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/readyz", nil)
+		w := httptest.NewRecorder()
+		handler := health.NewHealthHandler(health.Dependency{Name: "mysql", Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}})
+		handler.Readiness(w, r)
+
+		assert.NotContains(t, w.Body.String(), "connection refused")
+		assert.Contains(t, w.Body.String(), `"latency_ms":`)
+		response := decodeResponse(t, w)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, health.StatusNotReady, response.Status)
+		assert.Len(t, response.Dependencies, 1)
+		assert.Equal(t, "mysql", response.Dependencies[0].Name)
+		assert.Equal(t, health.StatusDown, response.Dependencies[0].Status)
+	})
+
+	t.Run("準正常系 停止処理中の場合、依存先を確認せずに503が返却されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/readyz", nil)
+		w := httptest.NewRecorder()
+		called := false
+		handler := health.NewHealthHandler(health.Dependency{Name: "mysql", Check: func(ctx context.Context) error {
+			called = true
+			return nil
+		}})
+		handler.Shutdown()
+		handler.Readiness(w, r)
+
+		response := decodeResponse(t, w)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, health.StatusShuttingDown, response.Status)
+		assert.False(t, called)
+	})
+}
+
+func decodeResponse(t *testing.T, w *httptest.ResponseRecorder) health.HealthResponse {
+	var response health.HealthResponse
+	err := json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response
+}
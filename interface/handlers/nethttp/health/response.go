@@ -0,0 +1,15 @@
+package health
+
+// HealthResponse 死活監視の結果
+type HealthResponse struct {
+	Status       string               `json:"status"`
+	Dependencies []DependencyResponse `json:"dependencies,omitempty"`
+}
+
+// DependencyResponse 依存先ごとの確認結果
+// 接続先のアドレスなどを公開しないよう、エラーの内容はログにのみ出力します
+type DependencyResponse struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+}
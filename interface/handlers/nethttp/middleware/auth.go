@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/util/tracing"
+	"github.com/form3tech-oss/jwt-go/request"
+)
+
+// authRealm WWW-Authenticateヘッダーに設定するrealm
+const authRealm = "go-clean-arch"
+
+// Auth アクセストークンをsecretKeyで検証し、認証済みの利用者をcontextに設定するミドルウェアを作成します
+// 検証に失敗した場合は、WWW-Authenticateヘッダーを付与して401エラーを返却します
+func Auth(secretKey []byte) httpUtil.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, span := tracing.Start(r.Context(), "auth.VerifyAccessToken")
+			token, userID, err := httpUtil.VerifyAccessToken(r, secretKey)
+			tracing.End(span, err)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", authenticateChallenge(err))
+				httpUtil.WriteError(w, r, domain.ErrUnauthorized)
+				return
+			}
+			setAccessLogUserID(r.Context(), userID)
+			ctx := domain.WithPrincipal(r.Context(), domain.Principal{UserID: userID, Token: token})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// authenticateChallenge RFC 6750に従い、WWW-Authenticateヘッダーの値を作成します
+// トークンが送信されていない場合はエラーの詳細を含めません
+func authenticateChallenge(err error) string {
+	if err == request.ErrNoTokenInRequest {
+		return fmt.Sprintf(`Bearer realm="%s"`, authRealm)
+	}
+	return fmt.Sprintf(`Bearer realm="%s", error="invalid_token", error_description="the access token is invalid or expired"`, authRealm)
+}
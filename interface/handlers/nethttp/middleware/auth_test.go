@@ -0,0 +1,70 @@
+package middleware_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/middleware"
+	"github.com/Hajime3778/go-clean-arch/util/token"
+	"github.com/stretchr/testify/assert"
+)
+
+// secretKey テストでアクセストークンの署名に使用する鍵
+var secretKey = []byte("test_secret_key_0123456789abcdef")
+
+func TestAuth(t *testing.T) {
+	t.Run("正常系 認証済みの利用者がcontextに設定されること", func(t *testing.T) {
+		accessToken := token.GenerateAccessToken(domain.User{ID: 3, Name: "test user", Email: fmt.Sprintf("%d@example.com", time.Now().UnixNano())}, secretKey)
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks", nil)
+		r.Header.Set("Authorization", accessToken)
+		w := httptest.NewRecorder()
+
+		var principal domain.Principal
+		var ok bool
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok = domain.PrincipalFromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		})
+		middleware.Auth(secretKey)(next).ServeHTTP(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.True(t, ok)
+		assert.Equal(t, int64(3), principal.UserID)
+		assert.Equal(t, accessToken, principal.Token)
+	})
+
+	t.Run("異常系 トークンが設定されていない場合、401エラーとなり後続の処理が実行されないこと", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks", nil)
+		w := httptest.NewRecorder()
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})
+		middleware.Auth(secretKey)(next).ServeHTTP(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		assert.Equal(t, `Bearer realm="go-clean-arch"`, res.Header.Get("WWW-Authenticate"))
+		assert.False(t, called)
+	})
+
+	t.Run("異常系 トークンが不正な場合、invalid_tokenの401エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks", nil)
+		r.Header.Set("Authorization", "Bearer foo.bar.baz")
+		w := httptest.NewRecorder()
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+		middleware.Auth(secretKey)(next).ServeHTTP(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		assert.Contains(t, res.Header.Get("WWW-Authenticate"), `error="invalid_token"`)
+	})
+}
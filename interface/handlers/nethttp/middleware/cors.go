@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+)
+
+// allOrigins すべてのオリジンを許可する場合の指定
+const allOrigins = "*"
+
+// CORSConfig CORSの設定
+type CORSConfig struct {
+	// AllowedOrigins 許可するオリジンの一覧。"*" を含む場合はすべてのオリジンを許可します
+	AllowedOrigins []string
+	// AllowedMethods プリフライトで許可するメソッドの一覧
+	AllowedMethods []string
+	// AllowedHeaders プリフライトで許可するリクエストヘッダーの一覧
+	AllowedHeaders []string
+	// ExposedHeaders ブラウザのスクリプトから参照できるレスポンスヘッダーの一覧
+	ExposedHeaders []string
+	// AllowCredentials Cookieや認証ヘッダーを含むリクエストを許可するかどうか
+	AllowCredentials bool
+	// MaxAge プリフライトの結果をブラウザがキャッシュする時間。0の場合は出力しません
+	MaxAge time.Duration
+}
+
+// LoadCORSConfig 設定値からCORSの設定を読み込みます
+// CORS_ALLOWED_ORIGINSが設定されていない場合は、どのオリジンも許可しません
+func LoadCORSConfig(lookup envconfig.Lookup) (CORSConfig, error) {
+	p := envconfig.NewParser(lookup)
+	config := CORSConfig{
+		AllowedOrigins:   p.List("CORS_ALLOWED_ORIGINS", nil),
+		AllowedMethods:   p.List("CORS_ALLOWED_METHODS", []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}),
+		AllowedHeaders:   p.List("CORS_ALLOWED_HEADERS", []string{"Authorization", "Content-Type"}),
+		ExposedHeaders:   p.List("CORS_EXPOSED_HEADERS", nil),
+		AllowCredentials: p.Bool("CORS_ALLOW_CREDENTIALS", false),
+		MaxAge:           time.Duration(p.Int("CORS_MAX_AGE", 0)) * time.Second,
+	}
+	return config, p.Err()
+}
+
+// Validate CORSの設定が正しいかを検証します
+// すべてのオリジンの許可と認証情報の許可は、任意のサイトから認証情報付きでAPIを呼び出せるため組み合わせられません
+func (c CORSConfig) Validate() error {
+	if c.allowsAllOrigins() && c.AllowCredentials {
+		return errors.New("CORS_ALLOWED_ORIGINS must not contain '*' when CORS_ALLOW_CREDENTIALS is true")
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("invalid CORS_MAX_AGE: '%d'", int(c.MaxAge.Seconds()))
+	}
+	return nil
+}
+
+// CORS 許可したオリジンからのリクエストにCORSのヘッダーを付与します
+// プリフライトリクエストは後続の処理を実行せずに204を返却します
+// 許可していないオリジンの場合はCORSのヘッダーを付与しないため、ブラウザでレスポンスが破棄されます
+func CORS(config CORSConfig) httpUtil.Middleware {
+	allowedMethods := strings.Join(config.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(config.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(config.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(config.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			w.Header().Add("Vary", "Origin")
+			if preflight {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+			}
+
+			if origin == "" || !config.isAllowedOrigin(origin) {
+				if preflight {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// 認証情報の許可と "*" の組み合わせはValidateで拒否しています
+			if config.allowsAllOrigins() {
+				w.Header().Set("Access-Control-Allow-Origin", allOrigins)
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+			if config.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if !preflight {
+				if exposedHeaders != "" {
+					w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			if allowedHeaders != "" {
+				w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+			}
+			if config.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", maxAge)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// isAllowedOrigin オリジンが許可されているかどうかを判定します
+func (c CORSConfig) isAllowedOrigin(origin string) bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == allOrigins || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsAllOrigins すべてのオリジンを許可しているかどうかを判定します
+func (c CORSConfig) allowsAllOrigins() bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == allOrigins {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/middleware"
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	config := middleware.CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"Content-Disposition"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	t.Run("正常系 許可したオリジンのプリフライトは後続の処理を実行せずに204となること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodOptions, "http://example.com/tasks", nil)
+		r.Header.Set("Origin", "https://app.example.com")
+		r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		w := httptest.NewRecorder()
+
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})
+		middleware.CORS(config)(next).ServeHTTP(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		assert.False(t, called)
+		assert.Equal(t, "https://app.example.com", res.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", res.Header.Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "GET, POST", res.Header.Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Authorization, Content-Type", res.Header.Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", res.Header.Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("正常系 許可したオリジンのリクエストに公開するヘッダーが設定されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks", nil)
+		r.Header.Set("Origin", "https://app.example.com")
+		w := httptest.NewRecorder()
+
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		middleware.CORS(config)(next).ServeHTTP(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "https://app.example.com", res.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "Content-Disposition", res.Header.Get("Access-Control-Expose-Headers"))
+		assert.Empty(t, res.Header.Get("Access-Control-Allow-Methods"))
+		assert.Contains(t, res.Header.Values("Vary"), "Origin")
+	})
+
+	t.Run("準正常系 許可していないオリジンの場合、CORSのヘッダーが設定されないこと", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodOptions, "http://example.com/tasks", nil)
+		r.Header.Set("Origin", "https://evil.example.com")
+		r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		w := httptest.NewRecorder()
+
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+		middleware.CORS(config)(next).ServeHTTP(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		assert.Empty(t, res.Header.Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, res.Header.Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("正常系 すべてのオリジンを許可し認証情報を許可しない場合、*が設定されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks", nil)
+		r.Header.Set("Origin", "https://any.example.com")
+		w := httptest.NewRecorder()
+
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+		middleware.CORS(middleware.CORSConfig{AllowedOrigins: []string{"*"}})(next).ServeHTTP(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, "*", res.Header.Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, res.Header.Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("正常系 設定値が読み込まれること", func(t *testing.T) {
+		config, err := middleware.LoadCORSConfig(envconfig.Map(map[string]string{
+			"CORS_ALLOWED_ORIGINS":   "https://a.example.com, https://b.example.com",
+			"CORS_ALLOW_CREDENTIALS": "true",
+			"CORS_MAX_AGE":           "300",
+		}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, config.AllowedOrigins)
+		assert.True(t, config.AllowCredentials)
+		assert.Equal(t, 5*time.Minute, config.MaxAge)
+		assert.Equal(t, []string{"Authorization", "Content-Type"}, config.AllowedHeaders)
+	})
+
+	t.Run("異常系 形式が不正な場合、エラーとなること", func(t *testing.T) {
+		_, err := middleware.LoadCORSConfig(envconfig.Map(map[string]string{"CORS_ALLOW_CREDENTIALS": "yes please"}))
+
+		assert.EqualError(t, err, "invalid CORS_ALLOW_CREDENTIALS: 'yes please'")
+	})
+
+	t.Run("異常系 すべてのオリジンと認証情報を許可する場合、検証エラーとなること", func(t *testing.T) {
+		config := middleware.CORSConfig{AllowedOrigins: []string{"https://a.example.com", "*"}, AllowCredentials: true}
+
+		assert.EqualError(t, config.Validate(), "CORS_ALLOWED_ORIGINS must not contain '*' when CORS_ALLOW_CREDENTIALS is true")
+	})
+
+	t.Run("正常系 オリジンを指定して認証情報を許可する場合、検証エラーとならないこと", func(t *testing.T) {
+		config := middleware.CORSConfig{AllowedOrigins: []string{"https://a.example.com"}, AllowCredentials: true}
+
+		assert.NoError(t, config.Validate())
+	})
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
+)
+
+// Language Accept-Languageからレスポンスのメッセージの言語を決定し、contextに設定します
+// 対応する言語がない場合はdefaultLanguageとします
+// 言語によってレスポンスが変わるため、Content-LanguageとVaryヘッダーを付与します
+func Language(defaultLanguage i18n.Language) httpUtil.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang := i18n.Negotiate(r.Header.Get("Accept-Language"), defaultLanguage)
+			w.Header().Set("Content-Language", string(lang))
+			w.Header().Add("Vary", "Accept-Language")
+			ctx := i18n.WithLanguage(r.Context(), lang)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/middleware"
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguage(t *testing.T) {
+	t.Run("正常系 Accept-Languageの言語がcontextとContent-Languageに設定されること", func(t *testing.T) {
+		var lang i18n.Language
+		handler := middleware.Language(i18n.Japanese)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang = i18n.FromContext(r.Context())
+		}))
+		r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		r.Header.Set("Accept-Language", "en-US,en;q=0.9,ja;q=0.8")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, i18n.English, lang)
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+		assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+	})
+
+	t.Run("正常系 Accept-Languageが未送信の場合、既定の言語となること", func(t *testing.T) {
+		var lang i18n.Language
+		handler := middleware.Language(i18n.English)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang = i18n.FromContext(r.Context())
+		}))
+		r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, i18n.English, lang)
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+	})
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+)
+
+// Logging リクエストごとにメソッド、パス、ステータスコード、処理時間をログに出力します
+// パスはトークンを含む場合があるため、マッチしたルートのパターンを出力します
+// ステータスコードが5xxの場合はERROR、4xxの場合はWARN、それ以外はINFOで出力します
+func Logging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx, route := httpUtil.WithMatchedRoute(r.Context())
+		entry := &accessLog{}
+		ctx = context.WithValue(ctx, accessLogKey{}, entry)
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		path := *route
+		if path == "" {
+			path = r.URL.Path
+		}
+		level := slog.LevelInfo
+		switch {
+		case rec.status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case rec.status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", path),
+			slog.Int("status", rec.status),
+			slog.Int64("bytes", rec.bytes),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("remote_addr", r.RemoteAddr),
+			slog.String("user_agent", r.UserAgent()),
+		}
+		if entry.userID != 0 {
+			attrs = append(attrs, slog.Int64("user_id", entry.userID))
+		}
+		slog.LogAttrs(ctx, level, "request", attrs...)
+	})
+}
+
+// accessLogKey accessLogをcontextに保持する際のキー
+type accessLogKey struct{}
+
+// accessLog 後続のミドルウェアで判明し、アクセスログに出力する内容
+type accessLog struct {
+	userID int64
+}
+
+// setAccessLogUserID 認証済みの利用者をアクセスログに出力するように設定します
+func setAccessLogUserID(ctx context.Context, userID int64) {
+	if entry, ok := ctx.Value(accessLogKey{}).(*accessLog); ok {
+		entry.userID = userID
+	}
+}
+
+// statusRecorder レスポンスのステータスコードとサイズを記録します
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status = status
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap http.ResponseControllerから元のResponseWriterを参照できるようにします
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/infrastructure/logger"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/middleware"
+	"github.com/Hajime3778/go-clean-arch/util/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogging(t *testing.T) {
+	t.Run("正常系 パスの代わりにルートのパターンとステータスコードが出力されること", func(t *testing.T) {
+		var buf bytes.Buffer
+		setDefaultLogger(t, &buf)
+
+		router := httpUtil.NewRouter(middleware.RequestID, middleware.Logging)
+		router.Handle(http.MethodGet, "/calendar/{feed}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+			w.Write([]byte("body"))
+		})
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/calendar/secret.ics", nil)
+		r.Header.Set(middleware.RequestIDHeader, "req-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		var entry map[string]interface{}
+		err := json.Unmarshal(buf.Bytes(), &entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "request", entry["msg"])
+		assert.Equal(t, "WARN", entry["level"])
+		assert.Equal(t, http.MethodGet, entry["method"])
+		assert.Equal(t, "/calendar/{feed}", entry["path"])
+		assert.Equal(t, float64(http.StatusTeapot), entry["status"])
+		assert.Equal(t, float64(4), entry["bytes"])
+		assert.Equal(t, "req-1", entry["request_id"])
+		assert.NotContains(t, buf.String(), "secret")
+	})
+
+	t.Run("正常系 認証済みの利用者のユーザーIDが出力されること", func(t *testing.T) {
+		var buf bytes.Buffer
+		setDefaultLogger(t, &buf)
+
+		accessToken := token.GenerateAccessToken(domain.User{ID: 7, Name: "test user"}, secretKey)
+		router := httpUtil.NewRouter(middleware.Logging)
+		router.Handle(http.MethodGet, "/tasks", func(w http.ResponseWriter, r *http.Request) {}, middleware.Auth(secretKey))
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks", nil)
+		r.Header.Set("Authorization", accessToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		var entry map[string]interface{}
+		err := json.Unmarshal(buf.Bytes(), &entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "INFO", entry["level"])
+		assert.Equal(t, float64(7), entry["user_id"])
+		assert.NotContains(t, buf.String(), accessToken)
+	})
+}
+
+// setDefaultLogger テストの間、標準のロガーの出力先をbufに変更します
+func setDefaultLogger(t *testing.T, buf *bytes.Buffer) {
+	prev := slog.Default()
+	slog.SetDefault(logger.New(buf, slog.LevelInfo))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/util/metrics"
+)
+
+// unmatchedRoute ルートにマッチしなかったリクエストのラベル
+// パスをそのままラベルにすると系列数が際限なく増えるため、まとめて集計します
+const unmatchedRoute = "unmatched"
+
+// otherMethod 標準のメソッド以外のリクエストのラベル
+// メソッドはクライアントが任意の文字列を指定できるため、ルートと同様にまとめて集計します
+const otherMethod = "other"
+
+// knownMethods ラベルにそのまま使用するメソッド
+var knownMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// Metrics ルートごとのリクエスト数と処理時間を記録します
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx, route := httpUtil.WithMatchedRoute(r.Context())
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		label := *route
+		if label == "" {
+			label = unmatchedRoute
+		}
+		method := r.Method
+		if !knownMethods[method] {
+			method = otherMethod
+		}
+		status := strconv.Itoa(rec.status)
+		metrics.HTTPRequestsTotal.WithLabelValues(label, method, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(label, method, status).Observe(time.Since(start).Seconds())
+	})
+}
@@ -0,0 +1,49 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/middleware"
+	"github.com/Hajime3778/go-clean-arch/util/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	router := httpUtil.NewRouter(middleware.Logging, middleware.Metrics)
+	router.Handle(http.MethodGet, "/tasks/{id:int}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	t.Run("正常系 ルートのパターンとステータスコードごとにリクエスト数が記録されること", func(t *testing.T) {
+		counter := metrics.HTTPRequestsTotal.WithLabelValues("/tasks/{id:int}", http.MethodGet, "204")
+		before := testutil.ToFloat64(counter)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/tasks/1", nil))
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/tasks/2", nil))
+
+		assert.Equal(t, before+2, testutil.ToFloat64(counter))
+	})
+
+	t.Run("正常系 ルートにマッチしない場合、まとめて記録されること", func(t *testing.T) {
+		counter := metrics.HTTPRequestsTotal.WithLabelValues("unmatched", http.MethodGet, "404")
+		before := testutil.ToFloat64(counter)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/unknown/path", nil))
+
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+
+	t.Run("正常系 標準以外のメソッドの場合、まとめて記録されること", func(t *testing.T) {
+		counter := metrics.HTTPRequestsTotal.WithLabelValues("unmatched", "other", "404")
+		before := testutil.ToFloat64(counter)
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("FOO", "http://example.com/unknown/path", nil))
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("BAR", "http://example.com/unknown/path", nil))
+
+		assert.Equal(t, before+2, testutil.ToFloat64(counter))
+	})
+}
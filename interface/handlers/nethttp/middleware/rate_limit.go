@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+)
+
+// KeyFunc リクエストからレート制限のキーを取り出します
+// キーを取り出せないリクエストはエラーを返却し、制限を回避できないよう後続の処理を実行せずにエラーを返却します
+type KeyFunc func(w http.ResponseWriter, r *http.Request) (string, error)
+
+// RateLimit キーごとのトークンバケットでリクエスト数を制限します
+// 制限を超えた場合は後続の処理を実行せずに、Retry-Afterを付与して429を返却します
+// Limiterでエラーが発生した場合は、サービスを止めないようリクエストを許可します
+func RateLimit(limiter ratelimit.Limiter, name string, limit ratelimit.Limit, keyFunc KeyFunc) httpUtil.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, err := keyFunc(w, r)
+			if err != nil {
+				httpUtil.WriteError(w, r, err)
+				return
+			}
+
+			ctx := r.Context()
+			result, err := limiter.Allow(ctx, name+":"+key, limit)
+			if err != nil {
+				slog.ErrorContext(ctx, "rate limit failed", "limit", name, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !result.Allowed {
+				err := &domain.RateLimitError{RetryAfter: result.RetryAfter}
+				httpUtil.WriteError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// KeyByIP 接続元のIPアドレスをキーとします
+// リバースプロキシを経由する場合はプロキシのアドレスとなるため、プロキシ側で送信元を復元してください
+func KeyByIP(w http.ResponseWriter, r *http.Request) (string, error) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr, nil
+	}
+	return host, nil
+}
+
+// KeyByJSONField JSON形式のリクエストボディのフィールドの値をキーとします
+// 値は小文字に変換するため、メールアドレスの大文字・小文字の違いで制限を回避できません
+// ボディはdecoderと同じ最大サイズまで読み込み、後続の処理で再度読み込めるよう復元します
+// decoderで受け付けられないボディはエラーとし、フィールドがない場合は空文字のキーで制限します
+func KeyByJSONField(field string, decoder httpUtil.JSONDecoder) KeyFunc {
+	return func(w http.ResponseWriter, r *http.Request) (string, error) {
+		var body map[string]interface{}
+		err := decoder.PeekJSON(w, r, &body)
+		if err != nil {
+			return "", err
+		}
+		value, _ := body[field].(string)
+		return strings.ToLower(strings.TrimSpace(value)), nil
+	}
+}
@@ -0,0 +1,141 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/middleware"
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	limit := ratelimit.Limit{Burst: 5, Interval: time.Minute}
+
+	t.Run("正常系 許可された場合、名前を付けたキーで後続の処理が実行されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in", nil)
+		r.RemoteAddr = "192.0.2.1:12345"
+		w := httptest.NewRecorder()
+
+		var gotKey string
+		limiter := &mock.MockLimiter{
+			MockAllow: func(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+				gotKey = key
+				return ratelimit.Result{Allowed: true}, nil
+			},
+		}
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})
+		middleware.RateLimit(limiter, "sign_in_ip", limit, middleware.KeyByIP)(next).ServeHTTP(w, r)
+
+		assert.True(t, called)
+		assert.Equal(t, "sign_in_ip:192.0.2.1", gotKey)
+	})
+
+	t.Run("準正常系 制限を超えた場合、後続の処理を実行せずに429とRetry-Afterが返却されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in", nil)
+		w := httptest.NewRecorder()
+
+		limiter := &mock.MockLimiter{
+			MockAllow: func(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+				return ratelimit.Result{Allowed: false, RetryAfter: 2500 * time.Millisecond}, nil
+			},
+		}
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})
+		middleware.RateLimit(limiter, "sign_in_ip", limit, middleware.KeyByIP)(next).ServeHTTP(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+		assert.Equal(t, "3", res.Header.Get("Retry-After"))
+	})
+
+	t.Run("異常系 Limiterでエラーが発生した場合、後続の処理が実行されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in", nil)
+		w := httptest.NewRecorder()
+
+		limiter := &mock.MockLimiter{
+			MockAllow: func(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+				return ratelimit.Result{}, errors.New("test error")
+			},
+		}
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})
+		middleware.RateLimit(limiter, "sign_in_ip", limit, middleware.KeyByIP)(next).ServeHTTP(w, r)
+
+		assert.True(t, called)
+	})
+}
+
+func TestKeyByJSONField(t *testing.T) {
+	keyFunc := middleware.KeyByJSONField("email", httpUtil.NewJSONDecoder(64))
+
+	t.Run("正常系 フィールドの値を小文字にしたキーとなり、ボディが復元されること", func(t *testing.T) {
+		body := `{"email":" Test@Example.com ","password":"pw"}`
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+
+		key, err := keyFunc(httptest.NewRecorder(), r)
+		assert.NoError(t, err)
+		assert.Equal(t, "test@example.com", key)
+
+		restored, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, body, string(restored))
+	})
+
+	t.Run("準正常系 フィールドがない場合、空のキーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in", strings.NewReader(`{"password":"pw"}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		key, err := keyFunc(httptest.NewRecorder(), r)
+		assert.NoError(t, err)
+		assert.Empty(t, key)
+	})
+
+	t.Run("準正常系 JSONでない場合、400エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in", strings.NewReader("foo"))
+		r.Header.Set("Content-Type", "application/json")
+
+		_, err := keyFunc(httptest.NewRecorder(), r)
+		assert.ErrorIs(t, err, domain.ErrInvalidJSON)
+	})
+
+	t.Run("準正常系 最大サイズを超える場合、413エラーとなり後続の処理が実行されないこと", func(t *testing.T) {
+		body := `{"password":"` + strings.Repeat("a", 64) + `","email":"test@example.com"}`
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/auth/sign_in", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		limiter := &mock.MockLimiter{
+			MockAllow: func(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+				return ratelimit.Result{Allowed: true}, nil
+			},
+		}
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})
+		middleware.RateLimit(limiter, "sign_in_email", ratelimit.Limit{Burst: 1, Interval: time.Minute}, keyFunc)(next).ServeHTTP(w, r)
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Result().StatusCode)
+	})
+}
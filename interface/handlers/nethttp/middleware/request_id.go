@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// RequestIDHeader リクエストIDを受け渡すヘッダー
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength 受け付けるリクエストIDの最大長
+const maxRequestIDLength = 128
+
+// RequestID リクエストIDをcontextとレスポンスヘッダーに設定します
+// X-Request-IDが送信された場合はその値を使用し、未送信または不正な場合は新たに生成します
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+		ctx := domain.WithRequestID(r.Context(), requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isValidRequestID ログやヘッダーにそのまま出力できる値かどうかを判定します
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range requestID {
+		isAlnum := (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isAlnum && c != '-' && c != '_' && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID 128bitのランダムな値からリクエストIDを生成します
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,51 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	serve := func(requestID string) (string, *httptest.ResponseRecorder) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks", nil)
+		if requestID != "" {
+			r.Header.Set(middleware.RequestIDHeader, requestID)
+		}
+		w := httptest.NewRecorder()
+		var got string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = domain.RequestIDFromContext(r.Context())
+		})
+		middleware.RequestID(next).ServeHTTP(w, r)
+		return got, w
+	}
+
+	t.Run("正常系 送信されたリクエストIDがcontextとレスポンスに設定されること", func(t *testing.T) {
+		got, w := serve("abc-123")
+
+		assert.Equal(t, "abc-123", got)
+		assert.Equal(t, "abc-123", w.Header().Get(middleware.RequestIDHeader))
+	})
+
+	t.Run("正常系 リクエストIDが送信されていない場合、生成されること", func(t *testing.T) {
+		got, w := serve("")
+
+		assert.Len(t, got, 32)
+		assert.Equal(t, got, w.Header().Get(middleware.RequestIDHeader))
+	})
+
+	t.Run("準正常系 不正なリクエストIDの場合、生成した値に置き換えられること", func(t *testing.T) {
+		for _, invalid := range []string{"foo bar", "a\"b", strings.Repeat("a", 129)} {
+			got, _ := serve(invalid)
+
+			assert.NotEqual(t, invalid, got)
+			assert.Len(t, got, 32)
+		}
+	})
+}
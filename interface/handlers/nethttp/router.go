@@ -0,0 +1,255 @@
+package nethttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// Middleware http.Handlerを包んで前後の処理を追加します
+type Middleware func(http.Handler) http.Handler
+
+// Router パスパターンとHTTPメソッドでHandlerを振り分けます
+//
+// パターンは "/" 区切りのセグメントで、以下を使用できます
+//   - 固定の文字列: /tasks
+//   - 文字列のパラメータ: /calendar/{feed}
+//   - 整数のパラメータ: /tasks/{id:int}
+//
+// セグメントの数が一致しないパスにはマッチしません
+// 複数のパターンにマッチする場合は、先頭から見て固定の文字列が多いパターンを優先します
+// パターンにマッチし、メソッドが登録されていない場合はAllowヘッダーを付与して405を返却します
+// HEADはHEADが登録されていない場合、GETのHandlerで処理します
+// OPTIONSは登録されているメソッドをAllowヘッダーに設定して204を返却します
+type Router struct {
+	// handler 全体のミドルウェアを適用した振り分けのHandler
+	handler http.Handler
+	routes  []*route
+}
+
+type route struct {
+	pattern  string
+	segments []segment
+	handlers map[string]http.Handler
+}
+
+type segment struct {
+	value   string
+	isParam bool
+	isInt   bool
+}
+
+// pathParamsKey パスパラメータをcontextに保持する際のキー
+type pathParamsKey struct{}
+
+// matchedRouteKey マッチしたパターンの格納先をcontextに保持する際のキー
+type matchedRouteKey struct{}
+
+// NewRouter Routerを作成します。middlewaresは404, 405, OPTIONSを含むすべてのリクエストに適用されます
+// ミドルウェアはリクエストごとではなく、作成時に一度だけ適用します
+func NewRouter(middlewares ...Middleware) *Router {
+	rt := &Router{}
+	rt.handler = chain(http.HandlerFunc(rt.dispatch), middlewares)
+	return rt
+}
+
+// Handle methodとpatternに対するHandlerを登録します
+// middlewaresはこのルートにのみ、先頭が外側となるように適用されます
+func (rt *Router) Handle(method string, pattern string, handler http.HandlerFunc, middlewares ...Middleware) {
+	h := chain(handler, middlewares)
+
+	rte := rt.find(pattern)
+	if rte == nil {
+		rte = &route{pattern: pattern, segments: parsePattern(pattern), handlers: make(map[string]http.Handler)}
+		rt.routes = append(rt.routes, rte)
+	}
+	if _, ok := rte.handlers[method]; ok {
+		panic(fmt.Sprintf("router: %s %s is already registered", method, pattern))
+	}
+	rte.handlers[method] = h
+}
+
+// ServeHTTP リクエストを登録されたHandlerに振り分けます
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt.handler.ServeHTTP(w, r)
+}
+
+// chain middlewaresを先頭が外側となるようにhに適用します
+func chain(h http.Handler, middlewares []Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+func (rt *Router) dispatch(w http.ResponseWriter, r *http.Request) {
+	rte, params := rt.match(r.URL.Path)
+	if rte == nil {
+		WriteError(w, r, domain.ErrRecordNotFound.WithMessageKey("request.route_not_found"))
+		return
+	}
+	if matched, ok := r.Context().Value(matchedRouteKey{}).(*string); ok {
+		*matched = rte.pattern
+	}
+
+	handler, ok := rte.handler(r.Method)
+	if !ok {
+		w.Header().Set("Allow", rte.allow())
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		WriteError(w, r, domain.ErrMethodNotAllowed)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), pathParamsKey{}, params)
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// find 登録済みのパターンと一致するルートを取得します
+func (rt *Router) find(pattern string) *route {
+	for _, rte := range rt.routes {
+		if rte.pattern == pattern {
+			return rte
+		}
+	}
+	return nil
+}
+
+// match パスにマッチするルートのうち、最も優先度の高いルートとパスパラメータを返却します
+func (rt *Router) match(path string) (*route, map[string]string) {
+	parts := splitPath(path)
+	var matched *route
+	var matchedParams map[string]string
+	for _, rte := range rt.routes {
+		params, ok := rte.match(parts)
+		if !ok {
+			continue
+		}
+		if matched == nil || rte.moreSpecificThan(matched) {
+			matched = rte
+			matchedParams = params
+		}
+	}
+	return matched, matchedParams
+}
+
+func (rte *route) match(parts []string) (map[string]string, bool) {
+	if len(parts) != len(rte.segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, seg := range rte.segments {
+		part := parts[i]
+		if !seg.isParam {
+			if part != seg.value {
+				return nil, false
+			}
+			continue
+		}
+		if part == "" {
+			return nil, false
+		}
+		if seg.isInt {
+			_, err := strconv.ParseInt(part, 10, 64)
+			if err != nil {
+				return nil, false
+			}
+		}
+		params[seg.value] = part
+	}
+	return params, true
+}
+
+// moreSpecificThan 先頭のセグメントから比較し、固定の文字列が先に現れる方を優先します
+func (rte *route) moreSpecificThan(other *route) bool {
+	for i := range rte.segments {
+		if rte.segments[i].isParam != other.segments[i].isParam {
+			return !rte.segments[i].isParam
+		}
+	}
+	return false
+}
+
+// handler methodのHandlerを取得します
+// HEADが登録されていない場合はGETのHandlerを返却し、レスポンスのボディはnet/httpが破棄します
+func (rte *route) handler(method string) (http.Handler, bool) {
+	h, ok := rte.handlers[method]
+	if !ok && method == http.MethodHead {
+		h, ok = rte.handlers[http.MethodGet]
+	}
+	return h, ok
+}
+
+// allow Allowヘッダーに設定するメソッドの一覧を返却します
+func (rte *route) allow() string {
+	methods := []string{http.MethodOptions}
+	for method := range rte.handlers {
+		if method != http.MethodOptions {
+			methods = append(methods, method)
+		}
+	}
+	_, hasHead := rte.handlers[http.MethodHead]
+	if _, hasGet := rte.handlers[http.MethodGet]; hasGet && !hasHead {
+		methods = append(methods, http.MethodHead)
+	}
+	sort.Strings(methods)
+	return strings.Join(methods, ", ")
+}
+
+// parsePattern パターンをセグメントに分割します
+func parsePattern(pattern string) []segment {
+	parts := splitPath(pattern)
+	segments := make([]segment, 0, len(parts))
+	for _, part := range parts {
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			segments = append(segments, segment{value: part})
+			continue
+		}
+		name, typ, _ := strings.Cut(part[1:len(part)-1], ":")
+		switch typ {
+		case "":
+			segments = append(segments, segment{value: name, isParam: true})
+		case "int":
+			segments = append(segments, segment{value: name, isParam: true, isInt: true})
+		default:
+			panic(fmt.Sprintf("router: unknown parameter type %q in %s", typ, pattern))
+		}
+	}
+	return segments
+}
+
+// splitPath 先頭と末尾の "/" を除いてパスを分割します
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// WithMatchedRoute Routerがマッチしたパターンを書き込む格納先をcontextに設定します
+// Routerの外側のミドルウェアから、トークンなどを含む実際のパスの代わりにパターンを参照するために使用します
+// すでに設定されている場合は、同じ格納先を返却します
+func WithMatchedRoute(ctx context.Context) (context.Context, *string) {
+	if matched, ok := ctx.Value(matchedRouteKey{}).(*string); ok {
+		return ctx, matched
+	}
+	matched := new(string)
+	return context.WithValue(ctx, matchedRouteKey{}, matched), matched
+}
+
+// PathParam パスパラメータを取得します。存在しない場合は空文字を返却します
+func PathParam(r *http.Request, name string) string {
+	params, _ := r.Context().Value(pathParamsKey{}).(map[string]string)
+	return params[name]
+}
+
+// PathParamInt64 {name:int} で定義したパスパラメータを整数で取得します
+// ルーターで整数であることを検証済みのため、存在しない場合のみ0を返却します
+func PathParamInt64(r *http.Request, name string) int64 {
+	value, _ := strconv.ParseInt(PathParam(r, name), 10, 64)
+	return value
+}
@@ -0,0 +1,186 @@
+package nethttp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter(t *testing.T) {
+	newRouter := func(middlewares ...nethttp.Middleware) *nethttp.Router {
+		router := nethttp.NewRouter(middlewares...)
+		router.Handle(http.MethodGet, "/tasks/{id:int}", func(w http.ResponseWriter, r *http.Request) {
+			nethttp.WriteJSONResponse(w, http.StatusOK, map[string]int64{"id": nethttp.PathParamInt64(r, "id")})
+		})
+		router.Handle(http.MethodPut, "/tasks/{id:int}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		router.Handle(http.MethodGet, "/tasks/stats", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("stats"))
+		})
+		router.Handle(http.MethodGet, "/calendar/{feed}", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(nethttp.PathParam(r, "feed")))
+		})
+		return router
+	}
+
+	t.Run("正常系 整数のパスパラメータが取得できること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/5", nil)
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var body map[string]int64
+		err := json.NewDecoder(res.Body).Decode(&body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, int64(5), body["id"])
+	})
+
+	t.Run("正常系 文字列のパスパラメータが取得できること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/calendar/abc.ics", nil)
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "abc.ics", w.Body.String())
+	})
+
+	t.Run("正常系 固定の文字列のパターンがパラメータより優先されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/stats", nil)
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "stats", w.Body.String())
+	})
+
+	t.Run("準正常系 整数のパラメータに整数以外を指定した場合、404エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/hoge", nil)
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var resError domain.ProblemDetails
+		err := json.NewDecoder(res.Body).Decode(&resError)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.NotEmpty(t, resError.Code)
+	})
+
+	t.Run("準正常系 セグメントが多い場合、404エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/1/extra", nil)
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("準正常系 登録されていないメソッドの場合、Allowヘッダーが設定され405エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodDelete, "http://example.com/tasks/1", nil)
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, "GET, HEAD, OPTIONS, PUT", w.Header().Get("Allow"))
+	})
+
+	t.Run("正常系 OPTIONSの場合、Allowヘッダーが設定され204が返却されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodOptions, "http://example.com/tasks/1", nil)
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "GET, HEAD, OPTIONS, PUT", w.Header().Get("Allow"))
+	})
+
+	t.Run("正常系 HEADの場合、GETのHandlerで処理されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodHead, "http://example.com/tasks/1", nil)
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, r)
+
+		// ボディはnet/httpのサーバーが破棄するため、ここではGETのHandlerが呼ばれたことを確認します
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id":1}`, w.Body.String())
+	})
+
+	t.Run("準正常系 GETが登録されていないルートにHEADでリクエストした場合、405エラーとなること", func(t *testing.T) {
+		router := nethttp.NewRouter()
+		router.Handle(http.MethodPost, "/tasks", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "http://example.com/tasks", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, "OPTIONS, POST", w.Header().Get("Allow"))
+	})
+
+	t.Run("正常系 全体のミドルウェアがリクエストごとではなく作成時に一度だけ適用されること", func(t *testing.T) {
+		applied := 0
+		global := func(next http.Handler) http.Handler {
+			applied++
+			return next
+		}
+		router := newRouter(global)
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/tasks/1", nil))
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://example.com/tasks/2", nil))
+
+		assert.Equal(t, 1, applied)
+	})
+
+	t.Run("正常系 全体のミドルウェアが404を含むすべてのリクエストに適用されること", func(t *testing.T) {
+		global := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Global", "1")
+				next.ServeHTTP(w, r)
+			})
+		}
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/unknown", nil)
+		w := httptest.NewRecorder()
+		newRouter(global).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "1", w.Header().Get("X-Global"))
+	})
+
+	t.Run("正常系 ルートのミドルウェアが登録したルートにのみ適用されること", func(t *testing.T) {
+		deny := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			})
+		}
+		router := newRouter()
+		router.Handle(http.MethodDelete, "/tasks/{id:int}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}, deny)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://example.com/tasks/1", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "http://example.com/tasks/1", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("異常系 同じメソッドとパターンを重複して登録した場合、panicとなること", func(t *testing.T) {
+		router := newRouter()
+		assert.Panics(t, func() {
+			router.Handle(http.MethodGet, "/tasks/{id:int}", func(w http.ResponseWriter, r *http.Request) {})
+		})
+	})
+}
@@ -3,26 +3,27 @@ package task
 import (
 	"time"
 
-	validator "gopkg.in/go-playground/validator.v9"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
 )
 
-// CreateTaskRequest: タスク追加時のリクエスト
-type FindByUserIDTaskRequest struct {
-	Limit  int64 `json:"limit" validate:"required"`
-	Offset int64 `json:"offset" validate:"required"`
+// FindByUserIDQuery: タスク一覧取得時のクエリパラメーター
+// 一度に取得する件数は、データベースへの負荷を抑えるため100件までとします
+type FindByUserIDQuery struct {
+	Limit  int64 `json:"limit" validate:"min=1,max=100"`
+	Offset int64 `json:"offset" validate:"min=0"`
 }
 
 // CreateTaskRequest: タスク追加時のリクエスト
+// 文字数の上限はtasksテーブルの列の長さに合わせています
 type CreateTaskRequest struct {
-	Title   string    `json:"title" validate:"required"`
-	Content string    `json:"content" validate:"required"`
-	DueDate time.Time `json:"due_date" validate:"required"`
+	Title   string    `json:"title" validate:"required,max=50"`
+	Content string    `json:"content" validate:"required,max=200"`
+	DueDate time.Time `json:"due_date" validate:"required,due_date"`
 }
 
 // IsCreateRequestValid:
 func (r CreateTaskRequest) IsCreateRequestValid() (bool, error) {
-	validate := validator.New()
-	err := validate.Struct(r)
+	err := httpUtil.Validate(r)
 	if err != nil {
 		return false, err
 	}
@@ -30,15 +31,16 @@ func (r CreateTaskRequest) IsCreateRequestValid() (bool, error) {
 }
 
 // UpdateTaskRequest: タスク更新時のリクエスト
+// Completedを省略した場合、完了状態は変更されません
 type UpdateTaskRequest struct {
-	Title   string    `json:"title" validate:"required"`
-	Content string    `json:"content" validate:"required"`
-	DueDate time.Time `json:"due_date" validate:"required"`
+	Title     string    `json:"title" validate:"required,max=50"`
+	Content   string    `json:"content" validate:"required,max=200"`
+	DueDate   time.Time `json:"due_date" validate:"required,due_date"`
+	Completed *bool     `json:"completed"`
 }
 
 func (r UpdateTaskRequest) IsUpdateRequestValid() (bool, error) {
-	validate := validator.New()
-	err := validate.Struct(r)
+	err := httpUtil.Validate(r)
 	if err != nil {
 		return false, err
 	}
@@ -1,5 +1,38 @@
 package task
 
+import (
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
+)
+
 type ResponseError struct {
 	Message string `json:"message"`
 }
+
+// ImportTaskResponse タスクのインポート結果
+type ImportTaskResponse struct {
+	DryRun bool             `json:"dry_run"`
+	Count  int              `json:"count"`
+	Errors []ImportRowError `json:"errors"`
+}
+
+// ImportRowError インポートしたファイルの行単位のエラー
+// Rowはヘッダーを除いた1始まりの行番号です
+type ImportRowError struct {
+	Row     int                  `json:"row"`
+	Message string               `json:"message"`
+	Errors  []domain.ErrorDetail `json:"errors,omitempty"`
+	// appErr 検証エラーの場合に、メッセージを翻訳するための元のエラー
+	appErr *domain.AppError
+}
+
+// localize 検証エラーのメッセージをlangに翻訳します
+// ファイルの形式が不正な場合など、検証エラー以外のメッセージはそのまま返却します
+func (e ImportRowError) localize(lang i18n.Language) ImportRowError {
+	if e.appErr == nil {
+		return e
+	}
+	e.Message = e.appErr.LocalizedMessage(lang)
+	e.Errors = e.appErr.LocalizedDetails(lang)
+	return e
+}
@@ -11,13 +11,17 @@ import (
 	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
 	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/task"
 	"github.com/Hajime3778/go-clean-arch/usecase/task/mock"
 	"github.com/stretchr/testify/assert"
 )
 
+// decoder テストで使用するJSONDecoder
+var decoder = httpUtil.NewJSONDecoder(httpUtil.DefaultMaxJSONBodySize)
+
 func TestTaskHandlerTest(t *testing.T) {
-	t.Run("異常系 パラメータが読み取れない場合 400エラーとなること", func(t *testing.T) {
+	t.Run("準正常系 IDが整数でない場合、404エラーとなること", func(t *testing.T) {
 		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/hogehoge", nil)
 		w := httptest.NewRecorder()
 		mockErr := errors.New("test error")
@@ -26,32 +30,37 @@ func TestTaskHandlerTest(t *testing.T) {
 				return domain.Task{}, mockErr
 			},
 		}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		serve(w, r, task.TaskPath, handler.GetByID)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.NotEmpty(t, resError.Code)
 	})
 
-	t.Run("異常系 実装していないメソッドでリクエストした場合、404エラーとなること", func(t *testing.T) {
-		r := httptest.NewRequest(http.MethodOptions, "http://example.com/tasks/5", nil)
+	t.Run("異常系 実装していないメソッドでリクエストした場合、405エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPatch, "http://example.com/tasks/5", nil)
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockTaskUsecase{}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		router := httpUtil.NewRouter()
+		router.Handle(http.MethodGet, task.TaskPath, handler.GetByID)
+		router.Handle(http.MethodPut, task.TaskPath, handler.Update)
+		router.Handle(http.MethodDelete, task.TaskPath, handler.Delete)
+		router.ServeHTTP(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+		assert.Equal(t, "DELETE, GET, HEAD, OPTIONS, PUT", res.Header.Get("Allow"))
 	})
 }
 
@@ -77,8 +86,8 @@ func TestGetByID(t *testing.T) {
 				return mockTask, nil
 			},
 		}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		serve(w, r, task.TaskPath, handler.GetByID)
 		res := w.Result()
 		defer res.Body.Close()
 
@@ -113,12 +122,12 @@ func TestGetByID(t *testing.T) {
 				return domain.Task{}, domain.ErrRecordNotFound
 			},
 		}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		serve(w, r, task.TaskPath, handler.GetByID)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -126,7 +135,7 @@ func TestGetByID(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusNotFound, res.StatusCode)
-		assert.Equal(t, domain.ErrorResponse{Message: domain.ErrRecordNotFound.Error()}, resError)
+		assert.Equal(t, domain.ErrRecordNotFound.Code, resError.Code)
 	})
 
 	t.Run("異常系 Usecase実行時に想定外のエラーが発生した場合、500エラーとなること", func(t *testing.T) {
@@ -142,12 +151,12 @@ func TestGetByID(t *testing.T) {
 				return domain.Task{}, mockErr
 			},
 		}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		serve(w, r, task.TaskPath, handler.GetByID)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -155,7 +164,7 @@ func TestGetByID(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
-		assert.Equal(t, domain.ErrorResponse{Message: mockErr.Error()}, resError)
+		assert.Equal(t, domain.ErrInternalServerError.Code, resError.Code)
 	})
 }
 
@@ -176,12 +185,12 @@ func TestUpdate(t *testing.T) {
 		r.Header.Set("Authorization", token)
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockTaskUsecase{
-			MockUpdate: func(ctx context.Context, task domain.Task) error {
+			MockUpdate: func(ctx context.Context, task domain.Task, completed *bool) error {
 				return nil
 			},
 		}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		serve(w, r, task.TaskPath, handler.Update)
 		res := w.Result()
 		defer res.Body.Close()
 
@@ -203,16 +212,16 @@ func TestUpdate(t *testing.T) {
 		r.Header.Set("Authorization", token)
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockTaskUsecase{
-			MockUpdate: func(ctx context.Context, task domain.Task) error {
+			MockUpdate: func(ctx context.Context, task domain.Task, completed *bool) error {
 				return nil
 			},
 		}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		serve(w, r, task.TaskPath, handler.Update)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -220,14 +229,14 @@ func TestUpdate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 リクエスト形式が間違っている場合、400エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		token := generateToken(ctx)
-		req := domain.ErrorResponse{
-			Message: "test",
+		req := map[string]string{
+			"message": "test",
 		}
 		byteTask, _ := json.Marshal(req)
 		r := httptest.NewRequest(http.MethodPut, "http://example.com/tasks/5",
@@ -237,16 +246,16 @@ func TestUpdate(t *testing.T) {
 		r.Header.Set("Authorization", token)
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockTaskUsecase{
-			MockUpdate: func(ctx context.Context, task domain.Task) error {
+			MockUpdate: func(ctx context.Context, task domain.Task, completed *bool) error {
 				return nil
 			},
 		}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		serve(w, r, task.TaskPath, handler.Update)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -254,7 +263,7 @@ func TestUpdate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("異常系 Usecase実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
@@ -274,16 +283,16 @@ func TestUpdate(t *testing.T) {
 		mockErr := errors.New("test error")
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockTaskUsecase{
-			MockUpdate: func(ctx context.Context, task domain.Task) error {
+			MockUpdate: func(ctx context.Context, task domain.Task, completed *bool) error {
 				return mockErr
 			},
 		}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		serve(w, r, task.TaskPath, handler.Update)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -291,7 +300,7 @@ func TestUpdate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
-		assert.Equal(t, domain.ErrorResponse{Message: mockErr.Error()}, resError)
+		assert.Equal(t, domain.ErrInternalServerError.Code, resError.Code)
 	})
 }
 
@@ -308,8 +317,8 @@ func TestDelete(t *testing.T) {
 				return nil
 			},
 		}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		serve(w, r, task.TaskPath, handler.Delete)
 		res := w.Result()
 		defer res.Body.Close()
 
@@ -329,12 +338,12 @@ func TestDelete(t *testing.T) {
 				return mockErr
 			},
 		}
-		handler := task.NewTaskHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskHandler(mockUsecase, decoder)
+		serve(w, r, task.TaskPath, handler.Delete)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -342,6 +351,13 @@ func TestDelete(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
-		assert.Equal(t, domain.ErrorResponse{Message: mockErr.Error()}, resError)
+		assert.Equal(t, domain.ErrInternalServerError.Code, resError.Code)
 	})
 }
+
+// serve patternにhandlerを登録したRouterでリクエストを処理します
+func serve(w http.ResponseWriter, r *http.Request, pattern string, handler http.HandlerFunc) {
+	router := httpUtil.NewRouter()
+	router.Handle(r.Method, pattern, handler)
+	router.ServeHTTP(w, r)
+}
@@ -0,0 +1,31 @@
+package task
+
+import (
+	"net/http"
+
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	usecase "github.com/Hajime3778/go-clean-arch/usecase/task"
+)
+
+const TaskHistoryPath string = "/tasks/{id:int}/history"
+
+type taskHistoryHandler struct {
+	taskUsecase usecase.TaskUsecase
+}
+
+// NewTaskHistoryHandler タスク変更履歴のHandlerオブジェクトを作成します
+func NewTaskHistoryHandler(u usecase.TaskUsecase) *taskHistoryHandler {
+	return &taskHistoryHandler{u}
+}
+
+// History タスクの変更履歴を取得します
+func (t *taskHistoryHandler) History(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := httpUtil.PathParamInt64(r, "id")
+	activities, err := t.taskUsecase.History(ctx, id)
+	if err != nil {
+		httpUtil.WriteError(w, r, err)
+		return
+	}
+	httpUtil.WriteJSONResponse(w, http.StatusOK, activities)
+}
@@ -0,0 +1,73 @@
+package task_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/task"
+	"github.com/Hajime3778/go-clean-arch/usecase/task/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	t.Run("正常系 変更履歴を取得できること", func(t *testing.T) {
+		ctx := context.TODO()
+		token := generateToken(ctx)
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/5/history", nil)
+		r.Header.Set("Authorization", token)
+		w := httptest.NewRecorder()
+		after := "test title"
+		mockActivities := []domain.TaskActivity{{
+			ID:      1,
+			TaskID:  5,
+			ActorID: 1,
+			Action:  domain.ActivityCreate,
+			Changes: []domain.FieldChange{{Field: "title", After: &after}},
+		}}
+		var gotID int64
+		mockUsecase := &mock.MockTaskUsecase{
+			MockHistory: func(ctx context.Context, id int64) ([]domain.TaskActivity, error) {
+				gotID = id
+				return mockActivities, nil
+			},
+		}
+		handler := task.NewTaskHistoryHandler(mockUsecase)
+		serve(w, r, task.TaskHistoryPath, handler.History)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var activities []domain.TaskActivity
+		err := json.NewDecoder(res.Body).Decode(&activities)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, int64(5), gotID)
+		assert.Equal(t, mockActivities[0].Changes, activities[0].Changes)
+	})
+
+	t.Run("準正常系 タスクが存在しない場合、404エラーとなること", func(t *testing.T) {
+		ctx := context.TODO()
+		token := generateToken(ctx)
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/5/history", nil)
+		r.Header.Set("Authorization", token)
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockTaskUsecase{
+			MockHistory: func(ctx context.Context, id int64) ([]domain.TaskActivity, error) {
+				return nil, domain.ErrRecordNotFound
+			},
+		}
+		handler := task.NewTaskHistoryHandler(mockUsecase)
+		serve(w, r, task.TaskHistoryPath, handler.History)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+}
@@ -0,0 +1,131 @@
+package task
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// importColumns インポートするCSVに必須の列
+// エクスポートしたCSVをそのまま取り込めるよう、それ以外の列は無視します
+var importColumns = []string{"title", "content", "due_date"}
+
+// parseCSVRows CSVの各行をタスク追加時のリクエストに変換します
+// 行単位のエラーは処理を中断せずに返却し、ファイル全体が読み取れない場合のみerrを返却します
+// CSVの形式が不正な場合のerrはcsv.ParseError、サイズを超えた場合はhttp.MaxBytesErrorとなります
+func parseCSVRows(r io.Reader) ([]CreateTaskRequest, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, domain.ErrBadRequest.WithMessageKey("import.csv_header_required")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	indexes := make(map[string]int, len(header))
+	for i, column := range header {
+		indexes[strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))] = i
+	}
+	for _, column := range importColumns {
+		if _, ok := indexes[column]; !ok {
+			return nil, nil, domain.ErrBadRequest.WithMessageKey("import.csv_column_required", column)
+		}
+	}
+
+	requests := make([]CreateTaskRequest, 0)
+	rowErrors := make([]ImportRowError, 0)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if errors.Is(err, csv.ErrFieldCount) {
+				detail := domain.NewErrorDetail("", "field_count", "import.csv_field_count", len(header), len(record))
+				rowErrors = append(rowErrors, validationRowError(row, domain.ErrValidation.WithDetails(detail)))
+				continue
+			}
+			return nil, nil, err
+		}
+		if len(requests)+len(rowErrors) >= maxImportRows {
+			return nil, nil, errTooManyRows
+		}
+
+		request := CreateTaskRequest{
+			Title:   record[indexes["title"]],
+			Content: record[indexes["content"]],
+		}
+		if dueDate := record[indexes["due_date"]]; dueDate != "" {
+			request.DueDate, err = time.Parse(time.RFC3339, dueDate)
+			if err != nil {
+				detail := domain.NewErrorDetail("due_date", "datetime", "validation.datetime")
+				rowErrors = append(rowErrors, validationRowError(row, domain.ErrValidation.WithDetails(detail)))
+				continue
+			}
+		}
+		if ok, err := request.IsCreateRequestValid(); !ok {
+			rowErrors = append(rowErrors, validationRowError(row, err))
+			continue
+		}
+		requests = append(requests, request)
+	}
+	return requests, rowErrors, nil
+}
+
+// parseJSONRows JSONの配列の各要素をタスク追加時のリクエストに変換します
+// 行単位のエラーは処理を中断せずに返却し、件数が上限を超える場合のみerrを返却します
+// エクスポートしたJSONをそのまま取り込めるよう、要素の未知のフィールドは無視します
+func parseJSONRows(rawRows []json.RawMessage) ([]CreateTaskRequest, []ImportRowError, error) {
+	if len(rawRows) > maxImportRows {
+		return nil, nil, errTooManyRows
+	}
+
+	requests := make([]CreateTaskRequest, 0)
+	rowErrors := make([]ImportRowError, 0)
+	for i, rawRow := range rawRows {
+		row := i + 1
+		var request CreateTaskRequest
+		err := json.Unmarshal(rawRow, &request)
+		if err != nil {
+			rowErrors = append(rowErrors, jsonRowError(row, err))
+			continue
+		}
+		if ok, err := request.IsCreateRequestValid(); !ok {
+			rowErrors = append(rowErrors, validationRowError(row, err))
+			continue
+		}
+		requests = append(requests, request)
+	}
+	return requests, rowErrors, nil
+}
+
+// jsonRowError JSONの要素を読み込めない場合のエラーを、フィールドごとの詳細を持つ行単位のエラーに変換します
+func jsonRowError(row int, err error) ImportRowError {
+	var typeErr *json.UnmarshalTypeError
+	var timeErr *time.ParseError
+	detail := domain.NewErrorDetail("", "type", "validation.type", "object")
+	switch {
+	case errors.As(err, &typeErr):
+		detail = domain.NewErrorDetail(typeErr.Field, "type", "validation.type", typeErr.Type.String())
+	case errors.As(err, &timeErr):
+		// 日時の項目はdue_dateのみです
+		detail = domain.NewErrorDetail("due_date", "datetime", "validation.datetime")
+	}
+	return validationRowError(row, domain.ErrValidation.WithDetails(detail))
+}
+
+// validationRowError 検証エラーを、フィールドごとの詳細を持つ行単位のエラーに変換します
+func validationRowError(row int, err error) ImportRowError {
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) {
+		return ImportRowError{Row: row, Message: appErr.Message, Errors: appErr.Details, appErr: appErr}
+	}
+	return ImportRowError{Row: row, Message: err.Error()}
+}
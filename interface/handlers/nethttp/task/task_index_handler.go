@@ -1,14 +1,11 @@
 package task
 
 import (
-	"context"
-	"encoding/json"
 	"net/http"
 	"net/url"
 	"strconv"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
-	"github.com/Hajime3778/go-clean-arch/domain/constant"
 	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
 	usecase "github.com/Hajime3778/go-clean-arch/usecase/task"
 )
@@ -17,81 +14,64 @@ const TaskIndexPath string = "/tasks"
 
 type taskIndexHandler struct {
 	taskUsecase usecase.TaskUsecase
+	decoder     httpUtil.JSONDecoder
 }
 
 // NewTaskHandler タスク機能のHandlerオブジェクトを作成します
-func NewTaskIndexHandler(u usecase.TaskUsecase) *taskIndexHandler {
-	return &taskIndexHandler{u}
+func NewTaskIndexHandler(u usecase.TaskUsecase, decoder httpUtil.JSONDecoder) *taskIndexHandler {
+	return &taskIndexHandler{u, decoder}
 }
 
-// NewTaskIndexHandler
-func (t *taskIndexHandler) Handler(w http.ResponseWriter, r *http.Request) {
+// FindByUserID ログインユーザーのタスクを複数件取得します
+// limitは1〜100、offsetは0以上の整数で指定します
+func (t *taskIndexHandler) FindByUserID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	switch r.Method {
-	case http.MethodGet:
-		t.findByUserID(ctx, w, r)
-	case http.MethodPost:
-		t.create(ctx, w, r)
-	default:
-		w.WriteHeader(http.StatusNotFound)
-	}
-}
-
-func (t *taskIndexHandler) findByUserID(ctx context.Context, w http.ResponseWriter, r *http.Request) {
-	token, userID, err := httpUtil.VerifyAccessToken(r)
-	if err != nil {
-		httpUtil.WriteJSONResponse(w, http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
-		return
-	}
-	ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
-	ctx = context.WithValue(ctx, constant.AuthTokenContextKey, token)
 	q, _ := url.Parse(r.RequestURI)
 	query := q.Query()
 
 	strLimit := query.Get("limit")
 	strOffset := query.Get("offset")
 
-	limit, err := strconv.ParseInt(strLimit, 10, 64)
+	var request FindByUserIDQuery
+	var err error
+	request.Limit, err = strconv.ParseInt(strLimit, 10, 64)
 	if err != nil {
-		httpUtil.WriteJSONResponse(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, httpUtil.QueryParamError("limit", err, "validation.integer"))
 		return
 	}
-	offset, err := strconv.ParseInt(strOffset, 10, 64)
+	request.Offset, err = strconv.ParseInt(strOffset, 10, 64)
 	if err != nil {
-		httpUtil.WriteJSONResponse(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, httpUtil.QueryParamError("offset", err, "validation.integer"))
 		return
 	}
-
-	tasks, err := t.taskUsecase.FindByUserID(ctx, limit, offset)
+	err = httpUtil.Validate(request)
 	if err != nil {
-		httpUtil.WriteJSONResponse(w, httpUtil.GetStatusCode(err), domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
 
-	httpUtil.WriteJSONResponse(w, http.StatusOK, tasks)
-}
-
-func (t *taskIndexHandler) create(ctx context.Context, w http.ResponseWriter, r *http.Request) {
-	token, userID, err := httpUtil.VerifyAccessToken(r)
+	tasks, err := t.taskUsecase.FindByUserID(ctx, request.Limit, request.Offset)
 	if err != nil {
-		httpUtil.WriteJSONResponse(w, http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
-	ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
-	ctx = context.WithValue(ctx, constant.AuthTokenContextKey, token)
 
+	httpUtil.WriteJSONResponse(w, http.StatusOK, tasks)
+}
+
+// Create タスクを1件作成します
+func (t *taskIndexHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	var requestTask CreateTaskRequest
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err = decoder.Decode(&requestTask)
+	err := t.decoder.DecodeJSON(w, r, &requestTask)
 	if err != nil {
-		httpUtil.WriteJSONResponse(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
 
 	var ok bool
 	if ok, err = requestTask.IsCreateRequestValid(); !ok {
-		httpUtil.WriteJSONResponse(w, http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
 
@@ -103,7 +83,7 @@ func (t *taskIndexHandler) create(ctx context.Context, w http.ResponseWriter, r
 
 	err = t.taskUsecase.Create(ctx, task)
 	if err != nil {
-		httpUtil.WriteJSONResponse(w, httpUtil.GetStatusCode(err), domain.ErrorResponse{Message: err.Error()})
+		httpUtil.WriteError(w, r, err)
 		return
 	}
 
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
 	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/task"
 	"github.com/Hajime3778/go-clean-arch/usecase/task/mock"
 	"github.com/Hajime3778/go-clean-arch/util/token"
@@ -19,16 +20,20 @@ import (
 )
 
 func TestTaskIndexHandlerTest(t *testing.T) {
-	t.Run("異常系 実装していないメソッドでリクエストした場合、404エラーとなること", func(t *testing.T) {
-		r := httptest.NewRequest(http.MethodOptions, "http://example.com/tasks", nil)
+	t.Run("異常系 実装していないメソッドでリクエストした場合、405エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPatch, "http://example.com/tasks", nil)
 		w := httptest.NewRecorder()
 		mockUsecase := &mock.MockTaskUsecase{}
-		handler := task.NewTaskIndexHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+		router := httpUtil.NewRouter()
+		router.Handle(http.MethodGet, task.TaskIndexPath, handler.FindByUserID)
+		router.Handle(http.MethodPost, task.TaskIndexPath, handler.Create)
+		router.ServeHTTP(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+		assert.Equal(t, "GET, HEAD, OPTIONS, POST", res.Header.Get("Allow"))
 	})
 }
 
@@ -45,8 +50,8 @@ func TestFindByIDTest(t *testing.T) {
 				return make([]domain.Task, 0), nil
 			},
 		}
-		handler := task.NewTaskIndexHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+		handler.FindByUserID(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
@@ -65,12 +70,12 @@ func TestFindByIDTest(t *testing.T) {
 				return make([]domain.Task, 0), nil
 			},
 		}
-		handler := task.NewTaskIndexHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+		handler.FindByUserID(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -78,7 +83,7 @@ func TestFindByIDTest(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 limitが数字でない場合、400エラーとなること", func(t *testing.T) {
@@ -93,12 +98,12 @@ func TestFindByIDTest(t *testing.T) {
 				return make([]domain.Task, 0), nil
 			},
 		}
-		handler := task.NewTaskIndexHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+		handler.FindByUserID(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -106,7 +111,7 @@ func TestFindByIDTest(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("準正常系 offsetが数字でない場合、400エラーとなること", func(t *testing.T) {
@@ -121,12 +126,12 @@ func TestFindByIDTest(t *testing.T) {
 				return make([]domain.Task, 0), nil
 			},
 		}
-		handler := task.NewTaskIndexHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+		handler.FindByUserID(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -134,13 +139,56 @@ func TestFindByIDTest(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
+	})
+
+	t.Run("準正常系 limit, offsetが範囲外の場合、Usecaseを実行せずに400エラーとなること", func(t *testing.T) {
+		tests := []struct {
+			query   string
+			field   string
+			rule    string
+			message string
+		}{
+			{"limit=0&offset=0", "limit", "min", "1以上の値を指定してください"},
+			{"limit=101&offset=0", "limit", "max", "100以下の値を指定してください"},
+			{"limit=10&offset=-1", "offset", "min", "0以上の値を指定してください"},
+		}
+		for _, tt := range tests {
+			ctx := context.TODO()
+			token := generateToken(ctx)
+			r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks?"+tt.query, nil)
+			r.Header.Set("Authorization", token)
+			w := httptest.NewRecorder()
+			mockUsecase := &mock.MockTaskUsecase{
+				MockFindByUserID: func(ctx context.Context, limit int64, offset int64) ([]domain.Task, error) {
+					t.Fatal("範囲外の場合はUsecaseを実行しないこと")
+					return nil, nil
+				},
+			}
+			handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+			handler.FindByUserID(w, r)
+			res := w.Result()
+			defer res.Body.Close()
+
+			var resError domain.ProblemDetails
+			err := json.NewDecoder(res.Body).Decode(&resError)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, tt.query)
+			assert.Equal(t, domain.ErrValidation.Code, resError.Code, tt.query)
+			assert.Len(t, resError.Errors, 1, tt.query)
+			assert.Equal(t, tt.field, resError.Errors[0].Field, tt.query)
+			assert.Equal(t, tt.rule, resError.Errors[0].Rule, tt.query)
+			assert.Equal(t, tt.message, resError.Errors[0].Message, tt.query)
+		}
 	})
 
 	t.Run("異常系 Usecase実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		token := generateToken(ctx)
-		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks?limit=0&offset=0", nil)
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks?limit=10&offset=0", nil)
 		r.Header.Set("Content-Type", "application/json")
 		r.Header.Set("Authorization", token)
 		w := httptest.NewRecorder()
@@ -150,12 +198,12 @@ func TestFindByIDTest(t *testing.T) {
 				return nil, mockErr
 			},
 		}
-		handler := task.NewTaskIndexHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+		handler.FindByUserID(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -163,7 +211,7 @@ func TestFindByIDTest(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
-		assert.Equal(t, domain.ErrorResponse{Message: mockErr.Error()}, resError)
+		assert.Equal(t, domain.ErrInternalServerError.Code, resError.Code)
 	})
 }
 
@@ -190,8 +238,8 @@ func TestCreate(t *testing.T) {
 				return nil
 			},
 		}
-		handler := task.NewTaskIndexHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+		handler.Create(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
@@ -217,12 +265,12 @@ func TestCreate(t *testing.T) {
 				return nil
 			},
 		}
-		handler := task.NewTaskIndexHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+		handler.Create(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -230,14 +278,17 @@ func TestCreate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.Equal(t, domain.ErrValidation.Code, resError.Code)
+		assert.Len(t, resError.Errors, 1)
+		assert.Equal(t, "content", resError.Errors[0].Field)
+		assert.Equal(t, "required", resError.Errors[0].Rule)
 	})
 
 	t.Run("準正常系 リクエスト形式が間違っている場合、400エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		token := generateToken(ctx)
-		req := domain.ErrorResponse{
-			Message: "test",
+		req := map[string]string{
+			"message": "test",
 		}
 		byteTask, _ := json.Marshal(req)
 		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks",
@@ -251,12 +302,12 @@ func TestCreate(t *testing.T) {
 				return nil
 			},
 		}
-		handler := task.NewTaskIndexHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+		handler.Create(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -264,7 +315,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 
 	t.Run("異常系 Usecase実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
@@ -287,12 +338,12 @@ func TestCreate(t *testing.T) {
 				return errors.New("test error")
 			},
 		}
-		handler := task.NewTaskIndexHandler(mockUsecase)
-		handler.Handler(w, r)
+		handler := task.NewTaskIndexHandler(mockUsecase, decoder)
+		handler.Create(w, r)
 		res := w.Result()
 		defer res.Body.Close()
 
-		var resError domain.ErrorResponse
+		var resError domain.ProblemDetails
 		decoder := json.NewDecoder(res.Body)
 		err := decoder.Decode(&resError)
 		if err != nil {
@@ -300,7 +351,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
-		assert.NotEmpty(t, resError.Message)
+		assert.NotEmpty(t, resError.Code)
 	})
 }
 
@@ -313,5 +364,5 @@ func generateToken(ctx context.Context) string {
 		Salt:     "test salt",
 	}
 
-	return token.GenerateAccessToken(user)
+	return token.GenerateAccessToken(user, []byte("test_secret_key_0123456789abcdef"))
 }
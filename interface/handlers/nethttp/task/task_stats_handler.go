@@ -0,0 +1,47 @@
+package task
+
+import (
+	"net/http"
+	"strconv"
+
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	usecase "github.com/Hajime3778/go-clean-arch/usecase/task"
+)
+
+const TaskStatsPath string = "/tasks/stats"
+
+type taskStatsHandler struct {
+	taskUsecase usecase.TaskUsecase
+}
+
+// NewTaskStatsHandler タスク集計のHandlerオブジェクトを作成します
+func NewTaskStatsHandler(u usecase.TaskUsecase) *taskStatsHandler {
+	return &taskStatsHandler{u}
+}
+
+// Handler /tasks/stats?days=N でログインユーザーのタスクの集計結果を取得します
+// daysは完了数の推移を集計する日数で、省略した場合は30日となります
+func (t *taskStatsHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	days := usecase.DefaultStatsDays
+	if strDays := r.URL.Query().Get("days"); strDays != "" {
+		var err error
+		days, err = strconv.Atoi(strDays)
+		if err != nil {
+			httpUtil.WriteError(w, r, httpUtil.QueryParamError("days", err, "validation.integer"))
+			return
+		}
+		if days < 1 || days > usecase.MaxStatsDays {
+			httpUtil.WriteError(w, r, httpUtil.QueryParamError("days", nil, "validation.between", 1, usecase.MaxStatsDays))
+			return
+		}
+	}
+
+	stats, err := t.taskUsecase.Stats(ctx, days)
+	if err != nil {
+		httpUtil.WriteError(w, r, err)
+		return
+	}
+	httpUtil.WriteJSONResponse(w, http.StatusOK, stats)
+}
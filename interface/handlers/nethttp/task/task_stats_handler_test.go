@@ -0,0 +1,83 @@
+package task_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/task"
+	"github.com/Hajime3778/go-clean-arch/usecase/task/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskStatsHandler(t *testing.T) {
+	t.Run("正常系 集計結果が取得できること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/stats?days=7", nil)
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		var gotDays int
+		mockStats := domain.TaskStats{
+			ByStatus:        domain.TaskStatusCounts{Open: 3, Completed: 2},
+			Overdue:         1,
+			CompletionTrend: []domain.DailyCount{{Date: "2021-12-05", Count: 2}},
+		}
+		mockUsecase := &mock.MockTaskUsecase{
+			MockStats: func(ctx context.Context, days int) (domain.TaskStats, error) {
+				gotDays = days
+				return mockStats, nil
+			},
+		}
+		handler := task.NewTaskStatsHandler(mockUsecase)
+		handler.Handler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var stats domain.TaskStats
+		err := json.NewDecoder(res.Body).Decode(&stats)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, 7, gotDays)
+		assert.Equal(t, mockStats, stats)
+	})
+
+	t.Run("正常系 daysを省略した場合、既定の日数で集計されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/stats", nil)
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		var gotDays int
+		mockUsecase := &mock.MockTaskUsecase{
+			MockStats: func(ctx context.Context, days int) (domain.TaskStats, error) {
+				gotDays = days
+				return domain.TaskStats{}, nil
+			},
+		}
+		handler := task.NewTaskStatsHandler(mockUsecase)
+		handler.Handler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, 30, gotDays)
+	})
+
+	t.Run("準正常系 daysが範囲外の場合、400エラーとなること", func(t *testing.T) {
+		for _, days := range []string{"0", "366", "foo"} {
+			r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/stats?days="+days, nil)
+			r.Header.Set("Authorization", generateToken(context.TODO()))
+			w := httptest.NewRecorder()
+			handler := task.NewTaskStatsHandler(&mock.MockTaskUsecase{})
+			handler.Handler(w, r)
+			res := w.Result()
+			res.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, days)
+		}
+	})
+
+}
@@ -0,0 +1,303 @@
+package task
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	httpUtil "github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	usecase "github.com/Hajime3778/go-clean-arch/usecase/task"
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
+)
+
+const TaskExportPath string = "/tasks/export"
+const TaskImportPath string = "/tasks/import"
+
+// FormatCSV, FormatJSON インポート・エクスポートで扱うファイル形式
+const (
+	FormatCSV  string = "csv"
+	FormatJSON string = "json"
+)
+
+// maxImportBodySize インポートするファイルの最大サイズ
+const maxImportBodySize int64 = 5 << 20
+
+// maxImportRows 1回でインポートできるタスクの最大件数
+const maxImportRows int = 1000
+
+// exportColumns エクスポートするCSVの列
+var exportColumns = []string{"id", "title", "content", "due_date", "completed_at", "created_at", "updated_at"}
+
+var errTooManyRows = domain.ErrBadRequest.WithMessageKey("import.too_many_rows", maxImportRows)
+
+type taskTransferHandler struct {
+	taskUsecase usecase.TaskUsecase
+	// decoder インポートするJSONを読み込みます。最大サイズはmaxImportBodySizeです
+	decoder httpUtil.JSONDecoder
+}
+
+// NewTaskTransferHandler タスクのインポート・エクスポートのHandlerオブジェクトを作成します
+func NewTaskTransferHandler(u usecase.TaskUsecase) *taskTransferHandler {
+	return &taskTransferHandler{u, httpUtil.NewJSONDecoder(maxImportBodySize)}
+}
+
+// ExportHandler /tasks/export?format=csv|json でログインユーザーのタスクを全件出力します
+// formatを省略した場合はJSONで出力します
+func (t *taskTransferHandler) ExportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "":
+		format = FormatJSON
+	case FormatCSV, FormatJSON:
+	default:
+		httpUtil.WriteError(w, r, domain.ErrBadRequest.WithMessageKey("import.unsupported_format", format))
+		return
+	}
+
+	var exporter taskExporter
+	if format == FormatCSV {
+		exporter = &csvTaskExporter{writer: csv.NewWriter(w)}
+	} else {
+		exporter = &jsonTaskExporter{writer: w}
+	}
+
+	// 1件目を出力するまではエラーをJSONで返却できるよう、ヘッダーの出力を遅らせます
+	started := false
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", exporter.contentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="tasks.%s"`, format))
+		w.WriteHeader(http.StatusOK)
+		return exporter.begin()
+	}
+
+	err := t.taskUsecase.Export(ctx, func(task domain.Task) error {
+		if !started {
+			err := start()
+			if err != nil {
+				return err
+			}
+		}
+		return exporter.write(task)
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = exporter.end()
+	}
+	if err != nil {
+		if !started {
+			httpUtil.WriteError(w, r, err)
+			return
+		}
+		// ステータスコードは出力済みのため、ログのみ出力します
+		slog.ErrorContext(ctx, "export failed after response started", "error", err)
+	}
+}
+
+// ImportHandler /tasks/import でCSVまたはJSONのタスクを一括で作成します
+// 形式は?format=csv|json、省略時はContent-Typeで判定します
+// JSONはDecodeJSONで読み込むため、Content-Typeにapplication/jsonを指定する必要があります
+// ?dry_run=true を指定した場合は検証のみ行い、タスクは作成しません
+// 1行でも不正な行がある場合は、1件も作成せずに行単位のエラーを返却します
+func (t *taskTransferHandler) ImportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	dryRun := false
+	if strDryRun := query.Get("dry_run"); strDryRun != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(strDryRun)
+		if err != nil {
+			httpUtil.WriteError(w, r, httpUtil.QueryParamError("dry_run", err, "validation.boolean"))
+			return
+		}
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = formatFromContentType(r.Header.Get("Content-Type"))
+	}
+	var requests []CreateTaskRequest
+	var rowErrors []ImportRowError
+	var err error
+	switch format {
+	case FormatCSV:
+		requests, rowErrors, err = parseCSVRows(http.MaxBytesReader(w, r.Body, maxImportBodySize))
+	case FormatJSON:
+		var rawRows []json.RawMessage
+		err = t.decoder.DecodeJSON(w, r, &rawRows)
+		if err == nil {
+			requests, rowErrors, err = parseJSONRows(rawRows)
+		}
+	default:
+		httpUtil.WriteError(w, r, domain.ErrUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		httpUtil.WriteError(w, r, importError(err))
+		return
+	}
+
+	lang := i18n.FromContext(ctx)
+	for i := range rowErrors {
+		rowErrors[i] = rowErrors[i].localize(lang)
+	}
+
+	response := ImportTaskResponse{DryRun: dryRun, Count: len(requests), Errors: rowErrors}
+	if len(rowErrors) > 0 {
+		response.Count = 0
+		httpUtil.WriteJSONResponse(w, http.StatusBadRequest, response)
+		return
+	}
+	if dryRun {
+		httpUtil.WriteJSONResponse(w, http.StatusOK, response)
+		return
+	}
+
+	tasks := make([]domain.Task, 0, len(requests))
+	for _, request := range requests {
+		tasks = append(tasks, domain.Task{
+			Title:   request.Title,
+			Content: request.Content,
+			DueDate: request.DueDate,
+		})
+	}
+	err = t.taskUsecase.Import(ctx, tasks)
+	if err != nil {
+		httpUtil.WriteError(w, r, err)
+		return
+	}
+	httpUtil.WriteJSONResponse(w, http.StatusCreated, response)
+}
+
+// importError ファイル全体を読み取れない場合のエラーを、クライアントに返却できるAppErrorに変換します
+// 読み取りのエラーの内容はレスポンスに含めず、メッセージカタログのキーで返却します
+func importError(err error) error {
+	var appErr *domain.AppError
+	var maxBytesErr *http.MaxBytesError
+	var parseErr *csv.ParseError
+	switch {
+	case errors.As(err, &appErr):
+		return appErr
+	case errors.As(err, &maxBytesErr):
+		return domain.ErrFileTooLarge.WithMessageKey("request.body_too_large", maxBytesErr.Limit)
+	case errors.As(err, &parseErr):
+		return domain.ErrBadRequest.WithMessageKey("import.csv_malformed", parseErr.Line).Wrap(err)
+	default:
+		return domain.ErrBadRequest.Wrap(err)
+	}
+}
+
+// formatFromContentType Content-Typeからインポートするファイルの形式を判定します
+func formatFromContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV
+	case "application/json":
+		return FormatJSON
+	default:
+		return ""
+	}
+}
+
+// taskExporter タスクを1件ずつ出力します
+type taskExporter interface {
+	contentType() string
+	begin() error
+	write(task domain.Task) error
+	end() error
+}
+
+// csvTaskExporter タスクをCSV形式で出力します
+type csvTaskExporter struct {
+	writer *csv.Writer
+}
+
+func (e *csvTaskExporter) contentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (e *csvTaskExporter) begin() error {
+	return e.writer.Write(exportColumns)
+}
+
+func (e *csvTaskExporter) write(task domain.Task) error {
+	var completedAt time.Time
+	if task.CompletedAt != nil {
+		completedAt = *task.CompletedAt
+	}
+	return e.writer.Write([]string{
+		strconv.FormatInt(task.ID, 10),
+		task.Title,
+		task.Content,
+		formatExportTime(task.DueDate),
+		formatExportTime(completedAt),
+		formatExportTime(task.CreatedAt),
+		formatExportTime(task.UpdatedAt),
+	})
+}
+
+func (e *csvTaskExporter) end() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// jsonTaskExporter タスクをJSONの配列で出力します
+type jsonTaskExporter struct {
+	writer io.Writer
+	count  int
+}
+
+func (e *jsonTaskExporter) contentType() string {
+	return "application/json"
+}
+
+func (e *jsonTaskExporter) begin() error {
+	_, err := io.WriteString(e.writer, "[")
+	return err
+}
+
+func (e *jsonTaskExporter) write(task domain.Task) error {
+	if e.count > 0 {
+		_, err := io.WriteString(e.writer, ",")
+		if err != nil {
+			return err
+		}
+	}
+	e.count++
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	_, err = e.writer.Write(data)
+	return err
+}
+
+func (e *jsonTaskExporter) end() error {
+	_, err := io.WriteString(e.writer, "]")
+	return err
+}
+
+// formatExportTime 日時をRFC3339形式で出力します。未設定の場合は空文字となります
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
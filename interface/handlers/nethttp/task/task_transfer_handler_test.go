@@ -0,0 +1,287 @@
+package task_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp/task"
+	"github.com/Hajime3778/go-clean-arch/usecase/task/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportHandler(t *testing.T) {
+	dueDate := time.Date(2021, 12, 5, 20, 30, 0, 0, time.UTC)
+	mockTasks := []domain.Task{
+		{ID: 1, UserID: 1, Title: "お米を炊く", Content: "5合, 早炊き", DueDate: dueDate},
+		{ID: 2, UserID: 1, Title: "買い出し", Content: "卵\n鶏肉", DueDate: dueDate},
+	}
+	exportMock := func(ctx context.Context, fn func(task domain.Task) error) error {
+		for _, mockTask := range mockTasks {
+			err := fn(mockTask)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	t.Run("正常系 CSV形式で出力されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/export?format=csv", nil)
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		handler := task.NewTaskTransferHandler(&mock.MockTaskUsecase{MockExport: exportMock})
+		handler.ExportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		records, err := csv.NewReader(res.Body).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/csv; charset=utf-8", res.Header.Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="tasks.csv"`, res.Header.Get("Content-Disposition"))
+		assert.Len(t, records, 3)
+		assert.Equal(t, []string{"id", "title", "content", "due_date", "completed_at", "created_at", "updated_at"}, records[0])
+		assert.Equal(t, []string{"2", "買い出し", "卵\n鶏肉", "2021-12-05T20:30:00Z", "", "", ""}, records[2])
+	})
+
+	t.Run("正常系 formatを省略した場合、JSON形式で出力されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/export", nil)
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		handler := task.NewTaskTransferHandler(&mock.MockTaskUsecase{MockExport: exportMock})
+		handler.ExportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var tasks []domain.Task
+		err := json.NewDecoder(res.Body).Decode(&tasks)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, mockTasks, tasks)
+	})
+
+	t.Run("正常系 タスクが存在しない場合、空の配列が出力されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/export?format=json", nil)
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockTaskUsecase{
+			MockExport: func(ctx context.Context, fn func(task domain.Task) error) error {
+				return nil
+			},
+		}
+		handler := task.NewTaskTransferHandler(mockUsecase)
+		handler.ExportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "[]", string(data))
+	})
+
+	t.Run("準正常系 未対応のformatの場合、400エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/export?format=xml", nil)
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		handler := task.NewTaskTransferHandler(&mock.MockTaskUsecase{})
+		handler.ExportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("異常系 出力前にUsecaseでエラーが発生した場合、500エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/export", nil)
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		mockUsecase := &mock.MockTaskUsecase{
+			MockExport: func(ctx context.Context, fn func(task domain.Task) error) error {
+				return domain.ErrInternalServerError
+			},
+		}
+		handler := task.NewTaskTransferHandler(mockUsecase)
+		handler.ExportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+}
+
+func TestImportHandler(t *testing.T) {
+	t.Run("正常系 CSVのタスクが一括で作成されること", func(t *testing.T) {
+		body := "id,title,content,due_date\n" +
+			"1,お米を炊く,\"5合, 早炊き\",2021-12-05T20:30:00+09:00\n" +
+			",買い出し,卵と鶏肉,2021-12-05T20:35:00Z\n"
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/import", strings.NewReader(body))
+		r.Header.Set("Content-Type", "text/csv")
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		var imported []domain.Task
+		mockUsecase := &mock.MockTaskUsecase{
+			MockImport: func(ctx context.Context, tasks []domain.Task) error {
+				imported = tasks
+				return nil
+			},
+		}
+		handler := task.NewTaskTransferHandler(mockUsecase)
+		handler.ImportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var response task.ImportTaskResponse
+		err := json.NewDecoder(res.Body).Decode(&response)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		assert.Equal(t, 2, response.Count)
+		assert.Len(t, imported, 2)
+		assert.Equal(t, "5合, 早炊き", imported[0].Content)
+		assert.True(t, time.Date(2021, 12, 5, 11, 30, 0, 0, time.UTC).Equal(imported[0].DueDate))
+	})
+
+	t.Run("正常系 dry_runの場合、検証のみ行いタスクは作成されないこと", func(t *testing.T) {
+		body := `[{"title":"お米を炊く","content":"5合","due_date":"2021-12-05T20:30:00Z"}]`
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/import?dry_run=true", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		handler := task.NewTaskTransferHandler(&mock.MockTaskUsecase{})
+		handler.ImportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var response task.ImportTaskResponse
+		err := json.NewDecoder(res.Body).Decode(&response)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, task.ImportTaskResponse{DryRun: true, Count: 1, Errors: []task.ImportRowError{}}, response)
+	})
+
+	t.Run("準正常系 不正な行がある場合、行単位のエラーが返却され作成されないこと", func(t *testing.T) {
+		body := `[
+			{"title":"お米を炊く","content":"5合","due_date":"2021-12-05T20:30:00Z"},
+			{"title":"","content":"5合","due_date":"2021-12-05T20:30:00Z"},
+			{"title":"買い出し","content":"卵","due_date":"tomorrow"}
+		]`
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/import?format=json", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		handler := task.NewTaskTransferHandler(&mock.MockTaskUsecase{})
+		handler.ImportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var response task.ImportTaskResponse
+		err := json.NewDecoder(res.Body).Decode(&response)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, 0, response.Count)
+		assert.Len(t, response.Errors, 2)
+		assert.Equal(t, 2, response.Errors[0].Row)
+		assert.Equal(t, 3, response.Errors[1].Row)
+		assert.Equal(t, "入力内容に誤りがあります", response.Errors[1].Message)
+		assert.Equal(t, []domain.ErrorDetail{{Field: "due_date", Rule: "datetime", Message: "RFC3339形式の日時を指定してください"}}, response.Errors[1].Errors)
+	})
+
+	t.Run("準正常系 CSVの列数が不正な行がある場合、行単位のエラーが返却されること", func(t *testing.T) {
+		body := "title,content,due_date\nお米を炊く,5合\n"
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/import?format=csv", strings.NewReader(body))
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		handler := task.NewTaskTransferHandler(&mock.MockTaskUsecase{})
+		handler.ImportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var response task.ImportTaskResponse
+		err := json.NewDecoder(res.Body).Decode(&response)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Len(t, response.Errors, 1)
+		assert.Equal(t, []domain.ErrorDetail{{Rule: "field_count", Message: "3列が必要ですが、2列です"}}, response.Errors[0].Errors)
+	})
+
+	t.Run("準正常系 CSVの形式が不正な場合、読み取りのエラーを含まない400エラーとなること", func(t *testing.T) {
+		body := "title,content,due_date\n\"お米,5合,2021-12-05T20:30:00Z\n"
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/import?format=csv", strings.NewReader(body))
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		handler := task.NewTaskTransferHandler(&mock.MockTaskUsecase{})
+		handler.ImportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var problem domain.ProblemDetails
+		err := json.NewDecoder(res.Body).Decode(&problem)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, "CSVの形式が正しくありません(2行目)", problem.Detail)
+	})
+
+	t.Run("準正常系 JSONに複数の値が含まれる場合、400エラーとなること", func(t *testing.T) {
+		body := `[{"title":"お米を炊く","content":"5合","due_date":"2021-12-05T20:30:00Z"}][]`
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/import", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		handler := task.NewTaskTransferHandler(&mock.MockTaskUsecase{})
+		handler.ImportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("準正常系 CSVに必須の列がない場合、400エラーとなること", func(t *testing.T) {
+		body := "title,content\nお米を炊く,5合\n"
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/import?format=csv", strings.NewReader(body))
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		handler := task.NewTaskTransferHandler(&mock.MockTaskUsecase{})
+		handler.ImportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("準正常系 形式が判定できない場合、415エラーとなること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks/import", strings.NewReader("foo"))
+		r.Header.Set("Content-Type", "text/plain")
+		r.Header.Set("Authorization", generateToken(context.TODO()))
+		w := httptest.NewRecorder()
+		handler := task.NewTaskTransferHandler(&mock.MockTaskUsecase{})
+		handler.ImportHandler(w, r)
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, res.StatusCode)
+	})
+
+}
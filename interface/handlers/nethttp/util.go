@@ -2,11 +2,14 @@ package nethttp
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"log/slog"
+	"math"
 	"net/http"
-	"os"
+	"strconv"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
 	"github.com/form3tech-oss/jwt-go"
 	"github.com/form3tech-oss/jwt-go/request"
 )
@@ -17,41 +20,87 @@ func WriteJSONResponse(w http.ResponseWriter, status int, body interface{}) {
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
-		log.Println(err.Error())
+		slog.Error("response marshal failed", "error", err)
 		return
 	}
 	w.WriteHeader(status)
 	w.Write(json)
-	log.Println(string(json))
 }
 
+// ProblemContentType エラーレスポンスのContent-Type
+const ProblemContentType = "application/problem+json"
+
 // GetStatusCode エラー内容からHttpStatusCodeを返却します
+// AppErrorでないエラーは500となります
 func GetStatusCode(err error) int {
 	if err == nil {
 		return http.StatusOK
 	}
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Status
+	}
+	return http.StatusInternalServerError
+}
+
+// WriteError エラーをRFC 7807 形式(application/problem+json)で出力します
+// detailと詳細のメッセージはcontextの言語に翻訳し、codeは言語によらず同じ値とします
+// AppErrorでないエラーや5xxのエラーは、内部の情報を返却しないようログにのみ出力します
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) {
+		slog.ErrorContext(ctx, "unexpected error", "error", err)
+		appErr = domain.ErrInternalServerError
+	} else if appErr.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx, "request failed", "error", err)
+	} else if appErr.Err != nil {
+		slog.DebugContext(ctx, "request rejected", "error", err)
+	}
+
+	lang := i18n.FromContext(ctx)
+	problem := domain.ProblemDetails{
+		Type:      "about:blank",
+		Title:     http.StatusText(appErr.Status),
+		Status:    appErr.Status,
+		Detail:    appErr.LocalizedMessage(lang),
+		Instance:  r.URL.Path,
+		Code:      appErr.Code,
+		Errors:    appErr.LocalizedDetails(lang),
+		RequestID: domain.RequestIDFromContext(ctx),
+	}
+	SetRetryAfter(w, err)
+	w.Header().Set("Content-Type", ProblemContentType)
+	WriteJSONResponse(w, appErr.Status, problem)
+}
 
-	switch err {
-	case domain.ErrInternalServerError:
-		return http.StatusInternalServerError
-	case domain.ErrRecordNotFound:
-		return http.StatusNotFound
-	case domain.ErrBadRequest:
-		return http.StatusBadRequest
-	case domain.ErrExistEmail:
-		return http.StatusBadRequest
-	case domain.ErrFailedSignIn:
-		return http.StatusUnauthorized
-	default:
-		return http.StatusInternalServerError
+// QueryParamError クエリパラメーターが不正な場合のエラーを作成します
+// keyは詳細のメッセージのメッセージカタログのキーです
+func QueryParamError(name string, err error, key string, args ...interface{}) error {
+	appErr := domain.ErrValidation.WithDetails(domain.NewErrorDetail(name, "", key, args...))
+	if err != nil {
+		return appErr.Wrap(err)
+	}
+	return appErr
+}
+
+// SetRetryAfter エラーが再試行できるまでの時間を持つ場合、Retry-Afterヘッダーに秒数を設定します
+func SetRetryAfter(w http.ResponseWriter, err error) {
+	var rateLimitErr *domain.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		return
+	}
+	seconds := int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
 	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
 }
 
-// VerifyAccessToken アクセストークン署名を検証し、トークンとUserIDを返却します。
-func VerifyAccessToken(r *http.Request) (string, int64, error) {
+// VerifyAccessToken アクセストークン署名をsecretKeyで検証し、トークンとUserIDを返却します。
+func VerifyAccessToken(r *http.Request, secretKey []byte) (string, int64, error) {
 	token, err := request.ParseFromRequestWithClaims(r, request.OAuth2Extractor, &domain.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		b := []byte(os.Getenv("SECRET_KEY"))
-		return b, nil
+		return secretKey, nil
 	})
 
 	if err != nil {
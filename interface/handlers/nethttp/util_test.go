@@ -3,30 +3,27 @@ package nethttp_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
-	"github.com/Hajime3778/go-clean-arch/infrastructure/env"
 	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
 	"github.com/form3tech-oss/jwt-go"
 	"github.com/stretchr/testify/assert"
 )
 
+// secretKey テストでアクセストークンの署名に使用する鍵
+var secretKey = []byte("test_secret_key_0123456789abcdef")
+
 type TestResponse struct {
 	Number json.Number `json:"number"`
 }
 
-func TestMain(m *testing.M) {
-	env.NewEnv().LoadEnvFile("../../../.env")
-	exitVal := m.Run()
-	os.Exit(exitVal)
-}
-
 func TestWriteJSONResponse(t *testing.T) {
 	t.Run("正常系 JSON文字列となり、リクエストされたStatusCodeが返却されること", func(t *testing.T) {
 		w := httptest.NewRecorder()
@@ -83,6 +80,21 @@ func TestGetStatusCode(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, status)
 	})
 
+	t.Run("正常系 ErrUnauthorizedの場合、401が返却されること", func(t *testing.T) {
+		status := nethttp.GetStatusCode(domain.ErrUnauthorized)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("正常系 ErrFileTooLargeの場合、413が返却されること", func(t *testing.T) {
+		status := nethttp.GetStatusCode(domain.ErrFileTooLarge)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	})
+
+	t.Run("正常系 ErrUnsupportedMediaTypeの場合、415が返却されること", func(t *testing.T) {
+		status := nethttp.GetStatusCode(domain.ErrUnsupportedMediaType)
+		assert.Equal(t, http.StatusUnsupportedMediaType, status)
+	})
+
 	t.Run("正常系 ErrRecordNotFoundの場合、404が返却されること", func(t *testing.T) {
 		status := nethttp.GetStatusCode(domain.ErrRecordNotFound)
 		assert.Equal(t, http.StatusNotFound, status)
@@ -92,6 +104,139 @@ func TestGetStatusCode(t *testing.T) {
 		status := nethttp.GetStatusCode(domain.ErrInternalServerError)
 		assert.Equal(t, http.StatusInternalServerError, status)
 	})
+
+	t.Run("正常系 ラップされたAppErrorの場合、AppErrorのStatusが返却されること", func(t *testing.T) {
+		status := nethttp.GetStatusCode(fmt.Errorf("find task: %w", domain.ErrRecordNotFound.Wrap(errors.New("no rows"))))
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("正常系 RateLimitErrorの場合、429が返却されること", func(t *testing.T) {
+		status := nethttp.GetStatusCode(&domain.RateLimitError{RetryAfter: time.Second})
+		assert.Equal(t, http.StatusTooManyRequests, status)
+	})
+}
+
+func TestWriteError(t *testing.T) {
+	t.Run("正常系 AppErrorがproblem+json形式で出力されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks/1", nil)
+		ctx := domain.WithRequestID(r.Context(), "request-id")
+		r = r.WithContext(i18n.WithLanguage(ctx, i18n.English))
+		w := httptest.NewRecorder()
+		err := domain.ErrValidation.WithDetails(domain.NewErrorDetail("title", "required", "validation.required"))
+
+		nethttp.WriteError(w, r, err)
+		res := w.Result()
+		defer res.Body.Close()
+
+		var problem domain.ProblemDetails
+		if err := json.NewDecoder(res.Body).Decode(&problem); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, nethttp.ProblemContentType, res.Header.Get("Content-Type"))
+		assert.Equal(t, domain.ProblemDetails{
+			Type:      "about:blank",
+			Title:     "Bad Request",
+			Status:    http.StatusBadRequest,
+			Detail:    domain.ErrValidation.Message,
+			Instance:  "/tasks/1",
+			Code:      domain.ErrValidation.Code,
+			Errors:    []domain.ErrorDetail{{Field: "title", Rule: "required", Message: "is required"}},
+			RequestID: "request-id",
+		}, problem)
+	})
+
+	t.Run("正常系 contextの言語でメッセージが翻訳され、codeは言語によらず同じであること", func(t *testing.T) {
+		err := domain.ErrValidation.WithDetails(domain.NewErrorDetail("title", "max", "validation.max", "50"))
+		problems := make(map[i18n.Language]domain.ProblemDetails)
+		for _, lang := range []i18n.Language{i18n.Japanese, i18n.English} {
+			r := httptest.NewRequest(http.MethodPost, "http://example.com/tasks", nil)
+			r = r.WithContext(i18n.WithLanguage(r.Context(), lang))
+			w := httptest.NewRecorder()
+
+			nethttp.WriteError(w, r, err)
+			res := w.Result()
+			defer res.Body.Close()
+
+			var problem domain.ProblemDetails
+			if err := json.NewDecoder(res.Body).Decode(&problem); err != nil {
+				t.Fatal(err)
+			}
+			problems[lang] = problem
+		}
+
+		ja, en := problems[i18n.Japanese], problems[i18n.English]
+		assert.Equal(t, domain.ErrValidation.Code, ja.Code)
+		assert.Equal(t, en.Code, ja.Code)
+		assert.Equal(t, en.Errors[0].Rule, ja.Errors[0].Rule)
+		assert.Equal(t, "入力内容に誤りがあります", ja.Detail)
+		assert.Equal(t, "50文字以内で入力してください", ja.Errors[0].Message)
+		assert.Equal(t, "request validation failed", en.Detail)
+		assert.Equal(t, "must be at most 50 characters", en.Errors[0].Message)
+	})
+
+	t.Run("正常系 翻訳されないメッセージの場合、そのまま返却されること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks", nil)
+		w := httptest.NewRecorder()
+
+		nethttp.WriteError(w, r, domain.ErrBadRequest.WithMessage("parse error: line 1"))
+		res := w.Result()
+		defer res.Body.Close()
+
+		var problem domain.ProblemDetails
+		if err := json.NewDecoder(res.Body).Decode(&problem); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, domain.ErrBadRequest.Code, problem.Code)
+		assert.Equal(t, "parse error: line 1", problem.Detail)
+	})
+
+	t.Run("正常系 AppErrorでない場合、エラーの内容を返却せずに500となること", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks", nil)
+		w := httptest.NewRecorder()
+
+		nethttp.WriteError(w, r, errors.New("dial tcp 10.0.0.1:3306: connection refused"))
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		assert.Contains(t, string(data), domain.ErrInternalServerError.Code)
+		assert.NotContains(t, string(data), "10.0.0.1")
+	})
+
+	t.Run("正常系 原因を保持したAppErrorの場合、原因は返却されないこと", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/tasks", nil)
+		w := httptest.NewRecorder()
+
+		nethttp.WriteError(w, r, domain.ErrBadRequest.Wrap(errors.New("strconv.ParseInt: parsing \"a\": invalid syntax")))
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.NotContains(t, string(data), "strconv")
+	})
+}
+
+func TestSetRetryAfter(t *testing.T) {
+	t.Run("正常系 RateLimitErrorの場合、切り上げた秒数が設定されること", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		nethttp.SetRetryAfter(w, &domain.RateLimitError{RetryAfter: 1500 * time.Millisecond})
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("正常系 RateLimitErrorでない場合、設定されないこと", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		nethttp.SetRetryAfter(w, domain.ErrFailedSignIn)
+		assert.Empty(t, w.Header().Get("Retry-After"))
+	})
 }
 
 func TestVerifyAccessToken(t *testing.T) {
@@ -104,11 +249,11 @@ func TestVerifyAccessToken(t *testing.T) {
 			},
 		}
 
-		tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(os.Getenv("SECRET_KEY")))
+		tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secretKey)
 		r := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
 		r.Header.Add("Authorization", tokenString)
 
-		token, userID, err := nethttp.VerifyAccessToken(r)
+		token, userID, err := nethttp.VerifyAccessToken(r, secretKey)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -120,9 +265,25 @@ func TestVerifyAccessToken(t *testing.T) {
 		r := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
 		r.Header.Add("Authorization", "")
 
-		token, userID, err := nethttp.VerifyAccessToken(r)
+		token, userID, err := nethttp.VerifyAccessToken(r, secretKey)
 		assert.NotEmpty(t, err)
 		assert.Empty(t, token)
 		assert.Equal(t, int64(0), userID)
 	})
+
+	t.Run("異常系 異なる鍵で署名されたトークンの場合エラーとなること", func(t *testing.T) {
+		claims := domain.Claims{
+			UserID: 1,
+			StandardClaims: jwt.StandardClaims{
+				ExpiresAt: time.Now().Add(time.Hour * 24).Unix(),
+			},
+		}
+		tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("other_secret_key_0123456789abcdef"))
+		r := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
+		r.Header.Add("Authorization", tokenString)
+
+		_, userID, err := nethttp.VerifyAccessToken(r, secretKey)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), userID)
+	})
 }
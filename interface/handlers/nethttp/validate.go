@@ -0,0 +1,116 @@
+package nethttp
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+const (
+	// minPasswordLength パスワードの最小の長さ
+	minPasswordLength = 8
+	// maxPasswordBytes パスワードの最大のバイト数
+	// bcryptは72バイトまでしか扱えないため、10桁のsaltを除いた長さとします
+	maxPasswordBytes = 62
+)
+
+var (
+	// minDueDate 期限として受け付ける最も古い日時
+	minDueDate = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	// maxDueDate 期限として受け付ける最も新しい日時
+	maxDueDate = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// validate 構造体の検証に使用するValidator
+// 検証ルールを内部でキャッシュするため、リクエストごとに作成せずに共有します
+var validate = newValidator()
+
+// Validate 構造体のvalidateタグで検証します
+// 検証に失敗した場合は、JSONのフィールド名・ルール・メッセージを詳細に持つErrValidationを返却します
+func Validate(v interface{}) error {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	details := make([]domain.ErrorDetail, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		key, args := validationMessage(fieldErr)
+		details = append(details, domain.NewErrorDetail(fieldErr.Field(), fieldErr.Tag(), key, args...))
+	}
+	return domain.ErrValidation.WithDetails(details...)
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	// エラーのフィールド名を、構造体のフィールド名ではなくJSONのフィールド名とします
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+	v.RegisterValidation("password", isValidPassword)
+	v.RegisterValidation("due_date", isValidDueDate)
+	return v
+}
+
+// isValidPassword パスワードが長さの範囲内で、英字と数字を含むかどうかを判定します
+func isValidPassword(fl validator.FieldLevel) bool {
+	password := fl.Field().String()
+	if len([]rune(password)) < minPasswordLength || len(password) > maxPasswordBytes {
+		return false
+	}
+	var hasLetter, hasDigit bool
+	for _, c := range password {
+		switch {
+		case unicode.IsLetter(c):
+			hasLetter = true
+		case unicode.IsDigit(c):
+			hasDigit = true
+		}
+	}
+	return hasLetter && hasDigit
+}
+
+// isValidDueDate 期限が現実的な範囲の日時かどうかを判定します
+func isValidDueDate(fl validator.FieldLevel) bool {
+	dueDate, ok := fl.Field().Interface().(time.Time)
+	if !ok {
+		return false
+	}
+	return !dueDate.Before(minDueDate) && dueDate.Before(maxDueDate)
+}
+
+// validationMessage 検証ルールに応じたメッセージカタログのキーと埋め込む値を返却します
+func validationMessage(fieldErr validator.FieldError) (string, []interface{}) {
+	switch fieldErr.Tag() {
+	case "required", "email":
+		return "validation." + fieldErr.Tag(), nil
+	case "max", "min":
+		// 数値の場合は文字数ではなく値の範囲のメッセージとします
+		switch fieldErr.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return "validation." + fieldErr.Tag() + "_value", []interface{}{fieldErr.Param()}
+		}
+		return "validation." + fieldErr.Tag(), []interface{}{fieldErr.Param()}
+	case "password":
+		return "validation.password", []interface{}{minPasswordLength, maxPasswordBytes}
+	case "due_date":
+		return "validation.due_date", []interface{}{minDueDate.Format("2006-01-02"), maxDueDate.Format("2006-01-02")}
+	default:
+		return "validation.unknown_rule", []interface{}{fieldErr.Tag()}
+	}
+}
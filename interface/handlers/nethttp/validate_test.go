@@ -0,0 +1,71 @@
+package nethttp_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/handlers/nethttp"
+	"github.com/stretchr/testify/assert"
+)
+
+type validateTestRequest struct {
+	Title    string    `json:"title" validate:"required,max=5"`
+	Email    string    `json:"email" validate:"omitempty,email"`
+	Password string    `json:"password" validate:"omitempty,password"`
+	DueDate  time.Time `json:"due_date" validate:"omitempty,due_date"`
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("正常系 ルールを満たす場合、エラーとならないこと", func(t *testing.T) {
+		err := nethttp.Validate(validateTestRequest{
+			Title:    "タスク",
+			Email:    "test@example.com",
+			Password: "password1",
+			DueDate:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("準正常系 JSONのフィールド名とルールを持つ詳細が返却されること", func(t *testing.T) {
+		err := nethttp.Validate(validateTestRequest{})
+
+		var appErr *domain.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, domain.ErrValidation.Code, appErr.Code)
+		assert.Equal(t, []domain.ErrorDetail{domain.NewErrorDetail("title", "required", "validation.required")}, appErr.Details)
+		assert.Equal(t, "is required", appErr.Details[0].Message)
+		assert.NotContains(t, err.Error(), "validateTestRequest")
+	})
+
+	tests := []struct {
+		name    string
+		request validateTestRequest
+		field   string
+		rule    string
+	}{
+		{"文字数が上限を超える場合", validateTestRequest{Title: strings.Repeat("あ", 6)}, "title", "max"},
+		{"メールアドレスの形式でない場合", validateTestRequest{Title: "a", Email: "foo"}, "email", "email"},
+		{"パスワードが短い場合", validateTestRequest{Title: "a", Password: "pass1"}, "password", "password"},
+		{"パスワードに数字が含まれない場合", validateTestRequest{Title: "a", Password: "password"}, "password", "password"},
+		{"パスワードが長すぎる場合", validateTestRequest{Title: "a", Password: strings.Repeat("a", 62) + "1"}, "password", "password"},
+		{"期限が古すぎる場合", validateTestRequest{Title: "a", DueDate: time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)}, "due_date", "due_date"},
+		{"期限が遠すぎる場合", validateTestRequest{Title: "a", DueDate: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)}, "due_date", "due_date"},
+	}
+	for _, tt := range tests {
+		t.Run("準正常系 "+tt.name+"、エラーとなること", func(t *testing.T) {
+			err := nethttp.Validate(tt.request)
+
+			var appErr *domain.AppError
+			if !errors.As(err, &appErr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.Len(t, appErr.Details, 1)
+			assert.Equal(t, tt.field, appErr.Details[0].Field)
+			assert.Equal(t, tt.rule, appErr.Details[0].Rule)
+			assert.NotEmpty(t, appErr.Details[0].Message)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// LockoutState サインインに失敗し続けているキーの状態
+type LockoutState struct {
+	// Failures 最後のロック以降に連続して失敗した回数
+	Failures int
+	// LockCount ロックされた回数
+	LockCount int
+	// LockedUntil ロックが解除される日時
+	LockedUntil time.Time
+	// UpdatedAt 最後に失敗した日時
+	UpdatedAt time.Time
+}
+
+// LockoutStore キーごとのロック状態を保存します
+type LockoutStore interface {
+	// Get ロック状態を取得します。存在しない場合はゼロ値を返却します
+	Get(ctx context.Context, key string) (LockoutState, error)
+	// Update ロック状態をfnで更新して保存し、更新後の状態を返却します
+	// 同じキーへの同時の更新で失敗回数が失われないよう、読み込みから保存までを排他的に実行します
+	Update(ctx context.Context, key string, fn func(state LockoutState) LockoutState) (LockoutState, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// LockoutPolicy 段階的なロックの設定
+// Threshold回連続して失敗するとロックされ、ロックの時間はロックされるたびに
+// Durationから2倍ずつ延び、MaxDurationで頭打ちになります
+// 最後の失敗からResetAfterが経過した場合、状態はリセットされます
+type LockoutPolicy struct {
+	Threshold   int
+	Duration    time.Duration
+	MaxDuration time.Duration
+	ResetAfter  time.Duration
+}
+
+// Lockout 連続したサインインの失敗に応じてキーをロックします
+type Lockout struct {
+	Store  LockoutStore
+	Policy LockoutPolicy
+	// Now 現在日時を返却します。nilの場合はtime.Nowを使用します
+	Now func() time.Time
+}
+
+// NewLockout Lockoutオブジェクトを作成します
+func NewLockout(store LockoutStore, policy LockoutPolicy) *Lockout {
+	return &Lockout{Store: store, Policy: policy}
+}
+
+// Check キーがロックされている場合、ロックが解除されるまでの時間を返却します
+// ロックされていない場合は0を返却します
+func (l *Lockout) Check(ctx context.Context, key string) (time.Duration, error) {
+	state, err := l.Store.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if wait := state.LockedUntil.Sub(l.now()); wait > 0 {
+		return wait, nil
+	}
+	return 0, nil
+}
+
+// Fail 失敗を記録します。失敗回数が閾値に達した場合はロックし、ロックの時間を返却します
+func (l *Lockout) Fail(ctx context.Context, key string) (time.Duration, error) {
+	now := l.now()
+	var locked time.Duration
+	_, err := l.Store.Update(ctx, key, func(state LockoutState) LockoutState {
+		locked = 0
+		if l.Policy.ResetAfter > 0 && !state.UpdatedAt.IsZero() && now.Sub(state.UpdatedAt) > l.Policy.ResetAfter {
+			state = LockoutState{}
+		}
+
+		state.Failures++
+		state.UpdatedAt = now
+		if l.Policy.Threshold > 0 && state.Failures >= l.Policy.Threshold {
+			locked = l.Policy.lockDuration(state.LockCount)
+			state.Failures = 0
+			state.LockCount++
+			state.LockedUntil = now.Add(locked)
+		}
+		return state
+	})
+	if err != nil {
+		return 0, err
+	}
+	return locked, nil
+}
+
+// Reset サインインに成功した場合に、キーの状態を削除します
+func (l *Lockout) Reset(ctx context.Context, key string) error {
+	return l.Store.Delete(ctx, key)
+}
+
+func (l *Lockout) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now()
+}
+
+// lockDuration lockCount回ロックされた後のロックの時間を返却します
+func (p LockoutPolicy) lockDuration(lockCount int) time.Duration {
+	d := p.Duration
+	for i := 0; i < lockCount; i++ {
+		if p.MaxDuration > 0 && d >= p.MaxDuration {
+			break
+		}
+		d *= 2
+	}
+	if p.MaxDuration > 0 && d > p.MaxDuration {
+		d = p.MaxDuration
+	}
+	return d
+}
@@ -0,0 +1,118 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockout(t *testing.T) {
+	policy := ratelimit.LockoutPolicy{
+		Threshold:   2,
+		Duration:    time.Minute,
+		MaxDuration: 3 * time.Minute,
+		ResetAfter:  24 * time.Hour,
+	}
+
+	t.Run("正常系 閾値に達するたびにロックの時間が2倍になり、上限で頭打ちになること", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		lockout := ratelimit.NewLockout(newStore(), policy)
+		lockout.Now = func() time.Time { return now }
+
+		var durations []time.Duration
+		for i := 0; i < 8; i++ {
+			locked, err := lockout.Fail(context.TODO(), "key")
+			assert.NoError(t, err)
+			durations = append(durations, locked)
+		}
+		assert.Equal(t, []time.Duration{
+			0, time.Minute,
+			0, 2 * time.Minute,
+			0, 3 * time.Minute,
+			0, 3 * time.Minute,
+		}, durations)
+
+		wait, err := lockout.Check(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.Equal(t, 3*time.Minute, wait)
+	})
+
+	t.Run("正常系 ロックの期限を過ぎた場合、ロックされていないこと", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		lockout := ratelimit.NewLockout(newStore(), policy)
+		lockout.Now = func() time.Time { return now }
+		lockout.Fail(context.TODO(), "key")
+		lockout.Fail(context.TODO(), "key")
+
+		now = now.Add(time.Minute)
+		wait, err := lockout.Check(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), wait)
+	})
+
+	t.Run("正常系 最後の失敗からResetAfterが経過した場合、ロックの時間が初期値に戻ること", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		lockout := ratelimit.NewLockout(newStore(), policy)
+		lockout.Now = func() time.Time { return now }
+		lockout.Fail(context.TODO(), "key")
+		lockout.Fail(context.TODO(), "key")
+
+		now = now.Add(25 * time.Hour)
+		lockout.Fail(context.TODO(), "key")
+		locked, err := lockout.Fail(context.TODO(), "key")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, locked)
+	})
+
+	t.Run("正常系 Resetした場合、状態が削除されること", func(t *testing.T) {
+		store := newStore()
+		lockout := ratelimit.NewLockout(store, policy)
+		lockout.Fail(context.TODO(), "key")
+
+		err := lockout.Reset(context.TODO(), "key")
+		assert.NoError(t, err)
+		state, _ := store.Get(context.TODO(), "key")
+		assert.Equal(t, ratelimit.LockoutState{}, state)
+	})
+
+	t.Run("異常系 状態の取得でエラーが発生した場合、エラーとなること", func(t *testing.T) {
+		mockErr := errors.New("test error")
+		store := &mock.MockLockoutStore{
+			MockGet: func(ctx context.Context, key string) (ratelimit.LockoutState, error) {
+				return ratelimit.LockoutState{}, mockErr
+			},
+			MockUpdate: func(ctx context.Context, key string, fn func(state ratelimit.LockoutState) ratelimit.LockoutState) (ratelimit.LockoutState, error) {
+				return ratelimit.LockoutState{}, mockErr
+			},
+		}
+		lockout := ratelimit.NewLockout(store, policy)
+
+		_, err := lockout.Check(context.TODO(), "key")
+		assert.Equal(t, mockErr, err)
+		_, err = lockout.Fail(context.TODO(), "key")
+		assert.Equal(t, mockErr, err)
+	})
+}
+
+// newStore mapに状態を保持するLockoutStoreを作成します
+func newStore() *mock.MockLockoutStore {
+	states := map[string]ratelimit.LockoutState{}
+	return &mock.MockLockoutStore{
+		MockGet: func(ctx context.Context, key string) (ratelimit.LockoutState, error) {
+			return states[key], nil
+		},
+		MockUpdate: func(ctx context.Context, key string, fn func(state ratelimit.LockoutState) ratelimit.LockoutState) (ratelimit.LockoutState, error) {
+			states[key] = fn(states[key])
+			return states[key], nil
+		},
+		MockDelete: func(ctx context.Context, key string) error {
+			delete(states, key)
+			return nil
+		},
+	}
+}
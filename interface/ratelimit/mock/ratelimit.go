@@ -0,0 +1,35 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+)
+
+type MockLimiter struct {
+	ratelimit.Limiter
+	MockAllow func(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error)
+}
+
+func (m *MockLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return m.MockAllow(ctx, key, limit)
+}
+
+type MockLockoutStore struct {
+	ratelimit.LockoutStore
+	MockGet    func(ctx context.Context, key string) (ratelimit.LockoutState, error)
+	MockUpdate func(ctx context.Context, key string, fn func(state ratelimit.LockoutState) ratelimit.LockoutState) (ratelimit.LockoutState, error)
+	MockDelete func(ctx context.Context, key string) error
+}
+
+func (m *MockLockoutStore) Get(ctx context.Context, key string) (ratelimit.LockoutState, error) {
+	return m.MockGet(ctx, key)
+}
+
+func (m *MockLockoutStore) Update(ctx context.Context, key string, fn func(state ratelimit.LockoutState) ratelimit.LockoutState) (ratelimit.LockoutState, error) {
+	return m.MockUpdate(ctx, key, fn)
+}
+
+func (m *MockLockoutStore) Delete(ctx context.Context, key string) error {
+	return m.MockDelete(ctx, key)
+}
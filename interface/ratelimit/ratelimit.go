@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit トークンバケットの設定
+// バケットは最大Burst個のトークンを持ち、Intervalごとに1個補充されます
+type Limit struct {
+	Burst    int
+	Interval time.Duration
+}
+
+// Result レート制限の判定結果
+type Result struct {
+	// Allowed リクエストを許可するかどうか
+	Allowed bool
+	// Remaining 残りのトークン数
+	Remaining int
+	// RetryAfter 次のトークンが補充されるまでの時間(許可しない場合のみ)
+	RetryAfter time.Duration
+}
+
+// Limiter キーごとのトークンバケットでリクエスト数を制限します
+type Limiter interface {
+	// Allow キーのバケットからトークンを1個消費し、リクエストを許可するかどうかを返却します
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Bucket トークンバケットの状態
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// NewBucket トークンが満たされたバケットを作成します
+func NewBucket(limit Limit, now time.Time) Bucket {
+	return Bucket{Tokens: float64(limit.Burst), UpdatedAt: now}
+}
+
+// Take 経過時間分のトークンを補充してから1個消費し、更新後のバケットと判定結果を返却します
+// トークンが不足している場合はトークンを消費しません
+func (b Bucket) Take(limit Limit, now time.Time) (Bucket, Result) {
+	tokens := b.Tokens
+	if elapsed := now.Sub(b.UpdatedAt); elapsed > 0 && limit.Interval > 0 {
+		tokens += float64(elapsed) / float64(limit.Interval)
+	}
+	if burst := float64(limit.Burst); tokens > burst {
+		tokens = burst
+	}
+
+	if tokens < 1 {
+		wait := time.Duration(math.Ceil((1 - tokens) * float64(limit.Interval)))
+		return Bucket{Tokens: tokens, UpdatedAt: now}, Result{Allowed: false, RetryAfter: wait}
+	}
+	tokens--
+	return Bucket{Tokens: tokens, UpdatedAt: now}, Result{Allowed: true, Remaining: int(tokens)}
+}
+
+// IsFull 経過時間分を補充するとトークンが満たされるかどうかを判定します
+// 満たされたバケットは新規作成と区別できないため、破棄できます
+func (b Bucket) IsFull(limit Limit, now time.Time) bool {
+	if limit.Interval <= 0 {
+		return false
+	}
+	missing := float64(limit.Burst) - b.Tokens
+	return now.Sub(b.UpdatedAt) >= time.Duration(missing*float64(limit.Interval))
+}
@@ -0,0 +1,49 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketTake(t *testing.T) {
+	limit := ratelimit.Limit{Burst: 2, Interval: 10 * time.Second}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系 Burst回までは許可され、超えた場合は補充までの時間が返却されること", func(t *testing.T) {
+		bucket := ratelimit.NewBucket(limit, now)
+
+		bucket, result := bucket.Take(limit, now)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1, result.Remaining)
+
+		bucket, result = bucket.Take(limit, now)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+
+		_, result = bucket.Take(limit, now.Add(4*time.Second))
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 6*time.Second, result.RetryAfter)
+	})
+
+	t.Run("正常系 経過時間分のトークンが補充され、Burstを超えないこと", func(t *testing.T) {
+		bucket := ratelimit.Bucket{Tokens: 0, UpdatedAt: now}
+
+		_, result := bucket.Take(limit, now.Add(10*time.Second))
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+
+		_, result = bucket.Take(limit, now.Add(time.Hour))
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1, result.Remaining)
+	})
+
+	t.Run("正常系 補充によりトークンが満たされる場合、IsFullがtrueとなること", func(t *testing.T) {
+		bucket := ratelimit.Bucket{Tokens: 1, UpdatedAt: now}
+
+		assert.False(t, bucket.IsFull(limit, now.Add(9*time.Second)))
+		assert.True(t, bucket.IsFull(limit, now.Add(10*time.Second)))
+	})
+}
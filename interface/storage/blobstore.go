@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrObjectNotFound 指定したキーのオブジェクトが存在しない場合のエラー
+var ErrObjectNotFound = errors.New("object not found")
+
+// BlobStore ファイル本体を保存するストレージ
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
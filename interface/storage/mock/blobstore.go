@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"context"
+	"io"
+
+	"github.com/Hajime3778/go-clean-arch/interface/storage"
+)
+
+type MockBlobStore struct {
+	storage.BlobStore
+	MockPut    func(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	MockGet    func(ctx context.Context, key string) (io.ReadCloser, error)
+	MockDelete func(ctx context.Context, key string) error
+}
+
+func (m *MockBlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	return m.MockPut(ctx, key, body, size, contentType)
+}
+
+func (m *MockBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return m.MockGet(ctx, key)
+}
+
+func (m *MockBlobStore) Delete(ctx context.Context, key string) error {
+	return m.MockDelete(ctx, key)
+}
@@ -0,0 +1,189 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	attachmentRepository "github.com/Hajime3778/go-clean-arch/interface/database/attachment"
+	taskRepository "github.com/Hajime3778/go-clean-arch/interface/database/task"
+	"github.com/Hajime3778/go-clean-arch/interface/storage"
+	"github.com/Hajime3778/go-clean-arch/util/string_util"
+)
+
+// MaxAttachmentSize 添付ファイルの最大サイズ(10MB)
+const MaxAttachmentSize int64 = 10 << 20
+
+// sniffLength Content-Typeの判定に使用する先頭のバイト数
+const sniffLength = 512
+
+// allowedContentTypes 添付可能なファイル形式
+var allowedContentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"application/zip": true, // docx, xlsx などのOffice文書
+	"text/plain":      true,
+}
+
+type attachmentUsecase struct {
+	taskRepo       taskRepository.TaskRepository
+	attachmentRepo attachmentRepository.AttachmentRepository
+	blobStore      storage.BlobStore
+}
+
+// NewAttachmentUsecase 添付ファイル機能のUsecaseオブジェクトを作成します
+func NewAttachmentUsecase(taskRepo taskRepository.TaskRepository, attachmentRepo attachmentRepository.AttachmentRepository, blobStore storage.BlobStore) AttachmentUsecase {
+	return &attachmentUsecase{taskRepo, attachmentRepo, blobStore}
+}
+
+// FindByTaskID タスクの添付ファイルを全件取得します
+func (au *attachmentUsecase) FindByTaskID(ctx context.Context, taskID int64) ([]domain.Attachment, error) {
+	err := au.checkTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := au.attachmentRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// Upload ファイルを保存し、タスクに添付します
+// Content-Typeはクライアントの申告ではなく、ファイルの先頭から判定します
+func (au *attachmentUsecase) Upload(ctx context.Context, taskID int64, fileName string, size int64, body io.Reader) (domain.Attachment, error) {
+	// 空のファイルは形式を判定できず text/plain となるため、判定や保存の前に拒否します
+	if size <= 0 {
+		return domain.Attachment{}, domain.ErrValidation.WithDetails(domain.NewErrorDetail("file", "required", "attachment.empty_file"))
+	}
+	if size > MaxAttachmentSize {
+		return domain.Attachment{}, domain.ErrFileTooLarge
+	}
+	err := au.checkTask(ctx, taskID)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(body, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return domain.Attachment{}, err
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !allowedContentTypes[mediaType] {
+		return domain.Attachment{}, domain.ErrUnsupportedMediaType
+	}
+
+	attachment := domain.Attachment{
+		TaskID:      taskID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  fmt.Sprintf("tasks/%d/%s", taskID, string_util.GenerateRundomString(32)),
+		// 秒未満を保存できないデータベースがあるため、保存される値と同じになるよう切り捨てます
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+
+	err = au.blobStore.Put(ctx, attachment.StorageKey, io.MultiReader(bytes.NewReader(head), body), size, contentType)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	id, err := au.attachmentRepo.Create(ctx, attachment)
+	if err != nil {
+		au.deleteBlob(ctx, attachment.StorageKey)
+		return domain.Attachment{}, err
+	}
+	attachment.ID = id
+
+	return attachment, nil
+}
+
+// Download 添付ファイルのメタデータと本体を取得します
+// 本体のReadCloserは呼び出し側でCloseしてください
+func (au *attachmentUsecase) Download(ctx context.Context, taskID int64, id int64) (domain.Attachment, io.ReadCloser, error) {
+	attachment, err := au.getAttachment(ctx, taskID, id)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+
+	body, err := au.blobStore.Get(ctx, attachment.StorageKey)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return domain.Attachment{}, nil, domain.ErrRecordNotFound
+	}
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+
+	return attachment, body, nil
+}
+
+// Delete 添付ファイルを1件削除します
+func (au *attachmentUsecase) Delete(ctx context.Context, taskID int64, id int64) error {
+	attachment, err := au.getAttachment(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	err = au.attachmentRepo.Delete(ctx, attachment.ID)
+	if err != nil {
+		return err
+	}
+	au.deleteBlob(ctx, attachment.StorageKey)
+
+	return nil
+}
+
+// checkTask タスクが存在し、ログインユーザーのものであることを確認します
+func (au *attachmentUsecase) checkTask(ctx context.Context, taskID int64) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	task, err := au.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if task.UserID != userID {
+		return domain.ErrRecordNotFound
+	}
+	return nil
+}
+
+// getAttachment タスクに紐づく添付ファイルを1件取得します
+func (au *attachmentUsecase) getAttachment(ctx context.Context, taskID int64, id int64) (domain.Attachment, error) {
+	err := au.checkTask(ctx, taskID)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	attachment, err := au.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if attachment.TaskID != taskID {
+		return domain.Attachment{}, domain.ErrRecordNotFound
+	}
+	return attachment, nil
+}
+
+// deleteBlob ファイル本体を削除します(失敗した場合はログのみ出力します)
+func (au *attachmentUsecase) deleteBlob(ctx context.Context, key string) {
+	err := au.blobStore.Delete(ctx, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "blob delete failed", "key", key, "error", err)
+	}
+}
@@ -0,0 +1,216 @@
+package attachment_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	attachmentMock "github.com/Hajime3778/go-clean-arch/interface/database/attachment/mock"
+	taskMock "github.com/Hajime3778/go-clean-arch/interface/database/task/mock"
+	"github.com/Hajime3778/go-clean-arch/interface/storage"
+	storageMock "github.com/Hajime3778/go-clean-arch/interface/storage/mock"
+	usecase "github.com/Hajime3778/go-clean-arch/usecase/attachment"
+	"github.com/stretchr/testify/assert"
+)
+
+// pngHeader PNGファイルのシグネチャ
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A")
+
+func TestUpload(t *testing.T) {
+	t.Run("正常系 ファイルを保存し、判定したContent-Typeで登録されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		var stored []byte
+		var created domain.Attachment
+		blobStore := &storageMock.MockBlobStore{
+			MockPut: func(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+				stored, _ = io.ReadAll(body)
+				return nil
+			},
+		}
+		attachmentRepo := &attachmentMock.MockAttachmentRepo{
+			MockCreate: func(ctx context.Context, attachment domain.Attachment) (int64, error) {
+				created = attachment
+				return 3, nil
+			},
+		}
+		attachmentUsecase := usecase.NewAttachmentUsecase(newTaskRepo(1), attachmentRepo, blobStore)
+
+		content := append(pngHeader, []byte("image body")...)
+		result, err := attachmentUsecase.Upload(ctx, 1, "screenshot.png", int64(len(content)), bytes.NewReader(content))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), result.ID)
+		assert.Equal(t, "image/png", result.ContentType)
+		assert.Equal(t, content, stored)
+		assert.Equal(t, created.StorageKey, result.StorageKey)
+		assert.True(t, strings.HasPrefix(result.StorageKey, "tasks/1/"))
+		assert.False(t, result.CreatedAt.IsZero())
+		assert.Equal(t, created.CreatedAt, result.CreatedAt)
+	})
+
+	t.Run("準正常系 空のファイルの場合、保存せずにErrValidationエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		blobStore := &storageMock.MockBlobStore{
+			MockPut: func(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+				t.Fatal("空のファイルは保存しないこと")
+				return nil
+			},
+		}
+		attachmentUsecase := usecase.NewAttachmentUsecase(newTaskRepo(1), &attachmentMock.MockAttachmentRepo{}, blobStore)
+		_, err := attachmentUsecase.Upload(ctx, 1, "empty.txt", 0, bytes.NewReader(nil))
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		var appErr *domain.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Len(t, appErr.Details, 1)
+		assert.Equal(t, "file", appErr.Details[0].Field)
+		assert.Equal(t, "attachment.empty_file", appErr.Details[0].MessageKey)
+	})
+
+	t.Run("準正常系 サイズの上限を超えている場合、ErrFileTooLargeエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		attachmentUsecase := usecase.NewAttachmentUsecase(newTaskRepo(1), &attachmentMock.MockAttachmentRepo{}, &storageMock.MockBlobStore{})
+		_, err := attachmentUsecase.Upload(ctx, 1, "large.png", usecase.MaxAttachmentSize+1, bytes.NewReader(pngHeader))
+
+		assert.Equal(t, domain.ErrFileTooLarge, err)
+	})
+
+	t.Run("準正常系 許可されていない形式の場合、ErrUnsupportedMediaTypeエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		attachmentUsecase := usecase.NewAttachmentUsecase(newTaskRepo(1), &attachmentMock.MockAttachmentRepo{}, &storageMock.MockBlobStore{})
+		content := []byte("<html><body>foo</body></html>")
+		_, err := attachmentUsecase.Upload(ctx, 1, "screenshot.png", int64(len(content)), bytes.NewReader(content))
+
+		assert.Equal(t, domain.ErrUnsupportedMediaType, err)
+	})
+
+	t.Run("準正常系 他のユーザーのタスクの場合、ErrRecordNotFoundエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 2})
+		attachmentUsecase := usecase.NewAttachmentUsecase(newTaskRepo(1), &attachmentMock.MockAttachmentRepo{}, &storageMock.MockBlobStore{})
+		_, err := attachmentUsecase.Upload(ctx, 1, "screenshot.png", int64(len(pngHeader)), bytes.NewReader(pngHeader))
+
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+	})
+
+	t.Run("異常系 メタデータの登録に失敗した場合、保存したファイルが削除されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		var putKey, deletedKey string
+		blobStore := &storageMock.MockBlobStore{
+			MockPut: func(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+				putKey = key
+				return nil
+			},
+			MockDelete: func(ctx context.Context, key string) error {
+				deletedKey = key
+				return nil
+			},
+		}
+		attachmentRepo := &attachmentMock.MockAttachmentRepo{
+			MockCreate: func(ctx context.Context, attachment domain.Attachment) (int64, error) {
+				return 0, domain.ErrInternalServerError
+			},
+		}
+		attachmentUsecase := usecase.NewAttachmentUsecase(newTaskRepo(1), attachmentRepo, blobStore)
+		_, err := attachmentUsecase.Upload(ctx, 1, "screenshot.png", int64(len(pngHeader)), bytes.NewReader(pngHeader))
+
+		assert.Equal(t, domain.ErrInternalServerError, err)
+		assert.Equal(t, putKey, deletedKey)
+	})
+}
+
+func TestDownload(t *testing.T) {
+	t.Run("正常系 添付ファイルを取得できること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		mockAttachment := domain.Attachment{ID: 3, TaskID: 1, StorageKey: "tasks/1/foo"}
+		attachmentRepo := &attachmentMock.MockAttachmentRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Attachment, error) {
+				return mockAttachment, nil
+			},
+		}
+		blobStore := &storageMock.MockBlobStore{
+			MockGet: func(ctx context.Context, key string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("content")), nil
+			},
+		}
+		attachmentUsecase := usecase.NewAttachmentUsecase(newTaskRepo(1), attachmentRepo, blobStore)
+		attachment, body, err := attachmentUsecase.Download(ctx, 1, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockAttachment, attachment)
+		data, _ := io.ReadAll(body)
+		assert.Equal(t, "content", string(data))
+	})
+
+	t.Run("準正常系 別のタスクの添付ファイルの場合、ErrRecordNotFoundエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		attachmentRepo := &attachmentMock.MockAttachmentRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Attachment, error) {
+				return domain.Attachment{ID: 3, TaskID: 2}, nil
+			},
+		}
+		attachmentUsecase := usecase.NewAttachmentUsecase(newTaskRepo(1), attachmentRepo, &storageMock.MockBlobStore{})
+		_, body, err := attachmentUsecase.Download(ctx, 1, 3)
+
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+		assert.Nil(t, body)
+	})
+
+	t.Run("準正常系 ファイル本体が存在しない場合、ErrRecordNotFoundエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		attachmentRepo := &attachmentMock.MockAttachmentRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Attachment, error) {
+				return domain.Attachment{ID: 3, TaskID: 1}, nil
+			},
+		}
+		blobStore := &storageMock.MockBlobStore{
+			MockGet: func(ctx context.Context, key string) (io.ReadCloser, error) {
+				return nil, storage.ErrObjectNotFound
+			},
+		}
+		attachmentUsecase := usecase.NewAttachmentUsecase(newTaskRepo(1), attachmentRepo, blobStore)
+		_, _, err := attachmentUsecase.Download(ctx, 1, 3)
+
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("正常系 メタデータとファイル本体が削除されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		var deletedID int64
+		var deletedKey string
+		attachmentRepo := &attachmentMock.MockAttachmentRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Attachment, error) {
+				return domain.Attachment{ID: 3, TaskID: 1, StorageKey: "tasks/1/foo"}, nil
+			},
+			MockDelete: func(ctx context.Context, id int64) error {
+				deletedID = id
+				return nil
+			},
+		}
+		blobStore := &storageMock.MockBlobStore{
+			MockDelete: func(ctx context.Context, key string) error {
+				deletedKey = key
+				return nil
+			},
+		}
+		attachmentUsecase := usecase.NewAttachmentUsecase(newTaskRepo(1), attachmentRepo, blobStore)
+		err := attachmentUsecase.Delete(ctx, 1, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), deletedID)
+		assert.Equal(t, "tasks/1/foo", deletedKey)
+	})
+}
+
+// newTaskRepo 指定したユーザーのタスクを返却するモックを作成します
+func newTaskRepo(userID int64) *taskMock.MockTaskRepo {
+	return &taskMock.MockTaskRepo{
+		MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+			return domain.Task{ID: id, UserID: userID}, nil
+		},
+	}
+}
@@ -0,0 +1,15 @@
+package attachment
+
+import (
+	"context"
+	"io"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+type AttachmentUsecase interface {
+	FindByTaskID(ctx context.Context, taskID int64) ([]domain.Attachment, error)
+	Upload(ctx context.Context, taskID int64, fileName string, size int64, body io.Reader) (domain.Attachment, error)
+	Download(ctx context.Context, taskID int64, id int64) (domain.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, taskID int64, id int64) error
+}
@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"context"
+	"io"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	usecase "github.com/Hajime3778/go-clean-arch/usecase/attachment"
+)
+
+type MockAttachmentUsecase struct {
+	usecase.AttachmentUsecase
+	MockFindByTaskID func(ctx context.Context, taskID int64) ([]domain.Attachment, error)
+	MockUpload       func(ctx context.Context, taskID int64, fileName string, size int64, body io.Reader) (domain.Attachment, error)
+	MockDownload     func(ctx context.Context, taskID int64, id int64) (domain.Attachment, io.ReadCloser, error)
+	MockDelete       func(ctx context.Context, taskID int64, id int64) error
+}
+
+func (m *MockAttachmentUsecase) FindByTaskID(ctx context.Context, taskID int64) ([]domain.Attachment, error) {
+	return m.MockFindByTaskID(ctx, taskID)
+}
+
+func (m *MockAttachmentUsecase) Upload(ctx context.Context, taskID int64, fileName string, size int64, body io.Reader) (domain.Attachment, error) {
+	return m.MockUpload(ctx, taskID, fileName, size, body)
+}
+
+func (m *MockAttachmentUsecase) Download(ctx context.Context, taskID int64, id int64) (domain.Attachment, io.ReadCloser, error) {
+	return m.MockDownload(ctx, taskID, id)
+}
+
+func (m *MockAttachmentUsecase) Delete(ctx context.Context, taskID int64, id int64) error {
+	return m.MockDelete(ctx, taskID, id)
+}
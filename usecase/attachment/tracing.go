@@ -0,0 +1,47 @@
+package attachment
+
+import (
+	"context"
+	"io"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/util/tracing"
+)
+
+type tracedAttachmentUsecase struct {
+	next AttachmentUsecase
+}
+
+// WithTracing Usecaseの各メソッドの実行をスパンとして記録します
+func WithTracing(u AttachmentUsecase) AttachmentUsecase {
+	return &tracedAttachmentUsecase{u}
+}
+
+func (t *tracedAttachmentUsecase) FindByTaskID(ctx context.Context, taskID int64) ([]domain.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.FindByTaskID")
+	attachments, err := t.next.FindByTaskID(ctx, taskID)
+	tracing.End(span, err)
+	return attachments, err
+}
+
+func (t *tracedAttachmentUsecase) Upload(ctx context.Context, taskID int64, fileName string, size int64, body io.Reader) (domain.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.Upload")
+	attachment, err := t.next.Upload(ctx, taskID, fileName, size, body)
+	tracing.End(span, err)
+	return attachment, err
+}
+
+// Download ファイル本体の読み込みはHandlerで行うため、スパンには含まれません
+func (t *tracedAttachmentUsecase) Download(ctx context.Context, taskID int64, id int64) (domain.Attachment, io.ReadCloser, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.Download")
+	attachment, body, err := t.next.Download(ctx, taskID, id)
+	tracing.End(span, err)
+	return attachment, body, err
+}
+
+func (t *tracedAttachmentUsecase) Delete(ctx context.Context, taskID int64, id int64) error {
+	ctx, span := tracing.Start(ctx, "AttachmentUsecase.Delete")
+	err := t.next.Delete(ctx, taskID, id)
+	tracing.End(span, err)
+	return err
+}
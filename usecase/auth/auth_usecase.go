@@ -2,21 +2,30 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
 	repository "github.com/Hajime3778/go-clean-arch/interface/database/user"
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
+	"github.com/Hajime3778/go-clean-arch/util/metrics"
 	"github.com/Hajime3778/go-clean-arch/util/string_util"
 	"github.com/Hajime3778/go-clean-arch/util/token"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type authUsecase struct {
-	repo repository.UserRepository
+	repo    repository.UserRepository
+	lockout *ratelimit.Lockout
+	// secretKey アクセストークンの署名に使用する鍵
+	secretKey []byte
 }
 
 // NewAuthUsecase タスク機能のUsecaseオブジェクトを作成します
-func NewAuthUsecase(repo repository.UserRepository) AuthUsecase {
-	return &authUsecase{repo}
+func NewAuthUsecase(repo repository.UserRepository, lockout *ratelimit.Lockout, secretKey []byte) AuthUsecase {
+	return &authUsecase{repo, lockout, secretKey}
 }
 
 // SignUp ユーザーのサインアップを行います
@@ -28,7 +37,7 @@ func (u *authUsecase) SignUp(ctx context.Context, user domain.User) (string, err
 	if err == nil {
 		return "", domain.ErrExistEmail
 	}
-	if err != nil && err != domain.ErrRecordNotFound {
+	if err != nil && !errors.Is(err, domain.ErrRecordNotFound) {
 		return "", err
 	}
 
@@ -44,17 +53,32 @@ func (u *authUsecase) SignUp(ctx context.Context, user domain.User) (string, err
 		return "", err
 	}
 	user.ID = userID
+	metrics.SignUpsTotal.Inc()
 
-	token := token.GenerateAccessToken(user)
+	token := token.GenerateAccessToken(user, u.secretKey)
 
 	return token, nil
 }
 
 // SignIn ユーザーのサインインを行います
+// 連続して失敗したメールアドレスは一定時間ロックされ、ロック中はパスワードを検証せずにRateLimitErrorを返却します
+// 存在しないメールアドレスも同様にロックし、アカウントの有無を推測できないようにします
 func (u *authUsecase) SignIn(ctx context.Context, email string, password string) (string, error) {
+	key := lockoutKey(email)
+	wait, err := u.lockout.Check(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if wait > 0 {
+		metrics.SignInsTotal.WithLabelValues(metrics.ResultLocked).Inc()
+		return "", &domain.RateLimitError{RetryAfter: wait}
+	}
+
 	user, err := u.repo.GetByEmail(ctx, email)
-	if err == domain.ErrRecordNotFound {
-		return "", domain.ErrFailedSignIn
+	if errors.Is(err, domain.ErrRecordNotFound) {
+		// 応答時間の違いでアカウントの有無を推測できないよう、存在する場合と同じくbcryptで検証します
+		bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+		return "", u.failSignIn(ctx, key)
 	}
 	if err != nil {
 		return "", err
@@ -62,15 +86,46 @@ func (u *authUsecase) SignIn(ctx context.Context, email string, password string)
 	inputPassword := []byte(password + user.Salt)
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), inputPassword)
 	if err == bcrypt.ErrMismatchedHashAndPassword {
-		return "", domain.ErrFailedSignIn
+		return "", u.failSignIn(ctx, key)
 	}
 	if err != nil {
 		return "", err
 	}
-	token := token.GenerateAccessToken(user)
+
+	err = u.lockout.Reset(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	metrics.SignInsTotal.WithLabelValues(metrics.ResultSuccess).Inc()
+	token := token.GenerateAccessToken(user, u.secretKey)
 	return token, err
 }
 
+// failSignIn サインインの失敗を記録し、ErrFailedSignInを返却します
+func (u *authUsecase) failSignIn(ctx context.Context, key string) error {
+	metrics.SignInsTotal.WithLabelValues(metrics.ResultFailure).Inc()
+	locked, err := u.lockout.Fail(ctx, key)
+	if err != nil {
+		return err
+	}
+	if locked > 0 {
+		slog.WarnContext(ctx, "sign in locked", "duration", locked.String())
+	}
+	return domain.ErrFailedSignIn
+}
+
+// dummyPasswordHash 存在しないユーザーのサインインで検証に使用する、SignUpと同じコストのハッシュを返却します
+// 起動時間に影響しないよう、最初に使用する際に作成します
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hashed, _ := bcrypt.GenerateFromPassword([]byte(generateSalt()), bcrypt.DefaultCost)
+	return hashed
+})
+
+// lockoutKey メールアドレスからロック状態のキーを作成します
+func lockoutKey(email string) string {
+	return "sign_in:" + strings.ToLower(strings.TrimSpace(email))
+}
+
 // generateSalt Saltを作成します(10桁のランダム文字列)
 func generateSalt() string {
 	return string_util.GenerateRundomString(10)
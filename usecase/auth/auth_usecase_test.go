@@ -3,24 +3,22 @@ package auth_test
 import (
 	"context"
 	"fmt"
-	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
-	"github.com/Hajime3778/go-clean-arch/infrastructure/env"
+	memory "github.com/Hajime3778/go-clean-arch/infrastructure/ratelimit"
 	"github.com/Hajime3778/go-clean-arch/interface/database/user/mock"
+	"github.com/Hajime3778/go-clean-arch/interface/ratelimit"
 	usecase "github.com/Hajime3778/go-clean-arch/usecase/auth"
 	jwt "github.com/form3tech-oss/jwt-go"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/bcrypt"
 )
 
-func TestMain(m *testing.M) {
-	env.NewEnv().LoadEnvFile("../../.env")
-	exitVal := m.Run()
-	os.Exit(exitVal)
-}
+// secretKey テストでアクセストークンの署名に使用する鍵
+var secretKey = []byte("test_secret_key_0123456789abcdef")
 
 func TestSignUp(t *testing.T) {
 	t.Run("正常系 新規登録", func(t *testing.T) {
@@ -40,10 +38,10 @@ func TestSignUp(t *testing.T) {
 			CreatedAt: time.Time{},
 			UpdatedAt: time.Time{},
 		}
-		authUsecase := usecase.NewAuthUsecase(mockUserRepo)
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
 		tokenString, err := authUsecase.SignUp(context.TODO(), mockUser)
 		token, _ := jwt.ParseWithClaims(tokenString, &domain.Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("SECRET_KEY")), nil
+			return secretKey, nil
 		})
 
 		assert.NoError(t, err)
@@ -61,7 +59,7 @@ func TestSignUp(t *testing.T) {
 				return 1, nil
 			},
 		}
-		authUsecase := usecase.NewAuthUsecase(mockUserRepo)
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
 		token, err := authUsecase.SignUp(context.TODO(), domain.User{})
 
 		assert.Equal(t, domain.ErrExistEmail, err)
@@ -77,7 +75,7 @@ func TestSignUp(t *testing.T) {
 				return 1, nil
 			},
 		}
-		authUsecase := usecase.NewAuthUsecase(mockUserRepo)
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
 		token, err := authUsecase.SignUp(context.TODO(), domain.User{})
 
 		assert.Equal(t, domain.ErrInternalServerError, err)
@@ -93,7 +91,7 @@ func TestSignUp(t *testing.T) {
 				return 0, domain.ErrInternalServerError
 			},
 		}
-		authUsecase := usecase.NewAuthUsecase(mockUserRepo)
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
 		token, err := authUsecase.SignUp(context.TODO(), domain.User{})
 
 		assert.Equal(t, domain.ErrInternalServerError, err)
@@ -121,12 +119,12 @@ func TestSignIn(t *testing.T) {
 			},
 		}
 
-		authUsecase := usecase.NewAuthUsecase(mockUserRepo)
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
 		tokenString, err := authUsecase.SignIn(context.TODO(), mockUser.Email, password)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, tokenString)
 		token, _ := jwt.ParseWithClaims(tokenString, &domain.Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("SECRET_KEY")), nil
+			return secretKey, nil
 		})
 		claims := token.Claims.(*domain.Claims)
 		assert.Equal(t, mockUser.ID, claims.UserID)
@@ -151,7 +149,7 @@ func TestSignIn(t *testing.T) {
 				return domain.User{}, domain.ErrRecordNotFound
 			},
 		}
-		authUsecase := usecase.NewAuthUsecase(mockUserRepo)
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
 		tokenString, err := authUsecase.SignIn(context.TODO(), mockUser.Email, "foo bar")
 		assert.Equal(t, domain.ErrFailedSignIn, err)
 		assert.Empty(t, tokenString)
@@ -175,7 +173,7 @@ func TestSignIn(t *testing.T) {
 				return mockUser, nil
 			},
 		}
-		authUsecase := usecase.NewAuthUsecase(mockUserRepo)
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
 		tokenString, err := authUsecase.SignIn(context.TODO(), mockUser.Email, "foo bar")
 		assert.Equal(t, domain.ErrFailedSignIn, err)
 		assert.Empty(t, tokenString)
@@ -196,7 +194,7 @@ func TestSignIn(t *testing.T) {
 				return mockUser, nil
 			},
 		}
-		authUsecase := usecase.NewAuthUsecase(mockUserRepo)
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
 		tokenString, err := authUsecase.SignIn(context.TODO(), mockUser.Email, mockUser.Password)
 		assert.NotEmpty(t, err)
 		assert.Empty(t, tokenString)
@@ -208,12 +206,76 @@ func TestSignIn(t *testing.T) {
 				return domain.User{}, domain.ErrInternalServerError
 			},
 		}
-		authUsecase := usecase.NewAuthUsecase(mockUserRepo)
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
 		token, err := authUsecase.SignIn(context.TODO(), "", "")
 
 		assert.Equal(t, domain.ErrInternalServerError, err)
 		assert.Empty(t, token)
 	})
+
+	t.Run("準正常系 連続して失敗した場合、正しいパスワードでもRateLimitErrorとなること", func(t *testing.T) {
+		password := "test password"
+		salt := "salt"
+		hashed, _ := bcrypt.GenerateFromPassword([]byte(password+salt), bcrypt.DefaultCost)
+		mockUser := domain.User{
+			ID:       1,
+			Email:    generateRandomEmail(),
+			Password: string(hashed),
+			Salt:     salt,
+		}
+		mockUserRepo := &mock.MockUserRepo{
+			MockGetByEmail: func(ctx context.Context, email string) (domain.User, error) {
+				return mockUser, nil
+			},
+		}
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
+		for i := 0; i < 3; i++ {
+			_, err := authUsecase.SignIn(context.TODO(), mockUser.Email, "foo bar")
+			assert.Equal(t, domain.ErrFailedSignIn, err)
+		}
+
+		tokenString, err := authUsecase.SignIn(context.TODO(), strings.ToUpper(mockUser.Email), password)
+		var rateLimitErr *domain.RateLimitError
+		assert.ErrorAs(t, err, &rateLimitErr)
+		assert.Equal(t, time.Minute, rateLimitErr.RetryAfter.Round(time.Second))
+		assert.Empty(t, tokenString)
+	})
+
+	t.Run("正常系 サインインに成功した場合、失敗回数がリセットされること", func(t *testing.T) {
+		password := "test password"
+		salt := "salt"
+		hashed, _ := bcrypt.GenerateFromPassword([]byte(password+salt), bcrypt.DefaultCost)
+		mockUser := domain.User{
+			ID:       1,
+			Email:    generateRandomEmail(),
+			Password: string(hashed),
+			Salt:     salt,
+		}
+		mockUserRepo := &mock.MockUserRepo{
+			MockGetByEmail: func(ctx context.Context, email string) (domain.User, error) {
+				return mockUser, nil
+			},
+		}
+		authUsecase := usecase.NewAuthUsecase(mockUserRepo, newLockout(), secretKey)
+		for i := 0; i < 2; i++ {
+			_, err := authUsecase.SignIn(context.TODO(), mockUser.Email, "foo bar")
+			assert.Equal(t, domain.ErrFailedSignIn, err)
+		}
+		_, err := authUsecase.SignIn(context.TODO(), mockUser.Email, password)
+		assert.NoError(t, err)
+
+		_, err = authUsecase.SignIn(context.TODO(), mockUser.Email, "foo bar")
+		assert.Equal(t, domain.ErrFailedSignIn, err)
+	})
+}
+
+// newLockout 3回の失敗で1分間ロックするLockoutを作成します
+func newLockout() *ratelimit.Lockout {
+	return ratelimit.NewLockout(memory.NewMemoryLockoutStore(0), ratelimit.LockoutPolicy{
+		Threshold:   3,
+		Duration:    time.Minute,
+		MaxDuration: time.Hour,
+	})
 }
 
 func generateRandomEmail() string {
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/util/tracing"
+)
+
+type tracedAuthUsecase struct {
+	next AuthUsecase
+}
+
+// WithTracing Usecaseの各メソッドの実行をスパンとして記録します
+func WithTracing(u AuthUsecase) AuthUsecase {
+	return &tracedAuthUsecase{u}
+}
+
+func (t *tracedAuthUsecase) SignUp(ctx context.Context, user domain.User) (string, error) {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.SignUp")
+	token, err := t.next.SignUp(ctx, user)
+	tracing.End(span, err)
+	return token, err
+}
+
+func (t *tracedAuthUsecase) SignIn(ctx context.Context, email string, password string) (string, error) {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.SignIn")
+	token, err := t.next.SignIn(ctx, email, password)
+	tracing.End(span, err)
+	return token, err
+}
@@ -0,0 +1,83 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	calendarRepository "github.com/Hajime3778/go-clean-arch/interface/database/calendar"
+	taskRepository "github.com/Hajime3778/go-clean-arch/interface/database/task"
+	"github.com/Hajime3778/go-clean-arch/util/string_util"
+)
+
+// tokenLength フィードのURLに含めるトークンの桁数
+const tokenLength = 32
+
+// pageSize フィード作成時に1回で取得するタスクの件数
+const pageSize int64 = 100
+
+type calendarUsecase struct {
+	feedRepo calendarRepository.CalendarFeedRepository
+	taskRepo taskRepository.TaskRepository
+}
+
+// NewCalendarUsecase カレンダーフィード機能のUsecaseオブジェクトを作成します
+func NewCalendarUsecase(feedRepo calendarRepository.CalendarFeedRepository, taskRepo taskRepository.TaskRepository) CalendarUsecase {
+	return &calendarUsecase{feedRepo, taskRepo}
+}
+
+// GetFeed ログインユーザーのフィードを取得します(存在しない場合は作成します)
+func (cu *calendarUsecase) GetFeed(ctx context.Context) (domain.CalendarFeed, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	feed, err := cu.feedRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, domain.ErrRecordNotFound) {
+		return cu.RotateFeed(ctx)
+	}
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	return feed, nil
+}
+
+// RotateFeed ログインユーザーのフィードのトークンを再発行します
+// 再発行前のURLは使用できなくなります
+func (cu *calendarUsecase) RotateFeed(ctx context.Context) (domain.CalendarFeed, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	feed := domain.CalendarFeed{
+		UserID: userID,
+		Token:  string_util.GenerateRundomString(tokenLength),
+	}
+	err = cu.feedRepo.Save(ctx, feed)
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	return feed, nil
+}
+
+// FindTasksByToken トークンに紐づくユーザーのタスクを全件取得します
+// FindByUserIDは期限とIDの順で取得するため、ページの境界でタスクが重複・欠落しません
+func (cu *calendarUsecase) FindTasksByToken(ctx context.Context, token string) ([]domain.Task, error) {
+	feed, err := cu.feedRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]domain.Task, 0)
+	for offset := int64(0); ; offset += pageSize {
+		page, err := cu.taskRepo.FindByUserID(ctx, feed.UserID, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, page...)
+		if int64(len(page)) < pageSize {
+			break
+		}
+	}
+	return tasks, nil
+}
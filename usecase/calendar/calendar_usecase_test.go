@@ -0,0 +1,148 @@
+package calendar_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	calendarMock "github.com/Hajime3778/go-clean-arch/interface/database/calendar/mock"
+	taskRepository "github.com/Hajime3778/go-clean-arch/interface/database/task"
+	taskMock "github.com/Hajime3778/go-clean-arch/interface/database/task/mock"
+	usecase "github.com/Hajime3778/go-clean-arch/usecase/calendar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFeed(t *testing.T) {
+	t.Run("正常系 既存のフィードが返却されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		mockFeed := domain.CalendarFeed{UserID: 1, Token: "token"}
+		feedRepo := &calendarMock.MockCalendarFeedRepo{
+			MockGetByUserID: func(ctx context.Context, userID int64) (domain.CalendarFeed, error) {
+				return mockFeed, nil
+			},
+		}
+		calendarUsecase := usecase.NewCalendarUsecase(feedRepo, &taskMock.MockTaskRepo{})
+		feed, err := calendarUsecase.GetFeed(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockFeed, feed)
+	})
+
+	t.Run("正常系 フィードが存在しない場合、新しいトークンで作成されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		var saved domain.CalendarFeed
+		feedRepo := &calendarMock.MockCalendarFeedRepo{
+			MockGetByUserID: func(ctx context.Context, userID int64) (domain.CalendarFeed, error) {
+				return domain.CalendarFeed{}, domain.ErrRecordNotFound
+			},
+			MockSave: func(ctx context.Context, feed domain.CalendarFeed) error {
+				saved = feed
+				return nil
+			},
+		}
+		calendarUsecase := usecase.NewCalendarUsecase(feedRepo, &taskMock.MockTaskRepo{})
+		feed, err := calendarUsecase.GetFeed(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), saved.UserID)
+		assert.Equal(t, 32, len(saved.Token))
+		assert.Equal(t, saved, feed)
+	})
+
+	t.Run("異常系 Repository実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		feedRepo := &calendarMock.MockCalendarFeedRepo{
+			MockGetByUserID: func(ctx context.Context, userID int64) (domain.CalendarFeed, error) {
+				return domain.CalendarFeed{}, domain.ErrInternalServerError
+			},
+		}
+		calendarUsecase := usecase.NewCalendarUsecase(feedRepo, &taskMock.MockTaskRepo{})
+		_, err := calendarUsecase.GetFeed(ctx)
+
+		assert.Equal(t, domain.ErrInternalServerError, err)
+	})
+}
+
+func TestRotateFeed(t *testing.T) {
+	t.Run("正常系 再発行のたびに異なるトークンとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		feedRepo := &calendarMock.MockCalendarFeedRepo{
+			MockSave: func(ctx context.Context, feed domain.CalendarFeed) error {
+				return nil
+			},
+		}
+		calendarUsecase := usecase.NewCalendarUsecase(feedRepo, &taskMock.MockTaskRepo{})
+		first, err := calendarUsecase.RotateFeed(ctx)
+		assert.NoError(t, err)
+		second, err := calendarUsecase.RotateFeed(ctx)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, first.Token, second.Token)
+	})
+}
+
+func TestFindTasksByToken(t *testing.T) {
+	t.Run("正常系 全ページのタスクが取得されること", func(t *testing.T) {
+		feedRepo := &calendarMock.MockCalendarFeedRepo{
+			MockGetByToken: func(ctx context.Context, token string) (domain.CalendarFeed, error) {
+				return domain.CalendarFeed{UserID: 7, Token: token}, nil
+			},
+		}
+		var offsets []int64
+		taskRepo := &taskMock.MockTaskRepo{
+			MockFindByUserID: func(ctx context.Context, userID int64, limit int64, offset int64) ([]domain.Task, error) {
+				assert.Equal(t, int64(7), userID)
+				offsets = append(offsets, offset)
+				if offset == 0 {
+					return make([]domain.Task, limit), nil
+				}
+				return make([]domain.Task, 3), nil
+			},
+		}
+		calendarUsecase := usecase.NewCalendarUsecase(feedRepo, taskRepo)
+		tasks, err := calendarUsecase.FindTasksByToken(context.TODO(), "token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 103, len(tasks))
+		assert.Equal(t, []int64{0, 100}, offsets)
+	})
+
+	t.Run("正常系 期限が同じタスクがページの境界で重複・欠落せずに取得されること", func(t *testing.T) {
+		feedRepo := &calendarMock.MockCalendarFeedRepo{
+			MockGetByToken: func(ctx context.Context, token string) (domain.CalendarFeed, error) {
+				return domain.CalendarFeed{UserID: 7, Token: token}, nil
+			},
+		}
+		taskRepo := taskRepository.NewMemoryTaskRepository()
+		dueDate := time.Date(2021, 12, 8, 9, 0, 0, 0, time.UTC)
+		ids := make([]int64, 0)
+		for i := 0; i < 105; i++ {
+			id, err := taskRepo.Create(context.TODO(), domain.Task{UserID: 7, Title: "title", DueDate: dueDate})
+			assert.NoError(t, err)
+			ids = append(ids, id)
+		}
+		calendarUsecase := usecase.NewCalendarUsecase(feedRepo, taskRepo)
+		tasks, err := calendarUsecase.FindTasksByToken(context.TODO(), "token")
+
+		assert.NoError(t, err)
+		got := make([]int64, 0, len(tasks))
+		for _, task := range tasks {
+			got = append(got, task.ID)
+		}
+		assert.Equal(t, ids, got)
+	})
+
+	t.Run("準正常系 存在しないトークンの場合、ErrRecordNotFoundエラーとなること", func(t *testing.T) {
+		feedRepo := &calendarMock.MockCalendarFeedRepo{
+			MockGetByToken: func(ctx context.Context, token string) (domain.CalendarFeed, error) {
+				return domain.CalendarFeed{}, domain.ErrRecordNotFound
+			},
+		}
+		calendarUsecase := usecase.NewCalendarUsecase(feedRepo, &taskMock.MockTaskRepo{})
+		tasks, err := calendarUsecase.FindTasksByToken(context.TODO(), "token")
+
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+		assert.Nil(t, tasks)
+	})
+}
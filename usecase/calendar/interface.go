@@ -0,0 +1,13 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+type CalendarUsecase interface {
+	GetFeed(ctx context.Context) (domain.CalendarFeed, error)
+	RotateFeed(ctx context.Context) (domain.CalendarFeed, error)
+	FindTasksByToken(ctx context.Context, token string) ([]domain.Task, error)
+}
@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	usecase "github.com/Hajime3778/go-clean-arch/usecase/calendar"
+)
+
+type MockCalendarUsecase struct {
+	usecase.CalendarUsecase
+	MockGetFeed          func(ctx context.Context) (domain.CalendarFeed, error)
+	MockRotateFeed       func(ctx context.Context) (domain.CalendarFeed, error)
+	MockFindTasksByToken func(ctx context.Context, token string) ([]domain.Task, error)
+}
+
+func (m *MockCalendarUsecase) GetFeed(ctx context.Context) (domain.CalendarFeed, error) {
+	return m.MockGetFeed(ctx)
+}
+
+func (m *MockCalendarUsecase) RotateFeed(ctx context.Context) (domain.CalendarFeed, error) {
+	return m.MockRotateFeed(ctx)
+}
+
+func (m *MockCalendarUsecase) FindTasksByToken(ctx context.Context, token string) ([]domain.Task, error) {
+	return m.MockFindTasksByToken(ctx, token)
+}
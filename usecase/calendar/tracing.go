@@ -0,0 +1,38 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/util/tracing"
+)
+
+type tracedCalendarUsecase struct {
+	next CalendarUsecase
+}
+
+// WithTracing Usecaseの各メソッドの実行をスパンとして記録します
+func WithTracing(u CalendarUsecase) CalendarUsecase {
+	return &tracedCalendarUsecase{u}
+}
+
+func (t *tracedCalendarUsecase) GetFeed(ctx context.Context) (domain.CalendarFeed, error) {
+	ctx, span := tracing.Start(ctx, "CalendarUsecase.GetFeed")
+	feed, err := t.next.GetFeed(ctx)
+	tracing.End(span, err)
+	return feed, err
+}
+
+func (t *tracedCalendarUsecase) RotateFeed(ctx context.Context) (domain.CalendarFeed, error) {
+	ctx, span := tracing.Start(ctx, "CalendarUsecase.RotateFeed")
+	feed, err := t.next.RotateFeed(ctx)
+	tracing.End(span, err)
+	return feed, err
+}
+
+func (t *tracedCalendarUsecase) FindTasksByToken(ctx context.Context, token string) ([]domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "CalendarUsecase.FindTasksByToken")
+	tasks, err := t.next.FindTasksByToken(ctx, token)
+	tracing.End(span, err)
+	return tasks, err
+}
@@ -0,0 +1,51 @@
+package task
+
+import (
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// diffTask 変更前後のタスクを比較し、値が異なる項目を返却します
+// 作成時はbefore, 削除時はafterにnilを指定します
+func diffTask(before *domain.Task, after *domain.Task) []domain.FieldChange {
+	fields := []struct {
+		name  string
+		value func(task *domain.Task) *string
+	}{
+		{"title", func(task *domain.Task) *string { return &task.Title }},
+		{"content", func(task *domain.Task) *string { return &task.Content }},
+		{"due_date", func(task *domain.Task) *string { return formatTime(task.DueDate) }},
+		{"completed_at", func(task *domain.Task) *string {
+			if task.CompletedAt == nil {
+				return nil
+			}
+			return formatTime(*task.CompletedAt)
+		}},
+	}
+
+	changes := make([]domain.FieldChange, 0)
+	for _, field := range fields {
+		var beforeValue, afterValue *string
+		if before != nil {
+			beforeValue = field.value(before)
+		}
+		if after != nil {
+			afterValue = field.value(after)
+		}
+		if beforeValue == nil && afterValue == nil {
+			continue
+		}
+		if beforeValue != nil && afterValue != nil && *beforeValue == *afterValue {
+			continue
+		}
+		changes = append(changes, domain.FieldChange{Field: field.name, Before: beforeValue, After: afterValue})
+	}
+	return changes
+}
+
+// formatTime 日時を変更履歴に記録する形式に変換します
+func formatTime(t time.Time) *string {
+	v := t.Format(time.RFC3339)
+	return &v
+}
@@ -10,6 +10,10 @@ type TaskUsecase interface {
 	FindByUserID(ctx context.Context, limit int64, offset int64) ([]domain.Task, error)
 	GetByID(ctx context.Context, id int64) (domain.Task, error)
 	Create(ctx context.Context, task domain.Task) error
-	Update(ctx context.Context, task domain.Task) error
+	Update(ctx context.Context, task domain.Task, completed *bool) error
 	Delete(ctx context.Context, id int64) error
+	History(ctx context.Context, id int64) ([]domain.TaskActivity, error)
+	Export(ctx context.Context, fn func(task domain.Task) error) error
+	Import(ctx context.Context, tasks []domain.Task) error
+	Stats(ctx context.Context, days int) (domain.TaskStats, error)
 }
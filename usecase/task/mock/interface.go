@@ -12,8 +12,12 @@ type MockTaskUsecase struct {
 	MockFindByUserID func(ctx context.Context, limit int64, offset int64) ([]domain.Task, error)
 	MockGetByID      func(ctx context.Context, id int64) (domain.Task, error)
 	MockCreate       func(ctx context.Context, task domain.Task) error
-	MockUpdate       func(ctx context.Context, task domain.Task) error
+	MockUpdate       func(ctx context.Context, task domain.Task, completed *bool) error
 	MockDelete       func(ctx context.Context, id int64) error
+	MockHistory      func(ctx context.Context, id int64) ([]domain.TaskActivity, error)
+	MockExport       func(ctx context.Context, fn func(task domain.Task) error) error
+	MockImport       func(ctx context.Context, tasks []domain.Task) error
+	MockStats        func(ctx context.Context, days int) (domain.TaskStats, error)
 }
 
 func (m *MockTaskUsecase) FindByUserID(ctx context.Context, limit int64, offset int64) ([]domain.Task, error) {
@@ -28,10 +32,26 @@ func (m *MockTaskUsecase) Create(ctx context.Context, task domain.Task) error {
 	return m.MockCreate(ctx, task)
 }
 
-func (m *MockTaskUsecase) Update(ctx context.Context, task domain.Task) error {
-	return m.MockUpdate(ctx, task)
+func (m *MockTaskUsecase) Update(ctx context.Context, task domain.Task, completed *bool) error {
+	return m.MockUpdate(ctx, task, completed)
 }
 
 func (m *MockTaskUsecase) Delete(ctx context.Context, id int64) error {
 	return m.MockDelete(ctx, id)
 }
+
+func (m *MockTaskUsecase) History(ctx context.Context, id int64) ([]domain.TaskActivity, error) {
+	return m.MockHistory(ctx, id)
+}
+
+func (m *MockTaskUsecase) Export(ctx context.Context, fn func(task domain.Task) error) error {
+	return m.MockExport(ctx, fn)
+}
+
+func (m *MockTaskUsecase) Import(ctx context.Context, tasks []domain.Task) error {
+	return m.MockImport(ctx, tasks)
+}
+
+func (m *MockTaskUsecase) Stats(ctx context.Context, days int) (domain.TaskStats, error) {
+	return m.MockStats(ctx, days)
+}
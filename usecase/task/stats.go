@@ -0,0 +1,44 @@
+package task
+
+import (
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+)
+
+// DefaultStatsDays, MaxStatsDays 完了数の推移を集計する日数の既定値と上限
+const (
+	DefaultStatsDays int = 30
+	MaxStatsDays     int = 365
+)
+
+// newStatsPeriod 集計に使用する日時の境界を計算します
+// 週は月曜日始まりとします
+func newStatsPeriod(now time.Time, days int) domain.StatsPeriod {
+	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekday := (int(todayStart.Weekday()) + 6) % 7
+	weekStart := todayStart.AddDate(0, 0, -weekday)
+	return domain.StatsPeriod{
+		Now:           now,
+		TodayStart:    todayStart,
+		TomorrowStart: todayStart.AddDate(0, 0, 1),
+		WeekStart:     weekStart,
+		WeekEnd:       weekStart.AddDate(0, 0, 7),
+		TrendStart:    todayStart.AddDate(0, 0, -(days - 1)),
+	}
+}
+
+// fillDailyCounts 完了したタスクがない日を0件として補い、start からdays日分の推移を作成します
+func fillDailyCounts(counts []domain.DailyCount, start time.Time, days int) []domain.DailyCount {
+	byDate := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		byDate[count.Date] = count.Count
+	}
+
+	filled := make([]domain.DailyCount, 0, days)
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i).Format(domain.DateLayout)
+		filled = append(filled, domain.DailyCount{Date: date, Count: byDate[date]})
+	}
+	return filled
+}
@@ -2,25 +2,45 @@ package task
 
 import (
 	"context"
-
-	"github.com/Hajime3778/go-clean-arch/domain/constant"
+	"log/slog"
+	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/interface/database"
+	activityRepository "github.com/Hajime3778/go-clean-arch/interface/database/activity"
+	attachmentRepository "github.com/Hajime3778/go-clean-arch/interface/database/attachment"
 	repository "github.com/Hajime3778/go-clean-arch/interface/database/task"
+	"github.com/Hajime3778/go-clean-arch/interface/storage"
+	"github.com/Hajime3778/go-clean-arch/util/metrics"
 )
 
+// exportPageSize エクスポート時に1回で取得するタスクの件数
+const exportPageSize int64 = 100
+
 type taskUsecase struct {
-	repo repository.TaskRepository
+	repo           repository.TaskRepository
+	activityRepo   activityRepository.ActivityRepository
+	attachmentRepo attachmentRepository.AttachmentRepository
+	blobStore      storage.BlobStore
+	transactor     database.Transactor
+	// location 集計の日付の境界に使用するタイムゾーン
+	location *time.Location
+	now      func() time.Time
 }
 
 // NewTaskUsecase タスク機能のUsecaseオブジェクトを作成します
-func NewTaskUsecase(repo repository.TaskRepository) TaskUsecase {
-	return &taskUsecase{repo}
+// タスクを削除する際に添付ファイルも削除するため、添付ファイルのRepositoryとBlobStoreを使用します
+// locationには、データベースが日付に変換する際と同じタイムゾーンを指定します
+func NewTaskUsecase(repo repository.TaskRepository, activityRepo activityRepository.ActivityRepository, attachmentRepo attachmentRepository.AttachmentRepository, blobStore storage.BlobStore, transactor database.Transactor, location *time.Location) TaskUsecase {
+	return &taskUsecase{repo, activityRepo, attachmentRepo, blobStore, transactor, location, time.Now}
 }
 
 // FindByUserID タスクをユーザーIDで複数件取得します
 func (tu *taskUsecase) FindByUserID(ctx context.Context, limit int64, offset int64) ([]domain.Task, error) {
-	userID := ctx.Value(constant.UserIDContextKey).(int64)
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	task, err := tu.repo.FindByUserID(ctx, userID, limit, offset)
 	if err != nil {
 		return nil, err
@@ -29,44 +49,244 @@ func (tu *taskUsecase) FindByUserID(ctx context.Context, limit int64, offset int
 }
 
 // GetByID IDでタスクを1件取得します
+// ログインユーザー以外のタスクの場合は、存在を明かさないようにErrRecordNotFoundを返却します
 func (tu *taskUsecase) GetByID(ctx context.Context, id int64) (domain.Task, error) {
-	task, err := tu.repo.GetByID(ctx, id)
+	task, err := tu.getOwnedTask(ctx, id)
 	if err != nil {
 		return domain.Task{}, err
 	}
 	return task, nil
 }
 
-// Create タスクを1件作成します
+// Create タスクを1件作成し、変更履歴を記録します
 func (tu *taskUsecase) Create(ctx context.Context, task domain.Task) error {
-	userID := ctx.Value(constant.UserIDContextKey).(int64)
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
 	task.UserID = userID
-	_, err := tu.repo.Create(ctx, task)
+	err = tu.transactor.Transaction(ctx, func(ctx context.Context) error {
+		return tu.create(ctx, task)
+	})
 	if err != nil {
 		return err
 	}
+	metrics.TasksCreatedTotal.WithLabelValues(metrics.SourceAPI).Inc()
 	return nil
 }
 
-// Update IDでタスクを1件更新します
-func (tu *taskUsecase) Update(ctx context.Context, task domain.Task) error {
-	_, err := tu.repo.GetByID(ctx, task.ID)
+// create タスクを1件作成し、変更履歴を記録します
+func (tu *taskUsecase) create(ctx context.Context, task domain.Task) error {
+	id, err := tu.repo.Create(ctx, task)
 	if err != nil {
 		return err
 	}
+	return tu.recordActivity(ctx, id, domain.ActivityCreate, diffTask(nil, &task))
+}
+
+// Update IDでタスクを1件更新し、変更履歴を記録します
+// ログインユーザーのタスクでない場合はErrRecordNotFoundを返却します
+// completedがnilの場合は完了状態を変更せず、trueの場合は完了、falseの場合は未完了にします
+// 完了済みのタスクを再度完了にした場合、完了日時は変更されません
+func (tu *taskUsecase) Update(ctx context.Context, task domain.Task, completed *bool) error {
+	newlyCompleted := false
+	err := tu.transactor.Transaction(ctx, func(ctx context.Context) error {
+		before, err := tu.getOwnedTask(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		task.UserID = before.UserID
 
-	err = tu.repo.Update(ctx, task)
+		task.CompletedAt = before.CompletedAt
+		if completed != nil {
+			switch {
+			case !*completed:
+				task.CompletedAt = nil
+			case before.CompletedAt == nil:
+				now := tu.now()
+				task.CompletedAt = &now
+				newlyCompleted = true
+			}
+		}
+
+		err = tu.repo.Update(ctx, task)
+		if err != nil {
+			return err
+		}
+		return tu.recordActivity(ctx, task.ID, domain.ActivityUpdate, diffTask(&before, &task))
+	})
 	if err != nil {
 		return err
 	}
+	if newlyCompleted {
+		metrics.TasksCompletedTotal.Inc()
+	}
 	return nil
 }
 
-// Delete IDでタスクを1件削除します
+// Delete IDでタスクを1件削除し、変更履歴を記録します
+// ログインユーザーのタスクでない場合はErrRecordNotFoundを返却します
+// 添付ファイルも削除します。ファイル本体はロールバックで復元できないため、コミット後に削除します
 func (tu *taskUsecase) Delete(ctx context.Context, id int64) error {
-	err := tu.repo.Delete(ctx, id)
+	var attachments []domain.Attachment
+	err := tu.transactor.Transaction(ctx, func(ctx context.Context) error {
+		before, err := tu.getOwnedTask(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		attachments, err = tu.attachmentRepo.FindByTaskID(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = tu.attachmentRepo.DeleteByTaskID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = tu.repo.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+		return tu.recordActivity(ctx, id, domain.ActivityDelete, diffTask(&before, nil))
+	})
 	if err != nil {
 		return err
 	}
+
+	for _, attachment := range attachments {
+		// タスクは削除済みのため、失敗した場合もログのみ出力します
+		err := tu.blobStore.Delete(ctx, attachment.StorageKey)
+		if err != nil {
+			slog.ErrorContext(ctx, "blob delete failed", "key", attachment.StorageKey, "error", err)
+		}
+	}
 	return nil
 }
+
+// History タスクの変更履歴を取得します
+// 削除したタスクの履歴も取得できるよう、所有者は変更履歴の操作者で確認します
+// タスクを変更できるのは所有者のみのため、すべての操作者がログインユーザーの場合のみ返却します
+func (tu *taskUsecase) History(ctx context.Context, id int64) ([]domain.TaskActivity, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	activities, err := tu.activityRepo.FindByTaskID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(activities) == 0 {
+		// 変更履歴を記録する前に作成されたタスクは、タスクの所有者を確認します
+		_, err := tu.getOwnedTask(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return activities, nil
+	}
+	for _, activity := range activities {
+		if activity.ActorID != userID {
+			return nil, domain.ErrRecordNotFound
+		}
+	}
+	return activities, nil
+}
+
+// getOwnedTask ログインユーザーのタスクを1件取得します
+// 他のユーザーのタスクの場合は、存在を推測できないようErrRecordNotFoundを返却します
+func (tu *taskUsecase) getOwnedTask(ctx context.Context, id int64) (domain.Task, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task, err := tu.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if task.UserID != userID {
+		return domain.Task{}, domain.ErrRecordNotFound
+	}
+	return task, nil
+}
+
+// Export ログインユーザーのタスクを全件、1件ずつfnに渡します
+// 全件をメモリに保持しないよう、exportPageSize件ずつ取得します
+func (tu *taskUsecase) Export(ctx context.Context, fn func(task domain.Task) error) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	for offset := int64(0); ; offset += exportPageSize {
+		tasks, err := tu.repo.FindByUserID(ctx, userID, exportPageSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			err = fn(task)
+			if err != nil {
+				return err
+			}
+		}
+		if int64(len(tasks)) < exportPageSize {
+			return nil
+		}
+	}
+}
+
+// Import 複数のタスクを1つのトランザクションで作成します
+// 1件でも失敗した場合は、すべての作成を取り消します
+func (tu *taskUsecase) Import(ctx context.Context, tasks []domain.Task) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = tu.transactor.Transaction(ctx, func(ctx context.Context) error {
+		for _, task := range tasks {
+			task.UserID = userID
+			err := tu.create(ctx, task)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	metrics.TasksCreatedTotal.WithLabelValues(metrics.SourceImport).Add(float64(len(tasks)))
+	return nil
+}
+
+// Stats ログインユーザーのタスクを集計します
+// 日付の境界は作成時に指定したタイムゾーンで計算し、完了数の推移は今日を含む直近days日分を返却します
+func (tu *taskUsecase) Stats(ctx context.Context, days int) (domain.TaskStats, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return domain.TaskStats{}, err
+	}
+	period := newStatsPeriod(tu.now().In(tu.location), days)
+
+	stats, err := tu.repo.Stats(ctx, userID, period)
+	if err != nil {
+		return domain.TaskStats{}, err
+	}
+	stats.CompletionTrend = fillDailyCounts(stats.CompletionTrend, period.TrendStart, days)
+	return stats, nil
+}
+
+// recordActivity ログインユーザーを操作者として変更履歴を記録します
+func (tu *taskUsecase) recordActivity(ctx context.Context, taskID int64, action string, changes []domain.FieldChange) error {
+	actorID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	activity := domain.TaskActivity{
+		TaskID:  taskID,
+		ActorID: actorID,
+		Action:  action,
+		Changes: changes,
+	}
+	_, err = tu.activityRepo.Create(ctx, activity)
+	return err
+}
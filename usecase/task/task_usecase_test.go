@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
-	"github.com/Hajime3778/go-clean-arch/domain/constant"
+	activityMock "github.com/Hajime3778/go-clean-arch/interface/database/activity/mock"
+	attachmentMock "github.com/Hajime3778/go-clean-arch/interface/database/attachment/mock"
+	mockSqlDriver "github.com/Hajime3778/go-clean-arch/interface/database/mock"
 	"github.com/Hajime3778/go-clean-arch/interface/database/task/mock"
+	storageMock "github.com/Hajime3778/go-clean-arch/interface/storage/mock"
 	usecase "github.com/Hajime3778/go-clean-arch/usecase/task"
 	"github.com/stretchr/testify/assert"
 )
@@ -17,14 +20,14 @@ func TestFindByUserID(t *testing.T) {
 	t.Run("正常系 指定したユーザーIDで取得", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTasks := createMockTasks(5, userID)
 		mockTaskRepo := &mock.MockTaskRepo{
 			MockFindByUserID: func(ctx context.Context, userID int64, limit int64, offset int64) ([]domain.Task, error) {
 				return mockTasks, nil
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
 		result, err := taskUsecase.FindByUserID(ctx, int64(1), int64(1))
 
 		assert.NoError(t, err)
@@ -33,25 +36,32 @@ func TestFindByUserID(t *testing.T) {
 	t.Run("異常系 Repository実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTaskRepo := &mock.MockTaskRepo{
 			MockFindByUserID: func(ctx context.Context, userID int64, limit int64, offset int64) ([]domain.Task, error) {
 				return nil, domain.ErrInternalServerError
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
 		result, err := taskUsecase.FindByUserID(ctx, int64(1), int64(1))
 
 		assert.Equal(t, domain.ErrInternalServerError, err)
 		assert.Nil(t, result)
 	})
+	t.Run("異常系 認証済みの利用者が設定されていない場合、ErrUnauthorizedエラーとなること", func(t *testing.T) {
+		taskUsecase := usecase.NewTaskUsecase(&mock.MockTaskRepo{}, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		result, err := taskUsecase.FindByUserID(context.TODO(), int64(1), int64(1))
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		assert.Nil(t, result)
+	})
 }
 
 func TestGetByID(t *testing.T) {
 	t.Run("正常系 存在するIDで1件取得", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTask := domain.Task{
 			ID:        1,
 			UserID:    1,
@@ -66,7 +76,7 @@ func TestGetByID(t *testing.T) {
 				return mockTask, nil
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
 		result, err := taskUsecase.GetByID(ctx, mockTask.ID)
 
 		assert.NoError(t, err)
@@ -76,31 +86,53 @@ func TestGetByID(t *testing.T) {
 	t.Run("異常系 Repository実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTaskRepo := &mock.MockTaskRepo{
 			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
 				return domain.Task{}, domain.ErrRecordNotFound
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
 		result, err := taskUsecase.GetByID(ctx, int64(1))
 
 		assert.Equal(t, domain.ErrRecordNotFound, err)
 		assert.Equal(t, domain.Task{}, result)
 	})
+
+	t.Run("準正常系 他のユーザーのタスクの場合、ErrRecordNotFoundエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 2})
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{ID: id, UserID: 1, Title: "test title"}, nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		result, err := taskUsecase.GetByID(ctx, int64(1))
+
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+		assert.Equal(t, domain.Task{}, result)
+	})
+
+	t.Run("異常系 認証済みの利用者が設定されていない場合、ErrUnauthorizedエラーとなること", func(t *testing.T) {
+		taskUsecase := usecase.NewTaskUsecase(&mock.MockTaskRepo{}, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		result, err := taskUsecase.GetByID(context.TODO(), int64(1))
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		assert.Equal(t, domain.Task{}, result)
+	})
 }
 
 func TestCreate(t *testing.T) {
 	t.Run("正常系 1件追加", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTaskRepo := &mock.MockTaskRepo{
 			MockCreate: func(ctx context.Context, task domain.Task) (int64, error) {
 				return 1, nil
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
 		err := taskUsecase.Create(ctx, domain.Task{})
 
 		assert.NoError(t, err)
@@ -109,13 +141,13 @@ func TestCreate(t *testing.T) {
 	t.Run("異常系 Repository実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTaskRepo := &mock.MockTaskRepo{
 			MockCreate: func(ctx context.Context, task domain.Task) (int64, error) {
 				return 0, domain.ErrInternalServerError
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
 		err := taskUsecase.Create(ctx, domain.Task{})
 
 		assert.Equal(t, domain.ErrInternalServerError, err)
@@ -126,32 +158,77 @@ func TestUpdate(t *testing.T) {
 	t.Run("正常系 1件更新", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTaskRepo := &mock.MockTaskRepo{
 			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
-				return domain.Task{}, nil
+				return domain.Task{UserID: userID}, nil
 			},
 			MockUpdate: func(ctx context.Context, task domain.Task) error {
 				return nil
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
-		err := taskUsecase.Update(ctx, domain.Task{})
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		err := taskUsecase.Update(ctx, domain.Task{}, nil)
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("正常系 完了状態が指定どおりに更新されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		completedAt := time.Date(2021, 12, 5, 20, 30, 0, 0, time.UTC)
+		completed, notCompleted := true, false
+		cases := []struct {
+			name      string
+			before    *time.Time
+			completed *bool
+			expected  func(t *testing.T, got *time.Time)
+		}{
+			{"未完了のタスクを完了にした場合、現在日時が設定されること", nil, &completed, func(t *testing.T, got *time.Time) {
+				assert.NotNil(t, got)
+				assert.WithinDuration(t, time.Now(), *got, time.Minute)
+			}},
+			{"完了済みのタスクを完了にした場合、完了日時が変わらないこと", &completedAt, &completed, func(t *testing.T, got *time.Time) {
+				assert.Equal(t, &completedAt, got)
+			}},
+			{"完了済みのタスクを未完了にした場合、完了日時が削除されること", &completedAt, &notCompleted, func(t *testing.T, got *time.Time) {
+				assert.Nil(t, got)
+			}},
+			{"完了状態を指定しない場合、完了日時が変わらないこと", &completedAt, nil, func(t *testing.T, got *time.Time) {
+				assert.Equal(t, &completedAt, got)
+			}},
+		}
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				var updated domain.Task
+				mockTaskRepo := &mock.MockTaskRepo{
+					MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+						return domain.Task{ID: id, UserID: 1, CompletedAt: c.before}, nil
+					},
+					MockUpdate: func(ctx context.Context, task domain.Task) error {
+						updated = task
+						return nil
+					},
+				}
+				taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+				err := taskUsecase.Update(ctx, domain.Task{ID: 1}, c.completed)
+
+				assert.NoError(t, err)
+				c.expected(t, updated.CompletedAt)
+			})
+		}
+	})
+
 	t.Run("異常系 存在しないIDが指定された場合、エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTaskRepo := &mock.MockTaskRepo{
 			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
 				return domain.Task{}, domain.ErrRecordNotFound
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
-		err := taskUsecase.Update(ctx, domain.Task{})
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		err := taskUsecase.Update(ctx, domain.Task{}, nil)
 
 		assert.Equal(t, domain.ErrRecordNotFound, err)
 	})
@@ -159,54 +236,497 @@ func TestUpdate(t *testing.T) {
 	t.Run("異常系 Repository実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTaskRepo := &mock.MockTaskRepo{
 			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
-				return domain.Task{}, nil
+				return domain.Task{UserID: userID}, nil
 			},
 			MockUpdate: func(ctx context.Context, task domain.Task) error {
 				return domain.ErrInternalServerError
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
-		err := taskUsecase.Update(ctx, domain.Task{})
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		err := taskUsecase.Update(ctx, domain.Task{}, nil)
 
 		assert.Equal(t, domain.ErrInternalServerError, err)
 	})
+
+	t.Run("準正常系 他のユーザーのタスクの場合、更新されずにErrRecordNotFoundエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 2})
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{ID: id, UserID: 1}, nil
+			},
+			MockUpdate: func(ctx context.Context, task domain.Task) error {
+				t.Fatal("他のユーザーのタスクは更新しないこと")
+				return nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		err := taskUsecase.Update(ctx, domain.Task{ID: 1}, nil)
+
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+	})
 }
 
 func TestDelete(t *testing.T) {
 	t.Run("正常系 1件削除", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{ID: id, UserID: userID}, nil
+			},
 			MockDelete: func(ctx context.Context, id int64) error {
 				return nil
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
 		err := taskUsecase.Delete(ctx, int64(1))
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("正常系 添付ファイルの情報と本体が削除されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{ID: id, UserID: 1}, nil
+			},
+			MockDelete: func(ctx context.Context, id int64) error {
+				return nil
+			},
+		}
+		var deletedTaskID int64
+		attachmentRepo := &attachmentMock.MockAttachmentRepo{
+			MockFindByTaskID: func(ctx context.Context, taskID int64) ([]domain.Attachment, error) {
+				return []domain.Attachment{{ID: 1, StorageKey: "tasks/1/a"}, {ID: 2, StorageKey: "tasks/1/b"}}, nil
+			},
+			MockDeleteByTaskID: func(ctx context.Context, taskID int64) error {
+				deletedTaskID = taskID
+				return nil
+			},
+		}
+		deletedKeys := make([]string, 0)
+		blobStore := &storageMock.MockBlobStore{
+			MockDelete: func(ctx context.Context, key string) error {
+				deletedKeys = append(deletedKeys, key)
+				return nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), attachmentRepo, blobStore, newTransactor(), time.UTC)
+		err := taskUsecase.Delete(ctx, int64(1))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deletedTaskID)
+		assert.Equal(t, []string{"tasks/1/a", "tasks/1/b"}, deletedKeys)
+	})
+
 	t.Run("異常系 Repository実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
 		ctx := context.TODO()
 		userID := int64(1)
-		ctx = context.WithValue(ctx, constant.UserIDContextKey, userID)
+		ctx = domain.WithPrincipal(ctx, domain.Principal{UserID: userID})
 		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{ID: id, UserID: 1}, nil
+			},
 			MockDelete: func(ctx context.Context, id int64) error {
 				return domain.ErrInternalServerError
 			},
 		}
-		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo)
+		blobStore := &storageMock.MockBlobStore{
+			MockDelete: func(ctx context.Context, key string) error {
+				t.Fatal("ロールバックした場合はファイル本体を削除しないこと")
+				return nil
+			},
+		}
+		attachmentRepo := newAttachmentRepo()
+		attachmentRepo.MockFindByTaskID = func(ctx context.Context, taskID int64) ([]domain.Attachment, error) {
+			return []domain.Attachment{{ID: 1, StorageKey: "tasks/1/a"}}, nil
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), attachmentRepo, blobStore, newTransactor(), time.UTC)
+		err := taskUsecase.Delete(ctx, int64(1))
+
+		assert.Equal(t, domain.ErrInternalServerError, err)
+	})
+
+	t.Run("準正常系 他のユーザーのタスクの場合、削除されずにErrRecordNotFoundエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 2})
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{ID: id, UserID: 1}, nil
+			},
+			MockDelete: func(ctx context.Context, id int64) error {
+				t.Fatal("他のユーザーのタスクは削除しないこと")
+				return nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		err := taskUsecase.Delete(ctx, int64(1))
+
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+	})
+}
+
+func TestActivity(t *testing.T) {
+	t.Run("正常系 作成時に全項目の変更履歴が操作者とともに記録されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		var recorded domain.TaskActivity
+		mockActivityRepo := &activityMock.MockActivityRepo{
+			MockCreate: func(ctx context.Context, activity domain.TaskActivity) (int64, error) {
+				recorded = activity
+				return 1, nil
+			},
+		}
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockCreate: func(ctx context.Context, task domain.Task) (int64, error) {
+				return 10, nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, mockActivityRepo, newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		err := taskUsecase.Create(ctx, domain.Task{Title: "title", Content: "content"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), recorded.TaskID)
+		assert.Equal(t, int64(1), recorded.ActorID)
+		assert.Equal(t, domain.ActivityCreate, recorded.Action)
+		assert.Equal(t, 3, len(recorded.Changes))
+		assert.Nil(t, recorded.Changes[0].Before)
+		assert.Equal(t, "title", *recorded.Changes[0].After)
+	})
+
+	t.Run("正常系 更新時に変更された項目のみ変更前後の値が記録されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 2})
+		beforeDueDate := time.Date(2021, 12, 5, 20, 30, 0, 0, time.UTC)
+		afterDueDate := beforeDueDate.Add(time.Hour)
+		var recorded domain.TaskActivity
+		mockActivityRepo := &activityMock.MockActivityRepo{
+			MockCreate: func(ctx context.Context, activity domain.TaskActivity) (int64, error) {
+				recorded = activity
+				return 1, nil
+			},
+		}
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{ID: id, UserID: 2, Title: "title", Content: "content", DueDate: beforeDueDate}, nil
+			},
+			MockUpdate: func(ctx context.Context, task domain.Task) error {
+				return nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, mockActivityRepo, newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		err := taskUsecase.Update(ctx, domain.Task{ID: 1, Title: "title", Content: "content", DueDate: afterDueDate}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), recorded.ActorID)
+		assert.Equal(t, domain.ActivityUpdate, recorded.Action)
+		assert.Equal(t, 1, len(recorded.Changes))
+		assert.Equal(t, "due_date", recorded.Changes[0].Field)
+		assert.Equal(t, "2021-12-05T20:30:00Z", *recorded.Changes[0].Before)
+		assert.Equal(t, "2021-12-05T21:30:00Z", *recorded.Changes[0].After)
+	})
+
+	t.Run("異常系 変更履歴の記録に失敗した場合、トランザクションがエラーで終了すること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		var txErr error
+		transactor := &mockSqlDriver.MockTransactor{
+			MockTransaction: func(ctx context.Context, fn func(context.Context) error) error {
+				txErr = fn(ctx)
+				return txErr
+			},
+		}
+		mockActivityRepo := &activityMock.MockActivityRepo{
+			MockCreate: func(ctx context.Context, activity domain.TaskActivity) (int64, error) {
+				return 0, domain.ErrInternalServerError
+			},
+		}
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{ID: id, UserID: 1}, nil
+			},
+			MockDelete: func(ctx context.Context, id int64) error {
+				return nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, mockActivityRepo, newAttachmentRepo(), &storageMock.MockBlobStore{}, transactor, time.UTC)
 		err := taskUsecase.Delete(ctx, int64(1))
 
 		assert.Equal(t, domain.ErrInternalServerError, err)
+		assert.Equal(t, domain.ErrInternalServerError, txErr)
 	})
 }
 
+func TestHistory(t *testing.T) {
+	t.Run("正常系 変更履歴を取得できること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		mockActivities := []domain.TaskActivity{{ID: 1, TaskID: 1, ActorID: 1, Action: domain.ActivityCreate}}
+		mockActivityRepo := &activityMock.MockActivityRepo{
+			MockFindByTaskID: func(ctx context.Context, taskID int64) ([]domain.TaskActivity, error) {
+				return mockActivities, nil
+			},
+		}
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{ID: id, UserID: 1}, nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, mockActivityRepo, newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		result, err := taskUsecase.History(ctx, int64(1))
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockActivities, result)
+	})
+
+	t.Run("正常系 削除したタスクの変更履歴を取得できること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		mockActivities := []domain.TaskActivity{
+			{ID: 1, TaskID: 1, ActorID: 1, Action: domain.ActivityCreate},
+			{ID: 2, TaskID: 1, ActorID: 1, Action: domain.ActivityDelete},
+		}
+		mockActivityRepo := &activityMock.MockActivityRepo{
+			MockFindByTaskID: func(ctx context.Context, taskID int64) ([]domain.TaskActivity, error) {
+				return mockActivities, nil
+			},
+		}
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{}, domain.ErrRecordNotFound
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, mockActivityRepo, newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		result, err := taskUsecase.History(ctx, int64(1))
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockActivities, result)
+	})
+
+	t.Run("準正常系 他のユーザーが操作したタスクの場合、ErrRecordNotFoundエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 2})
+		mockActivityRepo := &activityMock.MockActivityRepo{
+			MockFindByTaskID: func(ctx context.Context, taskID int64) ([]domain.TaskActivity, error) {
+				return []domain.TaskActivity{{ID: 1, TaskID: 1, ActorID: 1, Action: domain.ActivityCreate}}, nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(&mock.MockTaskRepo{}, mockActivityRepo, newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		result, err := taskUsecase.History(ctx, int64(1))
+
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("準正常系 変更履歴がない他のユーザーのタスクの場合、ErrRecordNotFoundエラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 2})
+		mockActivityRepo := &activityMock.MockActivityRepo{
+			MockFindByTaskID: func(ctx context.Context, taskID int64) ([]domain.TaskActivity, error) {
+				return []domain.TaskActivity{}, nil
+			},
+		}
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				return domain.Task{ID: id, UserID: 1}, nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, mockActivityRepo, newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		result, err := taskUsecase.History(ctx, int64(1))
+
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestExport(t *testing.T) {
+	t.Run("正常系 ページングしながら全件がfnに渡されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		mockTasks := createMockTasks(150, 1)
+		var offsets []int64
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockFindByUserID: func(ctx context.Context, userID int64, limit int64, offset int64) ([]domain.Task, error) {
+				offsets = append(offsets, offset)
+				end := offset + limit
+				if end > int64(len(mockTasks)) {
+					end = int64(len(mockTasks))
+				}
+				return mockTasks[offset:end], nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+
+		exported := make([]domain.Task, 0)
+		err := taskUsecase.Export(ctx, func(task domain.Task) error {
+			exported = append(exported, task)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockTasks, exported)
+		assert.Equal(t, []int64{0, 100}, offsets)
+	})
+
+	t.Run("異常系 fnがエラーを返却した場合、処理を中断すること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockFindByUserID: func(ctx context.Context, userID int64, limit int64, offset int64) ([]domain.Task, error) {
+				return createMockTasks(3, 1), nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+
+		count := 0
+		err := taskUsecase.Export(ctx, func(task domain.Task) error {
+			count++
+			return domain.ErrInternalServerError
+		})
+
+		assert.Equal(t, domain.ErrInternalServerError, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestImport(t *testing.T) {
+	t.Run("正常系 1つのトランザクションで全件作成されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		created := make([]domain.Task, 0)
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockCreate: func(ctx context.Context, task domain.Task) (int64, error) {
+				created = append(created, task)
+				return int64(len(created)), nil
+			},
+		}
+		transactions := 0
+		transactor := &mockSqlDriver.MockTransactor{
+			MockTransaction: func(ctx context.Context, fn func(context.Context) error) error {
+				transactions++
+				return fn(ctx)
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, transactor, time.UTC)
+		err := taskUsecase.Import(ctx, []domain.Task{{Title: "a"}, {Title: "b"}})
+
+		assert.NoError(t, err)
+		assert.Len(t, created, 2)
+		assert.Equal(t, int64(1), created[1].UserID)
+		// 全件がImportで開始した1つのトランザクションで作成されます
+		assert.Equal(t, 1, transactions)
+	})
+
+	t.Run("異常系 途中で失敗した場合、エラーが返却され以降は作成されないこと", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		calls := 0
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockCreate: func(ctx context.Context, task domain.Task) (int64, error) {
+				calls++
+				return 0, domain.ErrInternalServerError
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		err := taskUsecase.Import(ctx, []domain.Task{{Title: "a"}, {Title: "b"}})
+
+		assert.Equal(t, domain.ErrInternalServerError, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestStats(t *testing.T) {
+	t.Run("正常系 日付の境界が計算され、完了のない日が0件で補われること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		var gotPeriod domain.StatsPeriod
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockStats: func(ctx context.Context, userID int64, period domain.StatsPeriod) (domain.TaskStats, error) {
+				gotPeriod = period
+				today := period.TodayStart.Format(domain.DateLayout)
+				return domain.TaskStats{
+					ByStatus:        domain.TaskStatusCounts{Open: 1, Completed: 2},
+					CompletionTrend: []domain.DailyCount{{Date: today, Count: 2}},
+				}, nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		result, err := taskUsecase.Stats(ctx, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TaskStatusCounts{Open: 1, Completed: 2}, result.ByStatus)
+
+		assert.Equal(t, gotPeriod.TodayStart.AddDate(0, 0, 1), gotPeriod.TomorrowStart)
+		assert.Equal(t, time.Monday, gotPeriod.WeekStart.Weekday())
+		assert.Equal(t, gotPeriod.WeekStart.AddDate(0, 0, 7), gotPeriod.WeekEnd)
+		assert.False(t, gotPeriod.TodayStart.Before(gotPeriod.WeekStart))
+		assert.True(t, gotPeriod.TodayStart.Before(gotPeriod.WeekEnd))
+		assert.Equal(t, gotPeriod.TodayStart.AddDate(0, 0, -6), gotPeriod.TrendStart)
+
+		assert.Len(t, result.CompletionTrend, 7)
+		assert.Equal(t, gotPeriod.TrendStart.Format(domain.DateLayout), result.CompletionTrend[0].Date)
+		assert.Equal(t, int64(0), result.CompletionTrend[0].Count)
+		assert.Equal(t, domain.DailyCount{Date: gotPeriod.TodayStart.Format(domain.DateLayout), Count: 2}, result.CompletionTrend[6])
+	})
+
+	t.Run("正常系 指定したタイムゾーンの日付の境界で集計されること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		// JSTと日付がずれるタイムゾーンを指定します
+		location := time.FixedZone("UTC-10", -10*60*60)
+		var gotPeriod domain.StatsPeriod
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockStats: func(ctx context.Context, userID int64, period domain.StatsPeriod) (domain.TaskStats, error) {
+				gotPeriod = period
+				return domain.TaskStats{}, nil
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), location)
+		result, err := taskUsecase.Stats(ctx, 7)
+
+		assert.NoError(t, err)
+		now := gotPeriod.Now
+		assert.Equal(t, location, now.Location())
+		assert.Equal(t, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location), gotPeriod.TodayStart)
+		assert.Equal(t, location, gotPeriod.WeekStart.Location())
+		assert.Equal(t, location, gotPeriod.TrendStart.Location())
+		assert.Equal(t, now.Format(domain.DateLayout), result.CompletionTrend[6].Date)
+	})
+
+	t.Run("異常系 Repository実行時にエラーが発生した場合、エラーとなること", func(t *testing.T) {
+		ctx := domain.WithPrincipal(context.TODO(), domain.Principal{UserID: 1})
+		mockTaskRepo := &mock.MockTaskRepo{
+			MockStats: func(ctx context.Context, userID int64, period domain.StatsPeriod) (domain.TaskStats, error) {
+				return domain.TaskStats{}, domain.ErrInternalServerError
+			},
+		}
+		taskUsecase := usecase.NewTaskUsecase(mockTaskRepo, newActivityRepo(), newAttachmentRepo(), &storageMock.MockBlobStore{}, newTransactor(), time.UTC)
+		_, err := taskUsecase.Stats(ctx, 7)
+
+		assert.Equal(t, domain.ErrInternalServerError, err)
+	})
+}
+
+// newActivityRepo 変更履歴の記録に成功するモックを作成します
+func newActivityRepo() *activityMock.MockActivityRepo {
+	return &activityMock.MockActivityRepo{
+		MockCreate: func(ctx context.Context, activity domain.TaskActivity) (int64, error) {
+			return 1, nil
+		},
+	}
+}
+
+// newAttachmentRepo 添付ファイルがないタスクとして振る舞うモックを作成します
+func newAttachmentRepo() *attachmentMock.MockAttachmentRepo {
+	return &attachmentMock.MockAttachmentRepo{
+		MockFindByTaskID: func(ctx context.Context, taskID int64) ([]domain.Attachment, error) {
+			return []domain.Attachment{}, nil
+		},
+		MockDeleteByTaskID: func(ctx context.Context, taskID int64) error {
+			return nil
+		},
+	}
+}
+
+// newTransactor fnをそのまま実行するモックを作成します
+func newTransactor() *mockSqlDriver.MockTransactor {
+	return &mockSqlDriver.MockTransactor{
+		MockTransaction: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+}
+
 // createMockTasks モックのタスクを指定したユーザーIDで作成します
 func createMockTasks(num int, userID int64) []domain.Task {
 	mockTasks := make([]domain.Task, 0)
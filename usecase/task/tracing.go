@@ -0,0 +1,80 @@
+package task
+
+import (
+	"context"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	"github.com/Hajime3778/go-clean-arch/util/tracing"
+)
+
+type tracedTaskUsecase struct {
+	next TaskUsecase
+}
+
+// WithTracing Usecaseの各メソッドの実行をスパンとして記録します
+func WithTracing(u TaskUsecase) TaskUsecase {
+	return &tracedTaskUsecase{u}
+}
+
+func (t *tracedTaskUsecase) FindByUserID(ctx context.Context, limit int64, offset int64) ([]domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUsecase.FindByUserID")
+	tasks, err := t.next.FindByUserID(ctx, limit, offset)
+	tracing.End(span, err)
+	return tasks, err
+}
+
+func (t *tracedTaskUsecase) GetByID(ctx context.Context, id int64) (domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUsecase.GetByID")
+	task, err := t.next.GetByID(ctx, id)
+	tracing.End(span, err)
+	return task, err
+}
+
+func (t *tracedTaskUsecase) Create(ctx context.Context, task domain.Task) error {
+	ctx, span := tracing.Start(ctx, "TaskUsecase.Create")
+	err := t.next.Create(ctx, task)
+	tracing.End(span, err)
+	return err
+}
+
+func (t *tracedTaskUsecase) Update(ctx context.Context, task domain.Task, completed *bool) error {
+	ctx, span := tracing.Start(ctx, "TaskUsecase.Update")
+	err := t.next.Update(ctx, task, completed)
+	tracing.End(span, err)
+	return err
+}
+
+func (t *tracedTaskUsecase) Delete(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "TaskUsecase.Delete")
+	err := t.next.Delete(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+func (t *tracedTaskUsecase) History(ctx context.Context, id int64) ([]domain.TaskActivity, error) {
+	ctx, span := tracing.Start(ctx, "TaskUsecase.History")
+	activities, err := t.next.History(ctx, id)
+	tracing.End(span, err)
+	return activities, err
+}
+
+func (t *tracedTaskUsecase) Export(ctx context.Context, fn func(task domain.Task) error) error {
+	ctx, span := tracing.Start(ctx, "TaskUsecase.Export")
+	err := t.next.Export(ctx, fn)
+	tracing.End(span, err)
+	return err
+}
+
+func (t *tracedTaskUsecase) Import(ctx context.Context, tasks []domain.Task) error {
+	ctx, span := tracing.Start(ctx, "TaskUsecase.Import")
+	err := t.next.Import(ctx, tasks)
+	tracing.End(span, err)
+	return err
+}
+
+func (t *tracedTaskUsecase) Stats(ctx context.Context, days int) (domain.TaskStats, error) {
+	ctx, span := tracing.Start(ctx, "TaskUsecase.Stats")
+	stats, err := t.next.Stats(ctx, days)
+	tracing.End(span, err)
+	return stats, err
+}
@@ -0,0 +1,41 @@
+package task_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/domain"
+	usecase "github.com/Hajime3778/go-clean-arch/usecase/task"
+	"github.com/Hajime3778/go-clean-arch/usecase/task/mock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	t.Run("正常系 メソッドの実行がスパンとして記録され、エラーが設定されること", func(t *testing.T) {
+		var spanContext trace.SpanContext
+		mockUsecase := &mock.MockTaskUsecase{
+			MockGetByID: func(ctx context.Context, id int64) (domain.Task, error) {
+				spanContext = trace.SpanContextFromContext(ctx)
+				return domain.Task{}, domain.ErrRecordNotFound
+			},
+		}
+		_, err := usecase.WithTracing(mockUsecase).GetByID(context.TODO(), 1)
+
+		assert.Equal(t, domain.ErrRecordNotFound, err)
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "TaskUsecase.GetByID", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, spans[0].SpanContext().SpanID(), spanContext.SpanID())
+	})
+}
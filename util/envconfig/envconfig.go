@@ -0,0 +1,109 @@
+// Package envconfig キーと文字列の値で表される設定を型に変換します
+package envconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lookup キーに対応する設定値を返却します。未設定の場合は空文字を返却します
+// 環境変数から読み込む場合は os.Getenv をそのまま指定できます
+type Lookup func(key string) string
+
+// Map: mapの値をLookupとして返却します
+func Map(values map[string]string) Lookup {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+// Parser Lookupの設定値を型に変換します
+// 変換に失敗した場合は既定値を返却し、最初のエラーをErrで返却します
+type Parser struct {
+	lookup Lookup
+	err    error
+}
+
+// NewParser Parserオブジェクトを作成します
+func NewParser(lookup Lookup) *Parser {
+	return &Parser{lookup: lookup}
+}
+
+// String: 設定値を返却します。未設定の場合はdefを返却します
+func (p *Parser) String(key string, def string) string {
+	if value := p.lookup(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// Int: 設定値を整数として返却します。未設定の場合はdefを返却します
+func (p *Parser) Int(key string, def int) int {
+	value := p.lookup(key)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		p.Invalid(key, value)
+		return def
+	}
+	return n
+}
+
+// Duration: 設定値をGoのDuration形式(例: 30s)として返却します。未設定の場合はdefを返却します
+func (p *Parser) Duration(key string, def time.Duration) time.Duration {
+	value := p.lookup(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		p.Invalid(key, value)
+		return def
+	}
+	return d
+}
+
+// Bool: 設定値を真偽値として返却します。未設定の場合はdefを返却します
+func (p *Parser) Bool(key string, def bool) bool {
+	value := p.lookup(key)
+	if value == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		p.Invalid(key, value)
+		return def
+	}
+	return b
+}
+
+// List: カンマ区切りの設定値を分割し、空の要素を除いて返却します。未設定の場合はdefを返却します
+func (p *Parser) List(key string, def []string) []string {
+	var list []string
+	for _, v := range strings.Split(p.lookup(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	if len(list) == 0 {
+		return def
+	}
+	return list
+}
+
+// Invalid: 設定値が不正であることを記録します
+// 独自の形式の設定値を変換する場合に使用します
+func (p *Parser) Invalid(key string, value string) {
+	if p.err == nil {
+		p.err = fmt.Errorf("invalid %s: '%s'", key, value)
+	}
+}
+
+// Err: 変換で最初に発生したエラーを返却します
+func (p *Parser) Err() error {
+	return p.err
+}
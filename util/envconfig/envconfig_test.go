@@ -0,0 +1,41 @@
+package envconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Hajime3778/go-clean-arch/util/envconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser(t *testing.T) {
+	t.Run("正常系 設定値が型に変換されること", func(t *testing.T) {
+		p := envconfig.NewParser(envconfig.Map(map[string]string{
+			"NAME":     "foo",
+			"COUNT":    "3",
+			"TIMEOUT":  "1m30s",
+			"ENABLED":  "true",
+			"ORIGINS":  " https://a.example.com, ,https://b.example.com ",
+			"OVERRIDE": "",
+		}))
+
+		assert.Equal(t, "foo", p.String("NAME", "bar"))
+		assert.Equal(t, 3, p.Int("COUNT", 1))
+		assert.Equal(t, 90*time.Second, p.Duration("TIMEOUT", time.Second))
+		assert.True(t, p.Bool("ENABLED", false))
+		assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, p.List("ORIGINS", nil))
+		assert.Equal(t, "default", p.String("OVERRIDE", "default"))
+		assert.NoError(t, p.Err())
+	})
+
+	t.Run("異常系 形式が不正な場合、最初のエラーが返却されること", func(t *testing.T) {
+		p := envconfig.NewParser(envconfig.Map(map[string]string{
+			"COUNT":   "three",
+			"TIMEOUT": "30",
+		}))
+
+		assert.Equal(t, 1, p.Int("COUNT", 1))
+		assert.Equal(t, time.Second, p.Duration("TIMEOUT", time.Second))
+		assert.EqualError(t, p.Err(), "invalid COUNT: 'three'")
+	})
+}
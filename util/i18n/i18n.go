@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Language メッセージの言語を表すBCP 47の基本言語タグ
+type Language string
+
+// Japanese, English メッセージカタログを用意している言語
+const (
+	Japanese Language = "ja"
+	English  Language = "en"
+)
+
+// DefaultLanguage contextに言語が設定されていない場合に使用する言語
+const DefaultLanguage = Japanese
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs 言語ごとのメッセージカタログ
+// キーはメッセージのID、値はfmt形式のテンプレートです
+var catalogs = loadCatalogs()
+
+func loadCatalogs() map[Language]map[string]string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	catalogs := make(map[Language]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var messages map[string]string
+		err = json.Unmarshal(data, &messages)
+		if err != nil {
+			panic(fmt.Sprintf("invalid message catalog %s: %v", entry.Name(), err))
+		}
+		catalogs[Language(strings.TrimSuffix(entry.Name(), ".json"))] = messages
+	}
+	return catalogs
+}
+
+// Supported 言語のメッセージカタログがあるかどうかを判定します
+func Supported(lang Language) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Translate キーに対応するメッセージをlangで作成します
+// langにキーがない場合は英語のメッセージを使用し、どちらにもない場合はfalseを返却します
+func Translate(lang Language, key string, args ...interface{}) (string, bool) {
+	template, ok := catalogs[lang][key]
+	if !ok {
+		template, ok = catalogs[English][key]
+		if !ok {
+			return "", false
+		}
+	}
+	if len(args) == 0 {
+		return template, true
+	}
+	return fmt.Sprintf(template, args...), true
+}
+
+// Negotiate Accept-Languageヘッダーから、対応する言語のうち最も優先度の高い言語を返却します
+// "ja-JP"のような地域付きのタグは基本言語で判定し、対応する言語がない場合はdefaultLanguageを返却します
+func Negotiate(acceptLanguage string, defaultLanguage Language) Language {
+	type candidate struct {
+		lang    Language
+		quality float64
+	}
+	candidates := make([]candidate, 0)
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+		if quality <= 0 {
+			continue
+		}
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		lang := Language(base)
+		if base == "*" {
+			lang = defaultLanguage
+		}
+		if !Supported(lang) {
+			continue
+		}
+		candidates = append(candidates, candidate{lang, quality})
+	}
+	if len(candidates) == 0 {
+		return defaultLanguage
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].lang
+}
+
+type languageKey struct{}
+
+// WithLanguage レスポンスの言語をcontextに設定します
+func WithLanguage(ctx context.Context, lang Language) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// FromContext contextからレスポンスの言語を取得します
+// 設定されていない場合はDefaultLanguageを返却します
+func FromContext(ctx context.Context) Language {
+	lang, ok := ctx.Value(languageKey{}).(Language)
+	if !ok {
+		return DefaultLanguage
+	}
+	return lang
+}
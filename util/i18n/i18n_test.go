@@ -0,0 +1,103 @@
+package i18n_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Hajime3778/go-clean-arch/util/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Run("正常系 指定した言語のメッセージに値が埋め込まれること", func(t *testing.T) {
+		message, ok := i18n.Translate(i18n.Japanese, "validation.max", "50")
+		assert.True(t, ok)
+		assert.Equal(t, "50文字以内で入力してください", message)
+
+		message, ok = i18n.Translate(i18n.English, "validation.max", "50")
+		assert.True(t, ok)
+		assert.Equal(t, "must be at most 50 characters", message)
+	})
+
+	t.Run("準正常系 カタログがない言語の場合、英語のメッセージとなること", func(t *testing.T) {
+		message, ok := i18n.Translate("fr", "error.not_found")
+		assert.True(t, ok)
+		assert.Equal(t, "record not found", message)
+	})
+
+	t.Run("準正常系 キーが存在しない場合、falseが返却されること", func(t *testing.T) {
+		_, ok := i18n.Translate(i18n.Japanese, "unknown.key")
+		assert.False(t, ok)
+	})
+}
+
+func TestCatalogs(t *testing.T) {
+	t.Run("正常系 すべての言語のカタログが同じキーを持つこと", func(t *testing.T) {
+		files, err := filepath.Glob(filepath.Join("locales", "*.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var expected []string
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var messages map[string]string
+			if err := json.Unmarshal(data, &messages); err != nil {
+				t.Fatal(err)
+			}
+			keys := make([]string, 0, len(messages))
+			for key := range messages {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			if expected == nil {
+				expected = keys
+				continue
+			}
+			assert.Equal(t, expected, keys, file)
+		}
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       i18n.Language
+	}{
+		{"未送信の場合、既定の言語となること", "", i18n.Japanese},
+		{"地域付きのタグの場合、基本言語で判定されること", "en-US", i18n.English},
+		{"品質値が最も高い言語が選ばれること", "ja;q=0.5, en;q=0.9", i18n.English},
+		{"品質値が同じ場合、先に指定された言語が選ばれること", "en, ja", i18n.English},
+		{"対応していない言語は無視されること", "fr-FR, ja;q=0.1", i18n.Japanese},
+		{"品質値が0の言語は選ばれないこと", "en;q=0", i18n.Japanese},
+		{"対応する言語がない場合、既定の言語となること", "fr, de", i18n.Japanese},
+	}
+	for _, tt := range tests {
+		t.Run("正常系 "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, i18n.Negotiate(tt.acceptLanguage, i18n.Japanese))
+		})
+	}
+
+	t.Run("正常系 指定した既定の言語が使用されること", func(t *testing.T) {
+		assert.Equal(t, i18n.English, i18n.Negotiate("fr, de", i18n.English))
+		assert.Equal(t, i18n.English, i18n.Negotiate("*", i18n.English))
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("正常系 設定された言語が取得できること", func(t *testing.T) {
+		ctx := i18n.WithLanguage(context.Background(), i18n.English)
+		assert.Equal(t, i18n.English, i18n.FromContext(ctx))
+	})
+
+	t.Run("正常系 設定されていない場合、既定の言語となること", func(t *testing.T) {
+		assert.Equal(t, i18n.DefaultLanguage, i18n.FromContext(context.Background()))
+	})
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace メトリクス名の接頭辞
+const namespace = "go_clean_arch"
+
+var (
+	// HTTPRequestsTotal ルート、メソッド、ステータスコードごとのリクエスト数
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by route, method and status.",
+	}, []string{"route", "method", "status"})
+
+	// HTTPRequestDuration ルート、メソッド、ステータスコードごとのリクエストの処理時間
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// DBQueryDuration リポジトリのメソッドごとのクエリの実行時間
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency by repository method and result.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "result"})
+
+	// SignUpsTotal 新規登録したユーザー数
+	SignUpsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_ups_total",
+		Help:      "Number of users signed up.",
+	})
+
+	// SignInsTotal 結果ごとのサインイン数
+	SignInsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_ins_total",
+		Help:      "Number of sign-in attempts by result.",
+	}, []string{"result"})
+
+	// TasksCreatedTotal 作成方法ごとの作成されたタスク数
+	TasksCreatedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_created_total",
+		Help:      "Number of tasks created by source.",
+	}, []string{"source"})
+
+	// TasksCompletedTotal 完了したタスク数
+	TasksCompletedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_completed_total",
+		Help:      "Number of tasks marked as completed.",
+	})
+)
+
+// TasksCreatedTotalのsource
+const (
+	SourceAPI    = "api"
+	SourceImport = "import"
+)
+
+// SignInsTotalのresult
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+	ResultLocked  = "locked"
+)
+
+func init() {
+	prometheus.MustRegister(
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		DBQueryDuration,
+		SignUpsTotal,
+		SignInsTotal,
+		TasksCreatedTotal,
+		TasksCompletedTotal,
+	)
+}
+
+// RegisterDBStats コネクションプールの統計情報をメトリクスとして登録します
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	err := prometheus.Register(collectors.NewDBStatsCollector(db, dbName))
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		return nil
+	}
+	return err
+}
+
+// Handler Prometheusのテキスト形式でメトリクスを出力するHandlerを返却します
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -1,15 +1,14 @@
 package token
 
 import (
-	"os"
 	"time"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
 	"github.com/form3tech-oss/jwt-go"
 )
 
-// GenerateAccessToken アクセストークンを発行します
-func GenerateAccessToken(user domain.User) string {
+// GenerateAccessToken secretKeyで署名したアクセストークンを発行します
+func GenerateAccessToken(user domain.User, secretKey []byte) string {
 	claims := domain.Claims{
 		UserID:   user.ID,
 		UserName: user.Name,
@@ -19,6 +18,6 @@ func GenerateAccessToken(user domain.User) string {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, _ := token.SignedString([]byte(os.Getenv("SECRET_KEY")))
+	tokenString, _ := token.SignedString(secretKey)
 	return tokenString
 }
@@ -1,7 +1,6 @@
 package token_test
 
 import (
-	"os"
 	"testing"
 
 	"github.com/Hajime3778/go-clean-arch/domain"
@@ -10,15 +9,18 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// secretKey テストでアクセストークンの署名に使用する鍵
+var secretKey = []byte("test_secret_key_0123456789abcdef")
+
 func TestGenerateRundomString(t *testing.T) {
 	t.Run("正常系 トークンの値が正しいこと", func(t *testing.T) {
 		user := domain.User{
 			ID:   1,
 			Name: "test name",
 		}
-		tokenString := token.GenerateAccessToken(user)
+		tokenString := token.GenerateAccessToken(user, secretKey)
 		token, _ := jwt.ParseWithClaims(tokenString, &domain.Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("SECRET_KEY")), nil
+			return secretKey, nil
 		})
 		claims := token.Claims.(*domain.Claims)
 		assert.Equal(t, user.ID, claims.UserID)
@@ -0,0 +1,27 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName スパンを作成するTracerの名前
+const instrumentationName = "github.com/Hajime3778/go-clean-arch"
+
+// Start ctxのスパンを親としてスパンを開始します
+// TracerProviderが設定されていない場合は、何も記録しないスパンとなります
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End errがある場合はスパンにエラーを記録し、スパンを終了します
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}